@@ -1,12 +1,155 @@
 package config
 
+import "time"
+
 type Config struct {
-	HTTPPort string   `mapstructure:"HTTP_PORT"`
-	BaseURL  string   `mapstructure:"BASE_URL"`
-	LogLevel string   `mapstructure:"LOG_LEVEL"`
-	DB       DBConfig `mapstructure:"DB"`
+	HTTPPort       string              `mapstructure:"HTTP_PORT"`
+	BaseURL        string              `mapstructure:"BASE_URL"`
+	TrustedProxies []string            `mapstructure:"TRUSTED_PROXIES"`
+	LogLevel       string              `mapstructure:"LOG_LEVEL"`
+	DB             DBConfig            `mapstructure:"DB"`
+	Auth           AuthConfig          `mapstructure:"AUTH"`
+	Comments       CommentsConfig      `mapstructure:"COMMENTS"`
+	Search         SearchConfig        `mapstructure:"SEARCH"`
+	RateLimit      RateLimitConfig     `mapstructure:"RATE_LIMIT"`
+	Filters        FiltersConfig       `mapstructure:"FILTERS"`
+	Moderation     ModerationConfig    `mapstructure:"MODERATION"`
+	Events         EventsConfig        `mapstructure:"EVENTS"`
+	WebSocket      WebSocketConfig     `mapstructure:"WEBSOCKET"`
+	Webhooks       WebhooksConfig      `mapstructure:"WEBHOOKS"`
+	Notifications  NotificationsConfig `mapstructure:"NOTIFICATIONS"`
+	Markdown       MarkdownConfig      `mapstructure:"MARKDOWN"`
+	Reactions      ReactionsConfig     `mapstructure:"REACTIONS"`
 }
 
 type DBConfig struct {
 	DSN string `mapstructure:"DSN"`
 }
+
+type AuthConfig struct {
+	APIKeys     []APIKeyConfig `mapstructure:"API_KEYS"`
+	APIKeysFile string         `mapstructure:"API_KEYS_FILE"`
+}
+
+type APIKeyConfig struct {
+	Key   string `mapstructure:"KEY"`
+	User  string `mapstructure:"USER"`
+	Role  string `mapstructure:"ROLE"`
+	Email string `mapstructure:"EMAIL"`
+}
+
+type CommentsConfig struct {
+	MaxDepth int `mapstructure:"MAX_DEPTH"`
+}
+
+type SearchConfig struct {
+	Backend         string      `mapstructure:"BACKEND"`
+	DefaultLanguage string      `mapstructure:"DEFAULT_LANGUAGE"`
+	FuzzyThreshold  float64     `mapstructure:"FUZZY_THRESHOLD"`
+	Bleve           BleveConfig `mapstructure:"BLEVE"`
+}
+
+type BleveConfig struct {
+	Path           string `mapstructure:"PATH"`
+	BatchSize      int    `mapstructure:"BATCH_SIZE"`
+	ReindexOnStart bool   `mapstructure:"REINDEX_ON_START"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool          `mapstructure:"ENABLED"`
+	IdleTTL time.Duration `mapstructure:"IDLE_TTL"`
+	Write   LimitConfig   `mapstructure:"WRITE"`
+	Read    LimitConfig   `mapstructure:"READ"`
+}
+
+type LimitConfig struct {
+	RPS   float64 `mapstructure:"RPS"`
+	Burst int     `mapstructure:"BURST"`
+}
+
+type ModerationConfig struct {
+	ReportThreshold int `mapstructure:"REPORT_THRESHOLD"`
+	MaxPinned       int `mapstructure:"MAX_PINNED"`
+}
+
+type FiltersConfig struct {
+	Blocklist  BlocklistConfig  `mapstructure:"BLOCKLIST"`
+	Links      LinksConfig      `mapstructure:"LINKS"`
+	Duplicates DuplicatesConfig `mapstructure:"DUPLICATES"`
+	Bayes      BayesConfig      `mapstructure:"BAYES"`
+}
+
+type BlocklistConfig struct {
+	Words    []string `mapstructure:"WORDS"`
+	Patterns []string `mapstructure:"PATTERNS"`
+	Action   string   `mapstructure:"ACTION"`
+}
+
+type LinksConfig struct {
+	Max    int    `mapstructure:"MAX"`
+	Action string `mapstructure:"ACTION"`
+}
+
+type DuplicatesConfig struct {
+	Window time.Duration `mapstructure:"WINDOW"`
+	Action string        `mapstructure:"ACTION"`
+}
+
+type BayesConfig struct {
+	SpamCorpus      string  `mapstructure:"SPAM_CORPUS"`
+	HamCorpus       string  `mapstructure:"HAM_CORPUS"`
+	HoldThreshold   float64 `mapstructure:"HOLD_THRESHOLD"`
+	RejectThreshold float64 `mapstructure:"REJECT_THRESHOLD"`
+}
+
+type EventsConfig struct {
+	ReplayBuffer     int           `mapstructure:"REPLAY_BUFFER"`
+	SubscriberBuffer int           `mapstructure:"SUBSCRIBER_BUFFER"`
+	Heartbeat        time.Duration `mapstructure:"HEARTBEAT"`
+	PollInterval     time.Duration `mapstructure:"POLL_INTERVAL"`
+	GapTimeout       time.Duration `mapstructure:"GAP_TIMEOUT"`
+	Retention        time.Duration `mapstructure:"RETENTION"`
+}
+
+type WebSocketConfig struct {
+	PingInterval     time.Duration `mapstructure:"PING_INTERVAL"`
+	SendBuffer       int           `mapstructure:"SEND_BUFFER"`
+	MaxSubscriptions int           `mapstructure:"MAX_SUBSCRIPTIONS"`
+	MaxMessageSize   int64         `mapstructure:"MAX_MESSAGE_SIZE"`
+}
+
+type WebhooksConfig struct {
+	Enabled      bool          `mapstructure:"ENABLED"`
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	BatchSize    int           `mapstructure:"BATCH_SIZE"`
+	Timeout      time.Duration `mapstructure:"TIMEOUT"`
+	MaxAttempts  int           `mapstructure:"MAX_ATTEMPTS"`
+	BackoffBase  time.Duration `mapstructure:"BACKOFF_BASE"`
+	BackoffMax   time.Duration `mapstructure:"BACKOFF_MAX"`
+}
+
+type NotificationsConfig struct {
+	Sender       string        `mapstructure:"SENDER"`
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	BatchSize    int           `mapstructure:"BATCH_SIZE"`
+	MaxAttempts  int           `mapstructure:"MAX_ATTEMPTS"`
+	BackoffBase  time.Duration `mapstructure:"BACKOFF_BASE"`
+	SMTP         SMTPConfig    `mapstructure:"SMTP"`
+}
+
+type SMTPConfig struct {
+	Host     string        `mapstructure:"HOST"`
+	Port     int           `mapstructure:"PORT"`
+	Username string        `mapstructure:"USERNAME"`
+	Password string        `mapstructure:"PASSWORD"`
+	From     string        `mapstructure:"FROM"`
+	Timeout  time.Duration `mapstructure:"TIMEOUT"`
+}
+
+type MarkdownConfig struct {
+	CacheSize int `mapstructure:"CACHE_SIZE"`
+}
+
+type ReactionsConfig struct {
+	Allowed []string `mapstructure:"ALLOWED"`
+}
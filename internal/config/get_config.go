@@ -2,10 +2,14 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/wb-go/wbf/config"
+
+	"github.com/sunr3d/comment-tree/models"
 )
 
 func GetConfig(path string) (*Config, error) {
@@ -16,6 +20,58 @@ func GetConfig(path string) (*Config, error) {
 	cfg.SetDefault("HTTP_PORT", "8080")
 	cfg.SetDefault("BASE_URL", "http://localhost:8080")
 	cfg.SetDefault("LOG_LEVEL", "info")
+	cfg.SetDefault("TRUSTED_PROXIES", []string{})
+	cfg.SetDefault("AUTH.API_KEYS_FILE", "")
+	cfg.SetDefault("COMMENTS.MAX_DEPTH", 0)
+	cfg.SetDefault("SEARCH.BACKEND", "postgres")
+	cfg.SetDefault("SEARCH.DEFAULT_LANGUAGE", "ru")
+	cfg.SetDefault("SEARCH.FUZZY_THRESHOLD", 0.3)
+	cfg.SetDefault("SEARCH.BLEVE.PATH", "data/search.bleve")
+	cfg.SetDefault("SEARCH.BLEVE.BATCH_SIZE", 500)
+	cfg.SetDefault("SEARCH.BLEVE.REINDEX_ON_START", true)
+	cfg.SetDefault("RATE_LIMIT.ENABLED", true)
+	cfg.SetDefault("RATE_LIMIT.IDLE_TTL", "10m")
+	cfg.SetDefault("RATE_LIMIT.WRITE.RPS", 0.2)
+	cfg.SetDefault("RATE_LIMIT.WRITE.BURST", 5)
+	cfg.SetDefault("RATE_LIMIT.READ.RPS", 10)
+	cfg.SetDefault("RATE_LIMIT.READ.BURST", 30)
+	cfg.SetDefault("MODERATION.REPORT_THRESHOLD", 3)
+	cfg.SetDefault("MODERATION.MAX_PINNED", 3)
+	cfg.SetDefault("EVENTS.REPLAY_BUFFER", 1000)
+	cfg.SetDefault("EVENTS.SUBSCRIBER_BUFFER", 64)
+	cfg.SetDefault("EVENTS.HEARTBEAT", "15s")
+	cfg.SetDefault("EVENTS.POLL_INTERVAL", "5s")
+	cfg.SetDefault("EVENTS.GAP_TIMEOUT", "5s")
+	cfg.SetDefault("EVENTS.RETENTION", "24h")
+	cfg.SetDefault("WEBSOCKET.PING_INTERVAL", "30s")
+	cfg.SetDefault("WEBSOCKET.SEND_BUFFER", 64)
+	cfg.SetDefault("WEBSOCKET.MAX_SUBSCRIPTIONS", 20)
+	cfg.SetDefault("WEBSOCKET.MAX_MESSAGE_SIZE", 4096)
+	cfg.SetDefault("WEBHOOKS.ENABLED", true)
+	cfg.SetDefault("WEBHOOKS.POLL_INTERVAL", "2s")
+	cfg.SetDefault("WEBHOOKS.BATCH_SIZE", 50)
+	cfg.SetDefault("WEBHOOKS.TIMEOUT", "10s")
+	cfg.SetDefault("WEBHOOKS.MAX_ATTEMPTS", 8)
+	cfg.SetDefault("WEBHOOKS.BACKOFF_BASE", "10s")
+	cfg.SetDefault("WEBHOOKS.BACKOFF_MAX", "1h")
+	cfg.SetDefault("NOTIFICATIONS.SENDER", "none")
+	cfg.SetDefault("NOTIFICATIONS.POLL_INTERVAL", "5s")
+	cfg.SetDefault("NOTIFICATIONS.BATCH_SIZE", 50)
+	cfg.SetDefault("NOTIFICATIONS.MAX_ATTEMPTS", 5)
+	cfg.SetDefault("NOTIFICATIONS.BACKOFF_BASE", "1m")
+	cfg.SetDefault("NOTIFICATIONS.SMTP.PORT", 25)
+	cfg.SetDefault("NOTIFICATIONS.SMTP.TIMEOUT", "10s")
+	cfg.SetDefault("MARKDOWN.CACHE_SIZE", 10000)
+	cfg.SetDefault("REACTIONS.ALLOWED", []string{"👍", "👎", "❤️", "😂", "🎉", "🤔"})
+	cfg.SetDefault("FILTERS.BLOCKLIST.ACTION", "reject")
+	cfg.SetDefault("FILTERS.LINKS.MAX", 3)
+	cfg.SetDefault("FILTERS.LINKS.ACTION", "hold")
+	cfg.SetDefault("FILTERS.DUPLICATES.WINDOW", "10m")
+	cfg.SetDefault("FILTERS.DUPLICATES.ACTION", "reject")
+	cfg.SetDefault("FILTERS.BAYES.SPAM_CORPUS", "filters/spam.txt")
+	cfg.SetDefault("FILTERS.BAYES.HAM_CORPUS", "filters/ham.txt")
+	cfg.SetDefault("FILTERS.BAYES.HOLD_THRESHOLD", 0.8)
+	cfg.SetDefault("FILTERS.BAYES.REJECT_THRESHOLD", 0.97)
 
 	var c Config
 	if err := cfg.Unmarshal(&c); err != nil {
@@ -31,6 +87,70 @@ func GetConfig(path string) (*Config, error) {
 	if strings.TrimSpace(c.DB.DSN) == "" {
 		return nil, fmt.Errorf("DB.DSN не может быть пустым")
 	}
+	// Ключи не хранятся в config.yml: их можно передать отдельным файлом (например, секретом)
+	if strings.TrimSpace(c.Auth.APIKeysFile) == "" {
+		c.Auth.APIKeysFile = strings.TrimSpace(os.Getenv("API_KEYS_FILE"))
+	}
+	if c.Auth.APIKeysFile != "" {
+		keys, err := loadAPIKeys(c.Auth.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		c.Auth.APIKeys = append(c.Auth.APIKeys, keys...)
+	}
+	for _, k := range c.Auth.APIKeys {
+		if strings.TrimSpace(k.Key) == "" {
+			return nil, fmt.Errorf("AUTH.API_KEYS: ключ не может быть пустым")
+		}
+	}
+	for _, proxy := range c.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return nil, fmt.Errorf("TRUSTED_PROXIES: некорректный адрес или подсеть %q", proxy)
+			}
+		}
+	}
+	// Интервалы тикеров: time.NewTicker паникует на неположительном значении
+	for _, interval := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"EVENTS.HEARTBEAT", c.Events.Heartbeat},
+		{"EVENTS.POLL_INTERVAL", c.Events.PollInterval},
+		{"WEBSOCKET.PING_INTERVAL", c.WebSocket.PingInterval},
+		{"WEBHOOKS.POLL_INTERVAL", c.Webhooks.PollInterval},
+		{"NOTIFICATIONS.POLL_INTERVAL", c.Notifications.PollInterval},
+	} {
+		if interval.d <= 0 {
+			return nil, fmt.Errorf("%s должен быть больше 0", interval.name)
+		}
+	}
+	if !models.ValidLanguage(c.Search.DefaultLanguage) {
+		return nil, fmt.Errorf("SEARCH.DEFAULT_LANGUAGE: язык %q не поддерживается", c.Search.DefaultLanguage)
+	}
+	if c.Search.Backend != "postgres" && c.Search.Backend != "bleve" {
+		return nil, fmt.Errorf("SEARCH.BACKEND: неизвестный движок поиска %q, допустимо: postgres, bleve", c.Search.Backend)
+	}
+	if c.Search.FuzzyThreshold <= 0 || c.Search.FuzzyThreshold > 1 {
+		return nil, fmt.Errorf("SEARCH.FUZZY_THRESHOLD должен быть в интервале (0, 1]")
+	}
 
 	return &c, nil
 }
+
+// loadAPIKeys читает список API_KEYS из отдельного файла в формате config.yml.
+func loadAPIKeys(path string) ([]APIKeyConfig, error) {
+	cfg := config.New()
+	if err := cfg.Load(path); err != nil {
+		return nil, fmt.Errorf("AUTH.API_KEYS_FILE: не удалось прочитать %q: %w", path, err)
+	}
+
+	var keys struct {
+		APIKeys []APIKeyConfig `mapstructure:"API_KEYS"`
+	}
+	if err := cfg.Unmarshal(&keys); err != nil {
+		return nil, fmt.Errorf("AUTH.API_KEYS_FILE: cfg.Unmarshal: %w", err)
+	}
+
+	return keys.APIKeys, nil
+}
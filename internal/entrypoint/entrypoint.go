@@ -5,14 +5,28 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/wb-go/wbf/zlog"
 
 	"github.com/sunr3d/comment-tree/internal/config"
 	httphandlers "github.com/sunr3d/comment-tree/internal/handlers"
+	"github.com/sunr3d/comment-tree/internal/infra/eventhub"
+	"github.com/sunr3d/comment-tree/internal/infra/mailer"
+	"github.com/sunr3d/comment-tree/internal/infra/memlimiter"
 	"github.com/sunr3d/comment-tree/internal/infra/postgres"
+	"github.com/sunr3d/comment-tree/internal/infra/webhook"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/services/authorsvc"
 	"github.com/sunr3d/comment-tree/internal/services/commenttreesvc"
+	"github.com/sunr3d/comment-tree/internal/services/contentfilter"
+	"github.com/sunr3d/comment-tree/internal/services/markdown"
+	"github.com/sunr3d/comment-tree/internal/services/moderationsvc"
+	"github.com/sunr3d/comment-tree/internal/services/notificationsvc"
+	"github.com/sunr3d/comment-tree/internal/services/reactionsvc"
+	"github.com/sunr3d/comment-tree/internal/services/searchsvc"
+	"github.com/sunr3d/comment-tree/internal/services/webhooksvc"
 )
 
 func Run(cfg *config.Config) error {
@@ -25,11 +39,65 @@ func Run(cfg *config.Config) error {
 		zlog.Logger.Error().Err(err).Msg("posgres.New")
 		return fmt.Errorf("postgres.New(): %w", err)
 	}
+	var limiter infra.RateLimiter
+	if cfg.RateLimit.Enabled {
+		limiter = memlimiter.New(cfg.RateLimit.IdleTTL)
+	}
+	// События пишутся в outbox в транзакции изменения и пересылаются оттуда в локальный хаб
+	events := eventhub.New(cfg.Events.ReplayBuffer, cfg.Events.SubscriberBuffer)
+	if err := postgres.StartEventRelay(appCtx, cfg.DB, cfg.Events, events); err != nil {
+		zlog.Logger.Error().Err(err).Msg("postgres.StartEventRelay")
+		return fmt.Errorf("postgres.StartEventRelay(): %w", err)
+	}
+
+	var searcher infra.Searcher
+	if cfg.Search.Backend == "bleve" {
+		// Встроенный индекс синхронизируется по событиям об изменениях комментариев
+		searcher, err = newBleveSearcher(appCtx, cfg.Search.Bleve, repo, events)
+		if err != nil {
+			zlog.Logger.Error().Err(err).Msg("newBleveSearcher")
+			return fmt.Errorf("newBleveSearcher(): %w", err)
+		}
+	} else {
+		searcher, err = postgres.NewSearcher(appCtx, cfg.DB)
+		if err != nil {
+			zlog.Logger.Error().Err(err).Msg("postgres.NewSearcher")
+			return fmt.Errorf("postgres.NewSearcher(): %w", err)
+		}
+	}
+
 	// Сервисный слой
-	svc := commenttreesvc.New(repo)
+	filter, err := contentfilter.New(cfg.Filters, repo)
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("contentfilter.New")
+		return fmt.Errorf("contentfilter.New(): %w", err)
+	}
+	var sender infra.NotificationSender
+	if cfg.Notifications.Sender == "smtp" {
+		sender = mailer.NewSMTP(cfg.Notifications.SMTP, cfg.BaseURL)
+	}
+	emails := make(map[string]string, len(cfg.Auth.APIKeys))
+	for _, k := range cfg.Auth.APIKeys {
+		if k.User != "" && k.Email != "" {
+			emails[strings.ToLower(k.User)] = k.Email
+		}
+	}
+	notifications := notificationsvc.New(repo, sender, cfg.Notifications, emails)
+	go notifications.Run(appCtx)
+
+	svc := commenttreesvc.New(repo, filter, notifications, cfg.Comments.MaxDepth, cfg.Search.DefaultLanguage)
+	moderation := moderationsvc.New(repo, notifications, cfg.Moderation.ReportThreshold, cfg.Moderation.MaxPinned)
+	webhooks := webhooksvc.New(repo, webhook.New(cfg.Webhooks.Timeout), cfg.Webhooks)
+	if cfg.Webhooks.Enabled {
+		go webhooks.Run(appCtx)
+	}
+	reactions := reactionsvc.New(repo, cfg.Reactions.Allowed)
+	search := searchsvc.New(searcher, cfg.Search.FuzzyThreshold)
+	authors := authorsvc.New(repo)
+	renderer := markdown.New(cfg.Markdown.CacheSize)
 
 	// REST API (HTTP) + Middleware
-	h := httphandlers.New(svc)
+	h := httphandlers.New(svc, moderation, webhooks, notifications, reactions, search, authors, renderer, limiter, events, cfg)
 	engine := h.RegisterHandlers()
 
 	// Server
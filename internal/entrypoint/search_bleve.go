@@ -0,0 +1,27 @@
+//go:build bleve
+
+package entrypoint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/infra/blevesearch"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+)
+
+func newBleveSearcher(
+	ctx context.Context,
+	cfg config.BleveConfig,
+	repo infra.Database,
+	events infra.EventHub,
+) (infra.Searcher, error) {
+	searcher, err := blevesearch.New(cfg, repo)
+	if err != nil {
+		return nil, fmt.Errorf("blevesearch.New: %w", err)
+	}
+	go searcher.Run(ctx, events)
+
+	return searcher, nil
+}
@@ -0,0 +1,17 @@
+//go:build !bleve
+
+package entrypoint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+)
+
+// newBleveSearcher - заглушка для сборки без Bleve: встроенный индекс тянет тяжелые зависимости,
+// поэтому он подключается тегом сборки bleve.
+func newBleveSearcher(context.Context, config.BleveConfig, infra.Database, infra.EventHub) (infra.Searcher, error) {
+	return nil, fmt.Errorf("сервис собран без поддержки Bleve, соберите его с -tags bleve")
+}
@@ -0,0 +1,107 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func (h *Handler) getAuthor(c *ginext.Context) {
+	id, ok := parseIDParam(c, "id", "некорректный id автора")
+	if !ok {
+		return
+	}
+
+	author, err := h.authors.GetAuthor(c.Request.Context(), id)
+	if err != nil {
+		h.authorError(c, err, "authors.GetAuthor")
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuthorResp(author))
+}
+
+func (h *Handler) updateAuthor(c *ginext.Context) {
+	id, ok := parseIDParam(c, "id", "некорректный id автора")
+	if !ok {
+		return
+	}
+
+	var req updateAuthorReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return
+	}
+
+	author := &models.Author{
+		ID:          id,
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarURL,
+	}
+	if err := h.authors.UpdateAuthor(c.Request.Context(), author, actorFrom(c)); err != nil {
+		h.authorError(c, err, "authors.UpdateAuthor")
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuthorResp(author))
+}
+
+func (h *Handler) getAuthorComments(c *ginext.Context) {
+	id, ok := parseIDParam(c, "id", "некорректный id автора")
+	if !ok {
+		return
+	}
+
+	var req authorCommentsReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if req.Page < 0 || req.Limit < 0 || req.Limit > 100 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректные параметры пагинации"})
+		return
+	}
+
+	result, err := h.authors.GetAuthorComments(c.Request.Context(), id, &models.PagParam{
+		Page:   req.Page,
+		Limit:  req.Limit,
+		Viewer: actorFrom(c),
+	})
+	if err != nil {
+		h.authorError(c, err, "authors.GetAuthorComments")
+		return
+	}
+
+	h.sendCommentsResp(c, result)
+}
+
+func (h *Handler) authorError(c *ginext.Context, err error, op string) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": "автор не найден"})
+	case strings.Contains(err.Error(), "нет прав"):
+		c.JSON(http.StatusForbidden, ginext.H{"error": "профиль может менять только сам автор или модератор"})
+	case strings.Contains(err.Error(), "некорректн"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": err.Error()})
+	default:
+		zlog.Logger.Error().Err(err).Msg(op)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+	}
+}
+
+func toAuthorResp(a *models.Author) authorResp {
+	return authorResp{
+		ID:           a.ID,
+		Username:     a.Username,
+		DisplayName:  a.DisplayName,
+		AvatarURL:    a.AvatarURL,
+		CommentCount: a.CommentCount,
+		CreatedAt:    a.CreatedAt,
+		UpdatedAt:    a.UpdatedAt,
+	}
+}
@@ -0,0 +1,120 @@
+package httphandlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func (h *Handler) streamComments(c *ginext.Context) {
+	if h.events == nil {
+		c.JSON(http.StatusServiceUnavailable, ginext.H{"error": "живые обновления отключены"})
+		return
+	}
+
+	var req streamReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if req.Parent < 0 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "id родительского комментария должен быть больше 0"})
+		return
+	}
+
+	if len(req.Thread) > 255 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "ключ треда не может быть длиннее 255 символов"})
+		return
+	}
+
+	lastEventID, ok := parseLastEventID(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.events.Subscribe(models.EventFilter{Thread: req.Thread, Parent: req.Parent}, lastEventID)
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("events.Subscribe")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	// Если подписчик не успевает читать, хаб закрывает канал. Браузер переподключится сам
+	// и получит пропущенные события по Last-Event-ID.
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-sub.Events():
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{
+				Id:    strconv.FormatInt(event.ID, 10),
+				Event: string(event.Type),
+				Data:  h.toEventDTO(&event),
+			})
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
+
+// parseLastEventID читает заголовок Last-Event-ID, который браузер отправляет при переподключении,
+// или параметр last_event_id для первого подключения.
+func parseLastEventID(c *ginext.Context) (int64, bool) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	if raw == "" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный Last-Event-ID"})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (h *Handler) toEventDTO(e *models.CommentEvent) commentEvent {
+	out := commentEvent{
+		ID:        e.ID,
+		Type:      string(e.Type),
+		Thread:    e.Thread,
+		CommentID: e.CommentID,
+		ParentID:  e.ParentID,
+		Path:      e.Path,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.Comment != nil && e.Type != models.EventDeleted {
+		dto := h.toCommentDTO(e.Comment)
+		out.Comment = &dto
+	}
+
+	return out
+}
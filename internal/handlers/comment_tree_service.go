@@ -1,8 +1,8 @@
 package httphandlers
 
 import (
+	"context"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/wb-go/wbf/ginext"
@@ -18,44 +18,37 @@ func (h *Handler) writeComment(c *ginext.Context) {
 		return
 	}
 
-	if req.Content == "" {
-		c.JSON(http.StatusBadRequest, ginext.H{"error": "комментарий не может быть пустым"})
+	if msg := h.bindAuthor(&req, actorFrom(c)); msg != "" {
+		c.JSON(http.StatusForbidden, ginext.H{"error": msg})
 		return
 	}
 
-	if req.Author == "" {
-		c.JSON(http.StatusBadRequest, ginext.H{"error": "автор не может быть пустым"})
+	if msg := validateCreateComment(&req); msg != "" {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": msg})
 		return
 	}
 
-	if len(req.Content) > 1000 {
-		c.JSON(http.StatusBadRequest, ginext.H{"error": "комментарий не может быть длиннее 1000 символов"})
-		return
+	comment := &models.Comment{
+		ParentID:  req.ParentID,
+		ThreadKey: req.Thread,
+		Content:   req.Content,
+		Author:    req.Author,
+		Language:  req.Language,
+		Quotes:    newQuotes(req.Quotes),
 	}
 
-	if len(req.Author) > 50 {
-		c.JSON(http.StatusBadRequest, ginext.H{"error": "автор не может быть длиннее 50 символов"})
+	if err := h.svc.WriteComment(c.Request.Context(), comment); err != nil {
+		status, msg := writeCommentError(err)
+		c.JSON(status, ginext.H{"error": msg})
 		return
 	}
 
-	comment := &models.Comment{
-		ParentID: req.ParentID,
-		Content:  req.Content,
-		Author:   req.Author,
-	}
-
-	if err := h.svc.WriteComment(c.Request.Context(), comment); err != nil {
-		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален") {
-			zlog.Logger.Error().Err(err).Msg("svc.WriteComment")
-			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
-			return
-		}
-		zlog.Logger.Error().Err(err).Msg("svc.WriteComment")
-		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+	if comment.Status == models.StatusPending {
+		c.JSON(http.StatusAccepted, ginext.H{"id": comment.ID, "message": "комментарий отправлен на модерацию"})
 		return
 	}
 
-	c.JSON(http.StatusOK, ginext.H{"message": "комментарий успешно создан"})
+	c.JSON(http.StatusOK, ginext.H{"id": comment.ID, "message": "комментарий успешно создан"})
 }
 
 func (h *Handler) getComments(c *ginext.Context) {
@@ -65,6 +58,13 @@ func (h *Handler) getComments(c *ginext.Context) {
 		return
 	}
 
+	if req.Sort != "" && !models.ValidSort(req.Sort) {
+		c.JSON(http.StatusBadRequest, ginext.H{
+			"error": "некорректная сортировка, допустимо: created_at_asc, created_at_desc, top, controversial, hot",
+		})
+		return
+	}
+
 	if req.ParentID == nil || *req.ParentID == 0 {
 		h.getRootComments(c, &req)
 		return
@@ -78,23 +78,75 @@ func (h *Handler) getComments(c *ginext.Context) {
 	h.getCommentsByParent(c, &req)
 }
 
-func (h *Handler) deleteComment(c *ginext.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+func (h *Handler) editComment(c *ginext.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req editCommentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return
+	}
+
+	if req.Content == "" {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "комментарий не может быть пустым"})
+		return
+	}
+
+	if len(req.Content) > 1000 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "комментарий не может быть длиннее 1000 символов"})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) > 500 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "причина не может быть длиннее 500 символов"})
+		return
+	}
+
+	comment, err := h.svc.EditComment(c.Request.Context(), id, req.Content, actorFrom(c), req.Reason)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный id комментария"})
+		switch {
+		case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
+		case strings.Contains(err.Error(), "нет прав"):
+			c.JSON(http.StatusForbidden, ginext.H{"error": "нет прав на редактирование комментария"})
+		case strings.Contains(err.Error(), "фильтром"):
+			zlog.Logger.Warn().Err(err).Msg("svc.EditComment")
+			c.JSON(http.StatusUnprocessableEntity, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().Err(err).Msg("svc.EditComment")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
 		return
 	}
-	if id < 1 {
-		c.JSON(http.StatusBadRequest, ginext.H{"error": "id комментария должен быть больше 0"})
+
+	c.JSON(http.StatusOK, h.toCommentDTO(comment))
+}
+
+func (h *Handler) deleteComment(c *ginext.Context) {
+	id, ok := parseID(c)
+	if !ok {
 		return
 	}
 
-	if err := h.svc.DeleteComment(c.Request.Context(), id); err != nil {
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteComment(c.Request.Context(), id, actorFrom(c), reason); err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			zlog.Logger.Error().Err(err).Msg("svc.DeleteComment")
 			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
 			return
 		}
+		if strings.Contains(err.Error(), "нет прав") {
+			c.JSON(http.StatusForbidden, ginext.H{"error": "нет прав на удаление комментария"})
+			return
+		}
 		zlog.Logger.Error().Err(err).Msg("svc.DeleteComment")
 		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
 		return
@@ -102,3 +154,159 @@ func (h *Handler) deleteComment(c *ginext.Context) {
 
 	c.JSON(http.StatusOK, ginext.H{"message": "комментарий успешно удален"})
 }
+
+func (h *Handler) restoreComment(c *ginext.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RestoreComment(c.Request.Context(), id, actorFrom(c), reason); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
+		case strings.Contains(err.Error(), "не удален") || strings.Contains(err.Error(), "сначала восстановите"):
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().Err(err).Msg("svc.RestoreComment")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"message": "комментарий восстановлен"})
+}
+
+func (h *Handler) moveComment(c *ginext.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req moveCommentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return
+	}
+
+	if req.ParentID != nil && *req.ParentID < 1 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "id родительского комментария должен быть больше 0"})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) > 500 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "причина не может быть длиннее 500 символов"})
+		return
+	}
+
+	comment, err := h.svc.MoveComment(c.Request.Context(), id, req.ParentID, actorFrom(c), req.Reason)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "собственную ветку") || strings.Contains(err.Error(), "глубина"):
+			c.JSON(http.StatusUnprocessableEntity, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().Err(err).Msg("svc.MoveComment")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toCommentDTO(comment))
+}
+
+func (h *Handler) voteComment(c *ginext.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req voteReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return
+	}
+	if req.Value != models.VoteUp && req.Value != models.VoteDown {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "голос должен быть 1 или -1"})
+		return
+	}
+
+	h.vote(c, id, req.Value)
+}
+
+func (h *Handler) unvoteComment(c *ginext.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	h.vote(c, id, models.VoteNone)
+}
+
+func (h *Handler) vote(c *ginext.Context, id int64, value int) {
+	res, err := h.svc.Vote(c.Request.Context(), id, actorFrom(c).User, value)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
+		case strings.Contains(err.Error(), "нет прав"):
+			c.JSON(http.StatusForbidden, ginext.H{"error": "нельзя голосовать за свой комментарий"})
+		default:
+			zlog.Logger.Error().Err(err).Msg("svc.Vote")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, voteResp{
+		ID:        res.CommentID,
+		Upvotes:   res.Upvotes,
+		Downvotes: res.Downvotes,
+		Score:     res.Score,
+		Vote:      res.Vote,
+	})
+}
+
+func (h *Handler) acceptAnswer(c *ginext.Context) {
+	h.markAnswer(c, "svc.AcceptAnswer", h.svc.AcceptAnswer)
+}
+
+func (h *Handler) unacceptAnswer(c *ginext.Context) {
+	h.markAnswer(c, "svc.UnacceptAnswer", h.svc.UnacceptAnswer)
+}
+
+func (h *Handler) markAnswer(
+	c *ginext.Context,
+	op string,
+	mark func(ctx context.Context, id int64, actor *models.Actor) (*models.Comment, error),
+) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	root, err := mark(c.Request.Context(), id, actorFrom(c))
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
+		case strings.Contains(err.Error(), "нет прав"):
+			c.JSON(http.StatusForbidden, ginext.H{"error": "отмечать ответ может автор вопроса или модератор"})
+		case strings.Contains(err.Error(), "не может быть ответом") || strings.Contains(err.Error(), "не отмечен"):
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().Err(err).Msg(op)
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toCommentDTO(root))
+}
@@ -1,23 +1,93 @@
 package httphandlers
 
 import (
+	"strings"
+	"time"
+
 	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
 
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
 	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
 )
 
 type Handler struct {
-	svc services.CommentTree
+	svc           services.CommentTree
+	moderation    services.Moderation
+	webhooks      services.Webhooks
+	notifications services.Notifications
+	reactions     services.Reactions
+	search        services.Search
+	authors       services.Authors
+	renderer      services.ContentRenderer
+	limiter       infra.RateLimiter
+	events        infra.EventHub
+	apiKeys       map[string]models.Actor
+	keyUsers      map[string]struct{}
+	writeLimit    models.RateLimit
+	readLimit     models.RateLimit
+	heartbeat     time.Duration
+	proxies       []string
+	ws            config.WebSocketConfig
+	rooms         *wsRooms
 }
 
-func New(svc services.CommentTree) *Handler {
+func New(
+	svc services.CommentTree,
+	moderation services.Moderation,
+	webhooks services.Webhooks,
+	notifications services.Notifications,
+	reactions services.Reactions,
+	search services.Search,
+	authors services.Authors,
+	renderer services.ContentRenderer,
+	limiter infra.RateLimiter,
+	events infra.EventHub,
+	cfg *config.Config,
+) *Handler {
+	apiKeys := make(map[string]models.Actor, len(cfg.Auth.APIKeys))
+	keyUsers := make(map[string]struct{}, len(cfg.Auth.APIKeys))
+	for _, k := range cfg.Auth.APIKeys {
+		apiKeys[k.Key] = models.Actor{
+			User:   k.User,
+			Role:   k.Role,
+			APIKey: k.Key,
+		}
+		if k.User != "" {
+			keyUsers[strings.ToLower(k.User)] = struct{}{}
+		}
+	}
+
 	return &Handler{
-		svc: svc,
+		svc:           svc,
+		moderation:    moderation,
+		webhooks:      webhooks,
+		notifications: notifications,
+		reactions:     reactions,
+		search:        search,
+		authors:       authors,
+		renderer:      renderer,
+		limiter:       limiter,
+		events:        events,
+		apiKeys:       apiKeys,
+		keyUsers:      keyUsers,
+		writeLimit:    models.RateLimit{RPS: cfg.RateLimit.Write.RPS, Burst: cfg.RateLimit.Write.Burst},
+		readLimit:     models.RateLimit{RPS: cfg.RateLimit.Read.RPS, Burst: cfg.RateLimit.Read.Burst},
+		heartbeat:     cfg.Events.Heartbeat,
+		proxies:       cfg.TrustedProxies,
+		ws:            cfg.WebSocket,
+		rooms:         newWSRooms(),
 	}
 }
 
 func (h *Handler) RegisterHandlers() *ginext.Engine {
 	router := ginext.New()
+	// IP клиента - ключ лимита для анонимов: X-Forwarded-For принимается только от известных прокси
+	if err := router.SetTrustedProxies(h.proxies); err != nil {
+		zlog.Logger.Error().Err(err).Msg("router.SetTrustedProxies")
+	}
 	router.Use(ginext.Logger(), ginext.Recovery())
 
 	// Web-интерфейс
@@ -27,9 +97,57 @@ func (h *Handler) RegisterHandlers() *ginext.Engine {
 	})
 
 	// API
-	router.POST("/comments", h.writeComment)
-	router.GET("/comments", h.getComments)
-	router.DELETE("/comments/:id", h.deleteComment)
+	router.POST("/comments", h.identify, h.rateLimit("write", h.writeLimit), h.writeComment)
+	router.GET("/comments", h.identify, h.rateLimit("read", h.readLimit), h.getComments)
+	router.GET("/search", h.identify, h.rateLimit("read", h.readLimit), h.searchComments)
+	router.GET("/comments/stream", h.identify, h.rateLimit("read", h.readLimit), h.streamComments)
+	router.GET("/ws", h.identify, h.rateLimit("read", h.readLimit), h.serveWebSocket)
+	router.PATCH("/comments/:id", h.identify, h.rateLimit("write", h.writeLimit), h.editComment)
+	router.DELETE("/comments/:id", h.identify, h.requireUser, h.rateLimit("write", h.writeLimit), h.deleteComment)
+	router.POST("/comments/:id/restore", h.identify, h.requireModerator, h.restoreComment)
+	router.POST("/comments/:id/move", h.identify, h.requireModerator, h.moveComment)
+	router.POST("/comments/:id/pin", h.identify, h.requireModerator, h.pinComment)
+	router.DELETE("/comments/:id/pin", h.identify, h.requireModerator, h.unpinComment)
+	router.POST("/comments/:id/report", h.identify, h.rateLimit("write", h.writeLimit), h.reportComment)
+	router.POST("/comments/:id/accept", h.identify, h.rateLimit("write", h.writeLimit), h.acceptAnswer)
+	router.DELETE("/comments/:id/accept", h.identify, h.rateLimit("write", h.writeLimit), h.unacceptAnswer)
+	router.PUT("/comments/:id/vote", h.identify, h.requireUser, h.rateLimit("write", h.writeLimit), h.voteComment)
+	router.DELETE("/comments/:id/vote", h.identify, h.requireUser, h.rateLimit("write", h.writeLimit), h.unvoteComment)
+	router.POST("/comments/:id/reactions/:emoji", h.identify, h.requireUser, h.rateLimit("write", h.writeLimit), h.addReaction)
+	router.DELETE("/comments/:id/reactions/:emoji", h.identify, h.requireUser, h.rateLimit("write", h.writeLimit), h.removeReaction)
+
+	// Модерация
+	router.GET("/threads/:key", h.identify, h.rateLimit("read", h.readLimit), h.getThread)
+	router.PUT("/threads/:key", h.identify, h.requireModerator, h.updateThread)
+	router.POST("/threads/:key/lock", h.identify, h.requireModerator, h.lockThread)
+	router.DELETE("/threads/:key/lock", h.identify, h.requireModerator, h.unlockThread)
+	router.GET("/threads/:key/redirects", h.identify, h.rateLimit("read", h.readLimit), h.getThreadRedirects)
+	router.POST("/threads/:key/merge", h.identify, h.requireAdmin, h.mergeThreads)
+	router.POST("/comments/:id/split", h.identify, h.requireAdmin, h.splitThread)
+	router.GET("/moderation/queue", h.identify, h.requireModerator, h.getModerationQueue)
+	router.POST("/moderation/:id/approve", h.identify, h.requireModerator, h.approveComment)
+	router.POST("/moderation/:id/reject", h.identify, h.requireModerator, h.rejectComment)
+	router.GET("/moderation/reports", h.identify, h.requireModerator, h.getReportedComments)
+	router.GET("/audit", h.identify, h.requireModerator, h.getAuditLog)
+
+	// Авторы
+	router.GET("/authors/:id", h.identify, h.rateLimit("read", h.readLimit), h.getAuthor)
+	router.PUT("/authors/:id", h.identify, h.requireUser, h.rateLimit("write", h.writeLimit), h.updateAuthor)
+	router.GET("/authors/:id/comments", h.identify, h.rateLimit("read", h.readLimit), h.getAuthorComments)
+
+	// Уведомления и упоминания
+	router.GET("/mentions/me", h.identify, h.requireUser, h.rateLimit("read", h.readLimit), h.getMyMentions)
+	router.GET("/notifications", h.identify, h.requireUser, h.rateLimit("read", h.readLimit), h.getNotifications)
+	router.POST("/notifications/read", h.identify, h.requireUser, h.rateLimit("write", h.writeLimit), h.markAllNotificationsRead)
+	router.POST("/notifications/:id/read", h.identify, h.requireUser, h.rateLimit("write", h.writeLimit), h.markNotificationRead)
+
+	// Вебхуки
+	router.POST("/webhooks", h.identify, h.requireAdmin, h.createWebhook)
+	router.GET("/webhooks", h.identify, h.requireAdmin, h.getWebhooks)
+	router.PUT("/webhooks/:id", h.identify, h.requireAdmin, h.updateWebhook)
+	router.DELETE("/webhooks/:id", h.identify, h.requireAdmin, h.deleteWebhook)
+	router.GET("/webhooks/:id/deliveries", h.identify, h.requireAdmin, h.getDeliveries)
+	router.POST("/webhooks/:id/deliveries/:delivery/redeliver", h.identify, h.requireAdmin, h.redeliverWebhook)
 
 	return router
 }
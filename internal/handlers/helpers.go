@@ -2,6 +2,7 @@ package httphandlers
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/wb-go/wbf/ginext"
@@ -42,10 +43,6 @@ func (h *Handler) getCommentsByParent(c *ginext.Context, req *getCommentsReq) {
 }
 
 func (h *Handler) buildPagination(c *ginext.Context, req *getCommentsReq) *models.PagParam {
-	if c.Query("page") == "" && c.Query("limit") == "" && c.Query("sort") == "" && c.Query("search") == "" {
-		return nil
-	}
-
 	if req.Page <= 0 {
 		req.Page = 1
 	}
@@ -53,29 +50,24 @@ func (h *Handler) buildPagination(c *ginext.Context, req *getCommentsReq) *model
 		req.Limit = 20
 	}
 	if req.Sort == "" {
-		req.Sort = "created_at_asc"
+		req.Sort = models.SortCreatedAsc
 	}
 
 	return &models.PagParam{
-		Page:   req.Page,
-		Limit:  req.Limit,
-		Sort:   req.Sort,
-		Search: req.Search,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Sort:       req.Sort,
+		Search:     req.Search,
+		Thread:     req.Thread,
+		Viewer:     actorFrom(c),
+		Unresolved: req.Unresolved,
 	}
 }
 
 func (h *Handler) sendCommentsResp(c *ginext.Context, result *models.CommentsRes) {
 	commentsDTO := make([]comment, len(result.Comments))
-	for i, c := range result.Comments {
-		commentsDTO[i] = comment{
-			ID:        c.ID,
-			Content:   c.Content,
-			Author:    c.Author,
-			CreatedAt: c.CreatedAt,
-			UpdatedAt: c.UpdatedAt,
-			DeletedAt: c.DeletedAt,
-			Level:     c.Level,
-		}
+	for i := range result.Comments {
+		commentsDTO[i] = h.toCommentDTO(&result.Comments[i])
 	}
 
 	out := getCommentsResp{
@@ -88,3 +80,178 @@ func (h *Handler) sendCommentsResp(c *ginext.Context, result *models.CommentsRes
 
 	c.JSON(http.StatusOK, out)
 }
+
+func (h *Handler) toCommentDTO(c *models.Comment) comment {
+	var mentions []mention
+	for _, m := range c.Mentions {
+		// Имя приводится к виду из comment_mentions и в только что созданном или исправленном комментарии
+		mentions = append(mentions, mention{Username: strings.ToLower(m.Username), Offset: m.Offset, Length: m.Length})
+	}
+
+	return comment{
+		ID:          c.ID,
+		Thread:      c.ThreadKey,
+		Content:     c.Content,
+		ContentHTML: h.renderer.Render(c),
+		Author:      c.Author,
+		AuthorID:    c.AuthorID,
+		AuthorName:  c.AuthorName,
+		Status:      string(c.Status),
+		Language:    c.Language,
+		Revision:    c.Revision,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+		DeletedAt:   c.DeletedAt,
+		PinnedAt:    c.PinnedAt,
+		AcceptedID:  c.AcceptedCommentID,
+		Accepted:    c.Accepted,
+		Level:       c.Level,
+		Upvotes:     c.Upvotes,
+		Downvotes:   c.Downvotes,
+		Score:       c.Score,
+		Mentions:    mentions,
+		Quotes:      toQuotesDTO(c.Quotes),
+		Reactions:   toReactionsDTO(c.Reactions),
+	}
+}
+
+func toQuotesDTO(quotes []models.Quote) []quote {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	out := make([]quote, len(quotes))
+	for i, q := range quotes {
+		out[i] = quote{ID: q.CommentID, Author: q.Author, AuthorName: q.AuthorName, Excerpt: q.Excerpt, Deleted: q.Deleted}
+	}
+
+	return out
+}
+
+// newQuotes переводит id из запроса в цитаты для сервиса.
+func newQuotes(ids []int64) []models.Quote {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	out := make([]models.Quote, len(ids))
+	for i, id := range ids {
+		out[i] = models.Quote{CommentID: id}
+	}
+
+	return out
+}
+
+// bindReason читает необязательную причину действия из JSON-тела или параметра reason.
+func bindReason(c *ginext.Context) (string, bool) {
+	var req reasonReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+			return "", false
+		}
+	}
+	if req.Reason == "" {
+		req.Reason = c.Query("reason")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > 500 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "причина не может быть длиннее 500 символов"})
+		return "", false
+	}
+
+	return reason, true
+}
+
+func parseID(c *ginext.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный id комментария"})
+		return 0, false
+	}
+	if id < 1 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "id комментария должен быть больше 0"})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// parseIDParam разбирает положительный id из параметра пути name, при ошибке отвечает 400 с errMsg.
+func parseIDParam(c *ginext.Context, name, errMsg string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": errMsg})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// bindAuthor привязывает автора комментария к пользователю API-ключа: права автора (правка, видимость
+// на модерации, уведомления, профиль) определяются по имени. Анонимам нельзя писать от имени пользователей
+// с API-ключами. Возвращает текст ошибки или пустую строку.
+func (h *Handler) bindAuthor(req *createCommentReq, actor *models.Actor) string {
+	req.Author = strings.TrimSpace(req.Author)
+
+	if actor != nil && actor.User != "" {
+		if req.Author != "" && req.Author != actor.User {
+			return "автор должен совпадать с пользователем API-ключа"
+		}
+		req.Author = actor.User
+		return ""
+	}
+
+	if _, ok := h.keyUsers[strings.ToLower(req.Author)]; ok {
+		return "имя автора занято пользователем, укажите его API-ключ"
+	}
+
+	return ""
+}
+
+// validateCreateComment проверяет новый комментарий и возвращает текст ошибки или пустую строку.
+func validateCreateComment(req *createCommentReq) string {
+	switch {
+	case req.Content == "":
+		return "комментарий не может быть пустым"
+	case req.Author == "":
+		return "автор не может быть пустым"
+	case len(req.Content) > 1000:
+		return "комментарий не может быть длиннее 1000 символов"
+	case len(req.Author) > 50:
+		return "автор не может быть длиннее 50 символов"
+	case len(req.Thread) > 255:
+		return "ключ треда не может быть длиннее 255 символов"
+	case req.Language != "" && !models.ValidLanguage(req.Language):
+		return "некорректный язык, допустимо: ru, en, kk"
+	case len(req.Quotes) > models.MaxQuotes:
+		return "нельзя процитировать больше " + strconv.Itoa(models.MaxQuotes) + " комментариев"
+	}
+	for _, id := range req.Quotes {
+		if id < 1 {
+			return "id цитируемого комментария должен быть больше 0"
+		}
+	}
+
+	return ""
+}
+
+// writeCommentError сопоставляет ошибку WriteComment с HTTP-статусом и текстом для клиента.
+func writeCommentError(err error) (int, string) {
+	switch {
+	case strings.Contains(err.Error(), "цитируемый") || strings.Contains(err.Error(), "глубина"):
+		return http.StatusUnprocessableEntity, err.Error()
+	case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+		zlog.Logger.Error().Err(err).Msg("svc.WriteComment")
+		return http.StatusNotFound, "комментарий не найден"
+	case strings.Contains(err.Error(), "фильтром"):
+		zlog.Logger.Warn().Err(err).Msg("svc.WriteComment")
+		return http.StatusUnprocessableEntity, err.Error()
+	case strings.Contains(err.Error(), "закрыт"):
+		return http.StatusLocked, err.Error()
+	default:
+		zlog.Logger.Error().Err(err).Msg("svc.WriteComment")
+		return http.StatusInternalServerError, "внутренняя ошибка сервера"
+	}
+}
@@ -0,0 +1,118 @@
+package httphandlers
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const actorKey = "actor"
+
+// identify определяет клиента по заголовку X-API-Key, анонимный клиент определяется по IP.
+func (h *Handler) identify(c *ginext.Context) {
+	actor := models.Actor{IP: c.ClientIP()}
+
+	if key := strings.TrimSpace(c.GetHeader("X-API-Key")); key != "" {
+		known, ok := h.apiKeys[key]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ginext.H{"error": "некорректный API-ключ"})
+			return
+		}
+		actor.User, actor.Role, actor.APIKey = known.User, known.Role, known.APIKey
+	}
+
+	c.Set(actorKey, &actor)
+	c.Next()
+}
+
+func (h *Handler) requireModerator(c *ginext.Context) {
+	actor := actorFrom(c)
+	if !actor.IsAuthenticated() {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ginext.H{"error": "требуется API-ключ"})
+		return
+	}
+	if !actor.IsModerator() {
+		c.AbortWithStatusJSON(http.StatusForbidden, ginext.H{"error": "недостаточно прав"})
+		return
+	}
+
+	c.Next()
+}
+
+// requireUser пропускает клиентов с API-ключом, привязанным к пользователю.
+func (h *Handler) requireUser(c *ginext.Context) {
+	actor := actorFrom(c)
+	if !actor.IsAuthenticated() || actor.User == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ginext.H{"error": "требуется API-ключ пользователя"})
+		return
+	}
+
+	c.Next()
+}
+
+func (h *Handler) requireAdmin(c *ginext.Context) {
+	actor := actorFrom(c)
+	if !actor.IsAuthenticated() {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ginext.H{"error": "требуется API-ключ"})
+		return
+	}
+	if !actor.IsAdmin() {
+		c.AbortWithStatusJSON(http.StatusForbidden, ginext.H{"error": "недостаточно прав"})
+		return
+	}
+
+	c.Next()
+}
+
+func (h *Handler) rateLimit(scope string, limit models.RateLimit) ginext.HandlerFunc {
+	return func(c *ginext.Context) {
+		allowed, retryAfter := h.allow(c.Request.Context(), scope, clientKey(c), limit)
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ginext.H{"error": "слишком много запросов, попробуйте позже"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (h *Handler) allow(ctx context.Context, scope, key string, limit models.RateLimit) (bool, time.Duration) {
+	if h.limiter == nil {
+		return true, 0
+	}
+
+	allowed, retryAfter, err := h.limiter.Allow(ctx, scope+":"+key, limit)
+	if err != nil {
+		// Отказ лимитера не должен ронять API, но запросы в это время не ограничиваются
+		zlog.Logger.Error().Err(err).Str("scope", scope).Msg("limiter.Allow: лимит не применен")
+		return true, 0
+	}
+
+	return allowed, retryAfter
+}
+
+func actorFrom(c *ginext.Context) *models.Actor {
+	v, ok := c.Get(actorKey)
+	if !ok {
+		return nil
+	}
+	actor, _ := v.(*models.Actor)
+	return actor
+}
+
+// clientKey - ключ клиента: пользователь, затем API-ключ, затем IP.
+func clientKey(c *ginext.Context) string {
+	if actor := actorFrom(c); actor != nil {
+		return actor.Key()
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -1,19 +1,90 @@
 package httphandlers
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type createCommentReq struct {
-	ParentID *int64 `json:"parent_id,omitempty"`
-	Content  string `json:"content"`
-	Author   string `json:"author"`
+	ParentID *int64  `json:"parent_id,omitempty"`
+	Thread   string  `json:"thread,omitempty"`
+	Content  string  `json:"content"`
+	Author   string  `json:"author"`
+	Language string  `json:"language,omitempty"`
+	Quotes   []int64 `json:"quotes,omitempty"`
+}
+
+type voteReq struct {
+	Value int `json:"value"`
+}
+
+type voteResp struct {
+	ID        int64 `json:"id"`
+	Upvotes   int   `json:"upvotes"`
+	Downvotes int   `json:"downvotes"`
+	Score     int   `json:"score"`
+	Vote      int   `json:"vote"`
+}
+
+type editCommentReq struct {
+	Content string `json:"content"`
+	Reason  string `json:"reason"`
+}
+
+type reasonReq struct {
+	Reason string `json:"reason"`
+}
+
+type moveCommentReq struct {
+	ParentID *int64 `json:"parent_id"`
+	Reason   string `json:"reason"`
 }
 
 type getCommentsReq struct {
-	ParentID *int64 `form:"parent"`
-	Page     int    `form:"page"`
-	Limit    int    `form:"limit"`
-	Sort     string `form:"sort"`
-	Search   string `form:"search"`
+	ParentID   *int64 `form:"parent"`
+	Thread     string `form:"thread"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+	Sort       string `form:"sort"`
+	Search     string `form:"search"`
+	Unresolved bool   `form:"unresolved"`
+}
+
+type searchReq struct {
+	Query          string    `form:"q"`
+	Author         string    `form:"author"`
+	CreatedFrom    time.Time `form:"created_from"`
+	CreatedTo      time.Time `form:"created_to"`
+	Thread         string    `form:"thread"`
+	Subtree        int64     `form:"subtree"`
+	IncludeDeleted bool      `form:"include_deleted"`
+	Mode           string    `form:"mode"`
+	Page           int       `form:"page"`
+	Limit          int       `form:"limit"`
+}
+
+type searchHit struct {
+	comment
+	Rank     float64    `json:"rank"`
+	Headline string     `json:"headline"`
+	Path     []pathItem `json:"path"`
+}
+
+type pathItem struct {
+	ID         int64  `json:"id"`
+	Author     string `json:"author,omitempty"`
+	AuthorName string `json:"author_name,omitempty"`
+	Excerpt    string `json:"excerpt,omitempty"`
+	Deleted    bool   `json:"deleted,omitempty"`
+}
+
+type searchResp struct {
+	Results []searchHit `json:"results"`
+	Mode    string      `json:"mode"`
+	Total   int         `json:"total"`
+	Page    int         `json:"page"`
+	Limit   int         `json:"limit"`
+	Pages   int         `json:"pages"`
 }
 
 type getCommentsResp struct {
@@ -25,11 +96,269 @@ type getCommentsResp struct {
 }
 
 type comment struct {
-	ID        int64      `json:"id"`
-	Content   string     `json:"content"`
-	Author    string     `json:"author"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
-	Level     int        `json:"level"`
+	ID          int64      `json:"id"`
+	Thread      string     `json:"thread"`
+	Content     string     `json:"content"`
+	ContentHTML string     `json:"content_html"`
+	Author      string     `json:"author"`
+	AuthorID    int64      `json:"author_id"`
+	AuthorName  string     `json:"author_name"`
+	Status      string     `json:"status"`
+	Language    string     `json:"language"`
+	Revision    int        `json:"revision"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	PinnedAt    *time.Time `json:"pinned_at,omitempty"`
+	AcceptedID  *int64     `json:"accepted_comment_id,omitempty"`
+	Accepted    bool       `json:"accepted,omitempty"`
+	Level       int        `json:"level"`
+	Upvotes     int        `json:"upvotes"`
+	Downvotes   int        `json:"downvotes"`
+	Score       int        `json:"score"`
+	Mentions    []mention  `json:"mentions,omitempty"`
+	Quotes      []quote    `json:"quotes,omitempty"`
+	Reactions   []reaction `json:"reactions,omitempty"`
+}
+
+// reaction - сводка по реакции; reacted - поставил ли ее текущий пользователь.
+type reaction struct {
+	Emoji   string `json:"emoji"`
+	Count   int    `json:"count"`
+	Reacted bool   `json:"reacted"`
+}
+
+type reactionsResp struct {
+	Reactions []reaction `json:"reactions"`
+}
+
+// quote - цитируемый комментарий; у удаленного или скрытого есть только id и deleted.
+type quote struct {
+	ID         int64  `json:"id"`
+	Author     string `json:"author,omitempty"`
+	AuthorName string `json:"author_name,omitempty"`
+	Excerpt    string `json:"excerpt,omitempty"`
+	Deleted    bool   `json:"deleted,omitempty"`
+}
+
+// mention - упоминание в тексте; offset и length - в символах (Unicode code points).
+type mention struct {
+	Username string `json:"username"`
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+}
+
+type moderationQueueReq struct {
+	Thread string `form:"thread"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+type threadSettingsReq struct {
+	Premoderation bool `json:"premoderation"`
+}
+
+type splitThreadReq struct {
+	Thread string `json:"thread"`
+	Reason string `json:"reason"`
+}
+
+type mergeThreadsReq struct {
+	Into   string `json:"into"`
+	Reason string `json:"reason"`
+}
+
+type threadRedirectResp struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	CommentID *int64    `json:"comment_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type threadResp struct {
+	Key           string     `json:"key"`
+	Premoderation bool       `json:"premoderation"`
+	LockedAt      *time.Time `json:"locked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type updateAuthorReq struct {
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+}
+
+type authorCommentsReq struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type authorResp struct {
+	ID           int64     `json:"id"`
+	Username     string    `json:"username"`
+	DisplayName  string    `json:"display_name"`
+	AvatarURL    string    `json:"avatar_url,omitempty"`
+	CommentCount int       `json:"comment_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type reportReq struct {
+	Reason string `json:"reason"`
+}
+
+type reportedComment struct {
+	comment
+	Reports        int       `json:"reports"`
+	LastReportedAt time.Time `json:"last_reported_at"`
+	Reasons        []string  `json:"reasons"`
+}
+
+type getReportedResp struct {
+	Comments []reportedComment `json:"comments"`
+	Total    int               `json:"total"`
+	Page     int               `json:"page"`
+	Limit    int               `json:"limit"`
+	Pages    int               `json:"pages"`
+}
+
+type streamReq struct {
+	Thread string `form:"thread"`
+	Parent int64  `form:"parent"`
+}
+
+type commentEvent struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	Thread    string    `json:"thread,omitempty"`
+	CommentID int64     `json:"comment_id,omitempty"`
+	ParentID  *int64    `json:"parent_id,omitempty"`
+	Path      []int64   `json:"path,omitempty"`
+	Comment   *comment  `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type wsInMsg struct {
+	Type         string            `json:"type"`
+	ID           string            `json:"id,omitempty"`
+	Subscription string            `json:"subscription,omitempty"`
+	Thread       string            `json:"thread,omitempty"`
+	Parent       int64             `json:"parent,omitempty"`
+	LastEventID  int64             `json:"last_event_id,omitempty"`
+	Author       string            `json:"author,omitempty"`
+	Comment      *createCommentReq `json:"comment,omitempty"`
+}
+
+type wsOutMsg struct {
+	Type         string        `json:"type"`
+	ID           string        `json:"id,omitempty"`
+	Subscription string        `json:"subscription,omitempty"`
+	Event        *commentEvent `json:"event,omitempty"`
+	CommentID    int64         `json:"comment_id,omitempty"`
+	Status       string        `json:"status,omitempty"`
+	User         string        `json:"user,omitempty"`
+	Online       int           `json:"online,omitempty"`
+	Code         int           `json:"code,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
+
+type auditReq struct {
+	Actor      string    `form:"actor"`
+	Action     string    `form:"action"`
+	TargetType string    `form:"target_type"`
+	TargetID   string    `form:"target_id"`
+	From       time.Time `form:"from"`
+	To         time.Time `form:"to"`
+	Page       int       `form:"page"`
+	Limit      int       `form:"limit"`
+}
+
+type auditEntry struct {
+	ID         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Reason     string          `json:"reason,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type getAuditResp struct {
+	Entries []auditEntry `json:"entries"`
+	Total   int          `json:"total"`
+	Page    int          `json:"page"`
+	Limit   int          `json:"limit"`
+	Pages   int          `json:"pages"`
+}
+
+type webhookReq struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+type webhookResp struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type deliveriesReq struct {
+	Status string `form:"status"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+type deliveryResp struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+type getDeliveriesResp struct {
+	Deliveries []deliveryResp `json:"deliveries"`
+	Total      int            `json:"total"`
+	Page       int            `json:"page"`
+	Limit      int            `json:"limit"`
+	Pages      int            `json:"pages"`
+}
+
+type notificationsReq struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type notificationResp struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	CommentID int64     `json:"comment_id"`
+	ParentID  *int64    `json:"parent_id,omitempty"`
+	Thread    string    `json:"thread"`
+	Actor     string    `json:"actor"`
+	Excerpt   string    `json:"excerpt"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type getNotificationsResp struct {
+	Notifications []notificationResp `json:"notifications"`
+	Unread        int                `json:"unread"`
+	Page          int                `json:"page"`
+	Limit         int                `json:"limit"`
+	Pages         int                `json:"pages"`
 }
@@ -0,0 +1,408 @@
+package httphandlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func (h *Handler) getModerationQueue(c *ginext.Context) {
+	var req moderationQueueReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	result, err := h.moderation.GetQueue(c.Request.Context(), &models.PagParam{
+		Page:   req.Page,
+		Limit:  req.Limit,
+		Thread: req.Thread,
+	})
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("moderation.GetQueue")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	h.sendCommentsResp(c, result)
+}
+
+func (h *Handler) approveComment(c *ginext.Context) {
+	h.moderate(c, h.moderation.Approve, "комментарий одобрен")
+}
+
+func (h *Handler) rejectComment(c *ginext.Context) {
+	h.moderate(c, h.moderation.Reject, "комментарий отклонен")
+}
+
+func (h *Handler) pinComment(c *ginext.Context) {
+	h.moderate(c, h.moderation.Pin, "комментарий закреплен")
+}
+
+func (h *Handler) unpinComment(c *ginext.Context) {
+	h.moderate(c, h.moderation.Unpin, "комментарий откреплен")
+}
+
+func (h *Handler) moderate(
+	c *ginext.Context,
+	decide func(ctx context.Context, id int64, actor *models.Actor, reason string) error,
+	message string,
+) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный id комментария"})
+		return
+	}
+
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+
+	if err := decide(c.Request.Context(), id, actorFrom(c), reason); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
+		case strings.Contains(err.Error(), "не ожидает модерации") || strings.Contains(err.Error(), "закреплен"):
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().Err(err).Msg("moderation.decide")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"message": message})
+}
+
+func (h *Handler) getThread(c *ginext.Context) {
+	thread, err := h.moderation.GetThread(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, ginext.H{"error": "тред не найден"})
+			return
+		}
+		zlog.Logger.Error().Err(err).Msg("moderation.GetThread")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	// Объединенный тред отвечает постоянным перенаправлением на тред, с которым его объединили
+	if thread.Key != c.Param("key") {
+		c.Header("Location", "/threads/"+url.PathEscape(thread.Key))
+		c.JSON(http.StatusMovedPermanently, toThreadResp(thread))
+		return
+	}
+
+	c.JSON(http.StatusOK, toThreadResp(thread))
+}
+
+func (h *Handler) getThreadRedirects(c *ginext.Context) {
+	redirects, err := h.moderation.GetThreadRedirects(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("moderation.GetThreadRedirects")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	out := make([]threadRedirectResp, len(redirects))
+	for i, rd := range redirects {
+		out[i] = threadRedirectResp{
+			From:      rd.FromKey,
+			To:        rd.ToKey,
+			CommentID: rd.CommentID,
+			CreatedAt: rd.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"redirects": out})
+}
+
+func (h *Handler) splitThread(c *ginext.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req splitThreadReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return
+	}
+
+	req.Thread = strings.TrimSpace(req.Thread)
+	if req.Thread == "" || len(req.Thread) > 255 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "ключ треда должен быть непустым и не длиннее 255 символов"})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) > 500 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "причина не может быть длиннее 500 символов"})
+		return
+	}
+
+	comment, err := h.moderation.SplitThread(c.Request.Context(), id, req.Thread, actorFrom(c), req.Reason)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
+		case strings.Contains(err.Error(), "уже существует"):
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().Err(err).Msg("moderation.SplitThread")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toCommentDTO(comment))
+}
+
+func (h *Handler) mergeThreads(c *ginext.Context) {
+	var req mergeThreadsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return
+	}
+
+	req.Into = strings.TrimSpace(req.Into)
+	if req.Into == "" {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "укажите тред, с которым нужно объединить"})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) > 500 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "причина не может быть длиннее 500 символов"})
+		return
+	}
+
+	moved, err := h.moderation.MergeThreads(c.Request.Context(), c.Param("key"), req.Into, actorFrom(c), req.Reason)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "уже объединен"):
+			c.JSON(http.StatusConflict, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "с самим собой"):
+			c.JSON(http.StatusUnprocessableEntity, ginext.H{"error": err.Error()})
+		default:
+			zlog.Logger.Error().Err(err).Msg("moderation.MergeThreads")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"thread": req.Into, "moved": moved})
+}
+
+func (h *Handler) updateThread(c *ginext.Context) {
+	key := c.Param("key")
+	if len(key) > 255 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "ключ треда не может быть длиннее 255 символов"})
+		return
+	}
+
+	var req threadSettingsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return
+	}
+
+	thread := &models.Thread{
+		Key:           key,
+		Premoderation: req.Premoderation,
+	}
+	if err := h.moderation.UpdateThread(c.Request.Context(), thread, actorFrom(c)); err != nil {
+		zlog.Logger.Error().Err(err).Msg("moderation.UpdateThread")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toThreadResp(thread))
+}
+
+func (h *Handler) lockThread(c *ginext.Context) {
+	h.setThreadLock(c, true, "тред закрыт")
+}
+
+func (h *Handler) unlockThread(c *ginext.Context) {
+	h.setThreadLock(c, false, "тред открыт")
+}
+
+func (h *Handler) setThreadLock(c *ginext.Context, locked bool, message string) {
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+
+	if err := h.moderation.LockThread(c.Request.Context(), c.Param("key"), locked, actorFrom(c), reason); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, ginext.H{"error": "тред не найден"})
+			return
+		}
+		zlog.Logger.Error().Err(err).Msg("moderation.LockThread")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"message": message})
+}
+
+func (h *Handler) reportComment(c *ginext.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный id комментария"})
+		return
+	}
+
+	var req reportReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "причина жалобы не может быть пустой"})
+		return
+	}
+	if len(req.Reason) > 500 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "причина жалобы не может быть длиннее 500 символов"})
+		return
+	}
+
+	report := &models.Report{
+		CommentID: id,
+		Reporter:  clientKey(c),
+		Reason:    req.Reason,
+	}
+	if err := h.moderation.Report(c.Request.Context(), report); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
+		case strings.Contains(err.Error(), "уже отправлена"):
+			c.JSON(http.StatusConflict, ginext.H{"error": "вы уже пожаловались на этот комментарий"})
+		default:
+			zlog.Logger.Error().Err(err).Msg("moderation.Report")
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"message": "жалоба отправлена"})
+}
+
+func (h *Handler) getReportedComments(c *ginext.Context) {
+	var req moderationQueueReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	result, err := h.moderation.GetReported(c.Request.Context(), &models.PagParam{
+		Page:   req.Page,
+		Limit:  req.Limit,
+		Thread: req.Thread,
+	})
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("moderation.GetReported")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	out := getReportedResp{
+		Comments: make([]reportedComment, len(result.Comments)),
+		Total:    result.Total,
+		Page:     result.Page,
+		Limit:    result.Limit,
+		Pages:    result.Pages,
+	}
+	for i := range result.Comments {
+		rc := &result.Comments[i]
+		out.Comments[i] = reportedComment{
+			comment:        h.toCommentDTO(&rc.Comment),
+			Reports:        rc.Reports,
+			LastReportedAt: rc.LastReportedAt,
+			Reasons:        rc.Reasons,
+		}
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *Handler) getAuditLog(c *ginext.Context) {
+	var req auditReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if req.Page < 0 || req.Limit < 0 || req.Limit > 200 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректные параметры пагинации"})
+		return
+	}
+
+	if !req.From.IsZero() && !req.To.IsZero() && req.From.After(req.To) {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "from не может быть позже to"})
+		return
+	}
+
+	result, err := h.moderation.GetAuditLog(c.Request.Context(), &models.AuditFilter{
+		Actor:      req.Actor,
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		From:       req.From,
+		To:         req.To,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("moderation.GetAuditLog")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	out := getAuditResp{
+		Entries: make([]auditEntry, len(result.Entries)),
+		Total:   result.Total,
+		Page:    result.Page,
+		Limit:   result.Limit,
+		Pages:   result.Pages,
+	}
+	for i := range result.Entries {
+		e := &result.Entries[i]
+		out.Entries[i] = auditEntry{
+			ID:         e.ID,
+			Actor:      e.Actor,
+			Action:     e.Action,
+			TargetType: e.TargetType,
+			TargetID:   e.TargetID,
+			Reason:     e.Reason,
+			Before:     e.Before,
+			After:      e.After,
+			CreatedAt:  e.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func toThreadResp(t *models.Thread) threadResp {
+	return threadResp{
+		Key:           t.Key,
+		Premoderation: t.Premoderation,
+		LockedAt:      t.LockedAt,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+}
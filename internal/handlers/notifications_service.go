@@ -0,0 +1,114 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func (h *Handler) getNotifications(c *ginext.Context) {
+	var req notificationsReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if req.Page < 0 || req.Limit < 0 || req.Limit > 100 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректные параметры пагинации"})
+		return
+	}
+
+	result, err := h.notifications.GetUnread(c.Request.Context(), actorFrom(c).User, &models.PagParam{
+		Page:  req.Page,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("notifications.GetUnread")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	out := getNotificationsResp{
+		Notifications: make([]notificationResp, len(result.Notifications)),
+		Unread:        result.Total,
+		Page:          result.Page,
+		Limit:         result.Limit,
+		Pages:         result.Pages,
+	}
+	for i := range result.Notifications {
+		n := &result.Notifications[i]
+		out.Notifications[i] = notificationResp{
+			ID:        n.ID,
+			Type:      n.Type,
+			CommentID: n.CommentID,
+			ParentID:  n.ParentID,
+			Thread:    n.ThreadKey,
+			Actor:     n.Actor,
+			Excerpt:   n.Excerpt,
+			CreatedAt: n.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *Handler) markNotificationRead(c *ginext.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный id уведомления"})
+		return
+	}
+
+	if err := h.notifications.MarkRead(c.Request.Context(), actorFrom(c).User, id); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, ginext.H{"error": "уведомление не найдено"})
+			return
+		}
+		zlog.Logger.Error().Err(err).Msg("notifications.MarkRead")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"message": "уведомление прочитано"})
+}
+
+func (h *Handler) markAllNotificationsRead(c *ginext.Context) {
+	n, err := h.notifications.MarkAllRead(c.Request.Context(), actorFrom(c).User)
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("notifications.MarkAllRead")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"marked": n})
+}
+
+func (h *Handler) getMyMentions(c *ginext.Context) {
+	var req notificationsReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if req.Page < 0 || req.Limit < 0 || req.Limit > 100 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректные параметры пагинации"})
+		return
+	}
+
+	result, err := h.svc.GetMentions(c.Request.Context(), actorFrom(c).User, &models.PagParam{
+		Page:  req.Page,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("svc.GetMentions")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	h.sendCommentsResp(c, result)
+}
@@ -0,0 +1,61 @@
+package httphandlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+type reactFunc func(ctx context.Context, commentID int64, user, emoji string) ([]models.Reaction, error)
+
+func (h *Handler) addReaction(c *ginext.Context) {
+	h.changeReaction(c, "reactions.React", h.reactions.React)
+}
+
+func (h *Handler) removeReaction(c *ginext.Context) {
+	h.changeReaction(c, "reactions.Unreact", h.reactions.Unreact)
+}
+
+func (h *Handler) changeReaction(c *ginext.Context, op string, fn reactFunc) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	emoji := c.Param("emoji")
+	if emoji == "" || utf8.RuneCountInString(emoji) > 32 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректная реакция"})
+		return
+	}
+
+	reactions, err := fn(c.Request.Context(), id, actorFrom(c).User, emoji)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "не разрешена"):
+			c.JSON(http.StatusBadRequest, ginext.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "уже удален"):
+			c.JSON(http.StatusNotFound, ginext.H{"error": "комментарий не найден"})
+		default:
+			zlog.Logger.Error().Err(err).Msg(op)
+			c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, reactionsResp{Reactions: toReactionsDTO(reactions)})
+}
+
+func toReactionsDTO(reactions []models.Reaction) []reaction {
+	out := make([]reaction, len(reactions))
+	for i, r := range reactions {
+		out[i] = reaction{Emoji: r.Emoji, Count: r.Count, Reacted: r.Reacted}
+	}
+
+	return out
+}
@@ -0,0 +1,94 @@
+package httphandlers
+
+import (
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func (h *Handler) searchComments(c *ginext.Context) {
+	var req searchReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if req.Page < 0 || req.Limit < 0 || req.Limit > 100 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректные параметры пагинации"})
+		return
+	}
+
+	if len(req.Query) > 500 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "поисковый запрос не может быть длиннее 500 символов"})
+		return
+	}
+
+	if req.Subtree < 0 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный id ветки"})
+		return
+	}
+
+	if !models.ValidSearchMode(req.Mode) {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный режим поиска, допустимо: fts, fuzzy"})
+		return
+	}
+
+	result, err := h.search.Search(c.Request.Context(), &models.SearchQuery{
+		Query:          req.Query,
+		Author:         req.Author,
+		From:           req.CreatedFrom,
+		To:             req.CreatedTo,
+		Thread:         req.Thread,
+		Subtree:        req.Subtree,
+		IncludeDeleted: req.IncludeDeleted,
+		Mode:           req.Mode,
+		Viewer:         actorFrom(c),
+		Page:           req.Page,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "не может") {
+			c.JSON(http.StatusBadRequest, ginext.H{"error": err.Error()})
+			return
+		}
+		zlog.Logger.Error().Err(err).Msg("search.Search")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	out := searchResp{
+		Results: make([]searchHit, len(result.Hits)),
+		Mode:    result.Mode,
+		Total:   result.Total,
+		Page:    result.Page,
+		Limit:   result.Limit,
+		Pages:   result.Pages,
+	}
+	for i := range result.Hits {
+		hit := &result.Hits[i]
+		out.Results[i] = searchHit{
+			comment:  h.toCommentDTO(&hit.Comment),
+			Rank:     hit.Rank,
+			Headline: headlineHTML(hit.Headline),
+			Path:     make([]pathItem, len(hit.Path)),
+		}
+		for j, p := range hit.Path {
+			out.Results[i].Path[j] = pathItem{ID: p.ID, Author: p.Author, AuthorName: p.AuthorName, Excerpt: p.Excerpt, Deleted: p.Deleted}
+		}
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+// headlineHTML экранирует фрагмент с совпадениями и размечает совпадения тегом <mark>.
+func headlineHTML(headline string) string {
+	return strings.NewReplacer(
+		models.HighlightStart, "<mark>",
+		models.HighlightStop, "</mark>",
+	).Replace(html.EscapeString(headline))
+}
@@ -0,0 +1,217 @@
+package httphandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func (h *Handler) createWebhook(c *ginext.Context) {
+	webhook, ok := bindWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhooks.CreateWebhook(c.Request.Context(), webhook); err != nil {
+		h.webhookError(c, err, "webhooks.CreateWebhook")
+		return
+	}
+
+	// Секрет показывается полностью только при создании
+	c.JSON(http.StatusCreated, toWebhookResp(webhook, false))
+}
+
+func (h *Handler) getWebhooks(c *ginext.Context) {
+	webhooks, err := h.webhooks.GetWebhooks(c.Request.Context())
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("webhooks.GetWebhooks")
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+		return
+	}
+
+	out := make([]webhookResp, len(webhooks))
+	for i := range webhooks {
+		out[i] = toWebhookResp(&webhooks[i], true)
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"webhooks": out})
+}
+
+func (h *Handler) updateWebhook(c *ginext.Context) {
+	id, ok := parseIDParam(c, "id", "некорректный id вебхука")
+	if !ok {
+		return
+	}
+
+	webhook, ok := bindWebhook(c)
+	if !ok {
+		return
+	}
+	webhook.ID = id
+
+	if err := h.webhooks.UpdateWebhook(c.Request.Context(), webhook); err != nil {
+		h.webhookError(c, err, "webhooks.UpdateWebhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookResp(webhook, true))
+}
+
+func (h *Handler) deleteWebhook(c *ginext.Context) {
+	id, ok := parseIDParam(c, "id", "некорректный id вебхука")
+	if !ok {
+		return
+	}
+
+	if err := h.webhooks.DeleteWebhook(c.Request.Context(), id); err != nil {
+		h.webhookError(c, err, "webhooks.DeleteWebhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"message": "вебхук удален"})
+}
+
+func (h *Handler) getDeliveries(c *ginext.Context) {
+	id, ok := parseIDParam(c, "id", "некорректный id вебхука")
+	if !ok {
+		return
+	}
+
+	var req deliveriesReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный запрос"})
+		return
+	}
+
+	if req.Page < 0 || req.Limit < 0 || req.Limit > 100 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректные параметры пагинации"})
+		return
+	}
+
+	status := models.DeliveryStatus(req.Status)
+	switch status {
+	case "", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead:
+	default:
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "status должен быть pending, delivered или dead"})
+		return
+	}
+
+	result, err := h.webhooks.GetDeliveries(c.Request.Context(), &models.DeliveryFilter{
+		WebhookID: id,
+		Status:    status,
+		Page:      req.Page,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		h.webhookError(c, err, "webhooks.GetDeliveries")
+		return
+	}
+
+	out := getDeliveriesResp{
+		Deliveries: make([]deliveryResp, len(result.Deliveries)),
+		Total:      result.Total,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Pages:      result.Pages,
+	}
+	for i := range result.Deliveries {
+		d := &result.Deliveries[i]
+		out.Deliveries[i] = deliveryResp{
+			ID:             d.ID,
+			WebhookID:      d.WebhookID,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Payload:        d.Payload,
+			Status:         string(d.Status),
+			Attempts:       d.Attempts,
+			NextAttemptAt:  d.NextAttemptAt,
+			LastStatusCode: d.LastStatusCode,
+			LastError:      d.LastError,
+			CreatedAt:      d.CreatedAt,
+			DeliveredAt:    d.DeliveredAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *Handler) redeliverWebhook(c *ginext.Context) {
+	id, ok := parseIDParam(c, "id", "некорректный id вебхука")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseIDParam(c, "delivery", "некорректный id доставки")
+	if !ok {
+		return
+	}
+
+	if err := h.webhooks.Redeliver(c.Request.Context(), id, deliveryID); err != nil {
+		h.webhookError(c, err, "webhooks.Redeliver")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, ginext.H{"message": "доставка поставлена в очередь"})
+}
+
+func (h *Handler) webhookError(c *ginext.Context, err error, op string) {
+	switch {
+	case strings.Contains(err.Error(), "не найден"):
+		c.JSON(http.StatusNotFound, ginext.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "некорректный"):
+		c.JSON(http.StatusBadRequest, ginext.H{"error": err.Error()})
+	default:
+		zlog.Logger.Error().Err(err).Msg(op)
+		c.JSON(http.StatusInternalServerError, ginext.H{"error": "внутренняя ошибка сервера"})
+	}
+}
+
+func bindWebhook(c *ginext.Context) (*models.Webhook, bool) {
+	var req webhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "некорректный JSON"})
+		return nil, false
+	}
+
+	req.URL = strings.TrimSpace(req.URL)
+	if req.URL == "" {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "url не может быть пустым"})
+		return nil, false
+	}
+	if len(req.URL) > 2048 {
+		c.JSON(http.StatusBadRequest, ginext.H{"error": "url не может быть длиннее 2048 символов"})
+		return nil, false
+	}
+
+	webhook := &models.Webhook{
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+		Active: req.Active == nil || *req.Active,
+	}
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+
+	return webhook, true
+}
+
+func toWebhookResp(w *models.Webhook, maskSecret bool) webhookResp {
+	secret := w.Secret
+	if maskSecret && len(secret) > 4 {
+		secret = strings.Repeat("*", 8) + secret[len(secret)-4:]
+	}
+
+	return webhookResp{
+		ID:        w.ID,
+		URL:       w.URL,
+		Secret:    secret,
+		Events:    w.Events,
+		Active:    w.Active,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
@@ -0,0 +1,362 @@
+package httphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsTypingInterval = 2 * time.Second
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// wsConn - одно WebSocket-соединение: подписки на хаб, очередь исходящих сообщений и heartbeat.
+type wsConn struct {
+	h     *Handler
+	conn  *websocket.Conn
+	actor *models.Actor
+	key   string
+
+	send      chan wsOutMsg
+	done      chan struct{}
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+
+	mu         sync.Mutex
+	subs       map[string]*wsSub
+	nextSub    int
+	lastTyping time.Time
+}
+
+type wsSub struct {
+	id     string
+	filter models.EventFilter
+	sub    infra.Subscription
+}
+
+func (h *Handler) serveWebSocket(c *ginext.Context) {
+	if h.events == nil {
+		c.JSON(http.StatusServiceUnavailable, ginext.H{"error": "живые обновления отключены"})
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrader уже ответил клиенту
+		zlog.Logger.Warn().Err(err).Msg("wsUpgrader.Upgrade")
+		return
+	}
+
+	ws := &wsConn{
+		h:     h,
+		conn:  conn,
+		actor: actorFrom(c),
+		key:   clientKey(c),
+		send:  make(chan wsOutMsg, h.ws.SendBuffer),
+		done:  make(chan struct{}),
+		subs:  make(map[string]*wsSub),
+	}
+
+	go ws.writeLoop()
+	ws.readLoop(c.Request.Context())
+	ws.cleanup()
+}
+
+func (ws *wsConn) readLoop(ctx context.Context) {
+	pongWait := 2 * ws.h.ws.PingInterval
+
+	ws.conn.SetReadLimit(ws.h.ws.MaxMessageSize)
+	_ = ws.conn.SetReadDeadline(time.Now().Add(pongWait))
+	ws.conn.SetPongHandler(func(string) error {
+		return ws.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, r, err := ws.conn.NextReader()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				zlog.Logger.Debug().Err(err).Str("client", ws.key).Msg("ws.conn.NextReader")
+			}
+			return
+		}
+		_ = ws.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return
+		}
+
+		var msg wsInMsg
+		if err := json.Unmarshal(data, &msg); err != nil {
+			ws.enqueue(wsError("", http.StatusBadRequest, "некорректный JSON"))
+			continue
+		}
+
+		ws.handle(ctx, &msg)
+	}
+}
+
+func (ws *wsConn) handle(ctx context.Context, msg *wsInMsg) {
+	switch msg.Type {
+	case "ping":
+		ws.enqueue(wsOutMsg{Type: "pong", ID: msg.ID})
+	case "subscribe":
+		ws.subscribe(msg)
+	case "unsubscribe":
+		ws.unsubscribe(msg)
+	case "post":
+		ws.post(ctx, msg)
+	case "typing":
+		ws.typing(msg)
+	default:
+		ws.enqueue(wsError(msg.ID, http.StatusBadRequest, "неизвестный тип сообщения"))
+	}
+}
+
+func (ws *wsConn) subscribe(msg *wsInMsg) {
+	if msg.Parent < 0 {
+		ws.enqueue(wsError(msg.ID, http.StatusBadRequest, "id родительского комментария должен быть больше 0"))
+		return
+	}
+	if len(msg.Thread) > 255 {
+		ws.enqueue(wsError(msg.ID, http.StatusBadRequest, "ключ треда не может быть длиннее 255 символов"))
+		return
+	}
+	if msg.LastEventID < 0 {
+		ws.enqueue(wsError(msg.ID, http.StatusBadRequest, "некорректный last_event_id"))
+		return
+	}
+
+	filter := models.EventFilter{Thread: msg.Thread, Parent: msg.Parent}
+
+	ws.mu.Lock()
+	if len(ws.subs) >= ws.h.ws.MaxSubscriptions {
+		ws.mu.Unlock()
+		ws.enqueue(wsError(msg.ID, http.StatusTooManyRequests, "слишком много подписок"))
+		return
+	}
+	for _, s := range ws.subs {
+		if s.filter == filter {
+			ws.mu.Unlock()
+			ws.enqueue(wsError(msg.ID, http.StatusConflict, "подписка уже существует: "+s.id))
+			return
+		}
+	}
+
+	sub, err := ws.h.events.Subscribe(filter, msg.LastEventID)
+	if err != nil {
+		ws.mu.Unlock()
+		zlog.Logger.Error().Err(err).Msg("events.Subscribe")
+		ws.enqueue(wsError(msg.ID, http.StatusInternalServerError, "внутренняя ошибка сервера"))
+		return
+	}
+
+	ws.nextSub++
+	s := &wsSub{id: "s" + strconv.Itoa(ws.nextSub), filter: filter, sub: sub}
+	ws.subs[s.id] = s
+	ws.mu.Unlock()
+
+	ws.enqueue(wsOutMsg{Type: "ack", ID: msg.ID, Subscription: s.id})
+	ws.h.rooms.join(s.filter, ws, s.id)
+
+	go ws.pump(s)
+}
+
+func (ws *wsConn) unsubscribe(msg *wsInMsg) {
+	s := ws.removeSub(msg.Subscription)
+	if s == nil {
+		ws.enqueue(wsError(msg.ID, http.StatusNotFound, "подписка не найдена"))
+		return
+	}
+	s.sub.Close()
+
+	ws.enqueue(wsOutMsg{Type: "ack", ID: msg.ID, Subscription: s.id})
+}
+
+func (ws *wsConn) post(ctx context.Context, msg *wsInMsg) {
+	if msg.Comment == nil {
+		ws.enqueue(wsError(msg.ID, http.StatusBadRequest, "нет комментария"))
+		return
+	}
+
+	if allowed, _ := ws.h.allow(ctx, "write", ws.key, ws.h.writeLimit); !allowed {
+		ws.enqueue(wsError(msg.ID, http.StatusTooManyRequests, "слишком много запросов, попробуйте позже"))
+		return
+	}
+
+	if errMsg := validateCreateComment(msg.Comment); errMsg != "" {
+		ws.enqueue(wsError(msg.ID, http.StatusBadRequest, errMsg))
+		return
+	}
+
+	comment := &models.Comment{
+		ParentID:  msg.Comment.ParentID,
+		ThreadKey: msg.Comment.Thread,
+		Content:   msg.Comment.Content,
+		Author:    msg.Comment.Author,
+		Language:  msg.Comment.Language,
+		Quotes:    newQuotes(msg.Comment.Quotes),
+	}
+	if err := ws.h.svc.WriteComment(ctx, comment); err != nil {
+		code, errMsg := writeCommentError(err)
+		ws.enqueue(wsError(msg.ID, code, errMsg))
+		return
+	}
+
+	ws.enqueue(wsOutMsg{Type: "ack", ID: msg.ID, CommentID: comment.ID, Status: string(comment.Status)})
+}
+
+// typing рассылает подсказку о наборе текста остальным подписчикам той же ветки, не чаще раза в 2 секунды.
+func (ws *wsConn) typing(msg *wsInMsg) {
+	ws.mu.Lock()
+	s, ok := ws.subs[msg.Subscription]
+	throttled := time.Since(ws.lastTyping) < wsTypingInterval
+	if ok && !throttled {
+		ws.lastTyping = time.Now()
+	}
+	ws.mu.Unlock()
+
+	if !ok {
+		ws.enqueue(wsError(msg.ID, http.StatusNotFound, "подписка не найдена"))
+		return
+	}
+	if throttled {
+		return
+	}
+
+	user := ""
+	if ws.actor != nil {
+		user = ws.actor.User
+	}
+	if user == "" {
+		user = msg.Author
+	}
+
+	ws.h.rooms.broadcast(s.filter, ws, func(subID string) wsOutMsg {
+		return wsOutMsg{Type: "typing", Subscription: subID, User: user}
+	})
+}
+
+// pump пересылает события подписки клиенту. Если хаб закрыл подписку сам (клиент не успевал
+// читать), клиенту отправляется ошибка - он может переподписаться с last_event_id.
+func (ws *wsConn) pump(s *wsSub) {
+	for event := range s.sub.Events() {
+		dto := ws.h.toEventDTO(&event)
+		ws.enqueue(wsOutMsg{Type: "event", Subscription: s.id, Event: &dto})
+	}
+
+	if ws.removeSub(s.id) != nil {
+		ws.enqueue(wsOutMsg{
+			Type:         "error",
+			Subscription: s.id,
+			Code:         http.StatusGone,
+			Error:        "подписка прервана, переподпишитесь с last_event_id",
+		})
+	}
+}
+
+func (ws *wsConn) removeSub(id string) *wsSub {
+	ws.mu.Lock()
+	s, ok := ws.subs[id]
+	delete(ws.subs, id)
+	ws.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	ws.h.rooms.leave(s.filter, ws)
+
+	return s
+}
+
+// enqueue не блокирует отправителя: если клиент не успевает читать, соединение закрывается.
+func (ws *wsConn) enqueue(msg wsOutMsg) {
+	select {
+	case <-ws.done:
+		return
+	default:
+	}
+
+	select {
+	case ws.send <- msg:
+	default:
+		ws.close(websocket.CloseTryAgainLater, "клиент не успевает читать сообщения")
+	}
+}
+
+func (ws *wsConn) writeLoop() {
+	ticker := time.NewTicker(ws.h.ws.PingInterval)
+	defer func() {
+		ticker.Stop()
+		_ = ws.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg := <-ws.send:
+			_ = ws.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := ws.conn.WriteJSON(msg); err != nil {
+				ws.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := ws.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				ws.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ws.done:
+			if ws.closeCode != websocket.CloseAbnormalClosure {
+				closeMsg := websocket.FormatCloseMessage(ws.closeCode, ws.closeText)
+				_ = ws.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(wsWriteWait))
+			}
+			return
+		}
+	}
+}
+
+func (ws *wsConn) close(code int, text string) {
+	ws.closeOnce.Do(func() {
+		ws.closeCode = code
+		ws.closeText = text
+		close(ws.done)
+	})
+}
+
+func (ws *wsConn) cleanup() {
+	ws.close(websocket.CloseNormalClosure, "")
+
+	ws.mu.Lock()
+	subs := make([]*wsSub, 0, len(ws.subs))
+	for _, s := range ws.subs {
+		subs = append(subs, s)
+	}
+	ws.mu.Unlock()
+
+	for _, s := range subs {
+		if ws.removeSub(s.id) != nil {
+			s.sub.Close()
+		}
+	}
+}
+
+func wsError(id string, code int, msg string) wsOutMsg {
+	return wsOutMsg{Type: "error", ID: id, Code: code, Error: msg}
+}
@@ -0,0 +1,69 @@
+package httphandlers
+
+import (
+	"sync"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+// wsRooms - кто из WebSocket-клиентов подписан на какую ветку. Нужен для подсказок о наборе
+// текста и счетчика присутствия, которые не проходят через хаб событий.
+type wsRooms struct {
+	mu    sync.Mutex
+	rooms map[models.EventFilter]map[*wsConn]string // соединение -> id его подписки
+}
+
+func newWSRooms() *wsRooms {
+	return &wsRooms{rooms: make(map[models.EventFilter]map[*wsConn]string)}
+}
+
+func (r *wsRooms) join(filter models.EventFilter, conn *wsConn, subID string) {
+	r.mu.Lock()
+	room, ok := r.rooms[filter]
+	if !ok {
+		room = make(map[*wsConn]string)
+		r.rooms[filter] = room
+	}
+	room[conn] = subID
+	r.mu.Unlock()
+
+	r.announce(filter)
+}
+
+func (r *wsRooms) leave(filter models.EventFilter, conn *wsConn) {
+	r.mu.Lock()
+	room := r.rooms[filter]
+	delete(room, conn)
+	if len(room) == 0 {
+		delete(r.rooms, filter)
+	}
+	r.mu.Unlock()
+
+	r.announce(filter)
+}
+
+// broadcast отправляет сообщение всем участникам ветки, кроме except.
+func (r *wsRooms) broadcast(filter models.EventFilter, except *wsConn, build func(subID string) wsOutMsg) {
+	r.mu.Lock()
+	targets := make(map[*wsConn]string, len(r.rooms[filter]))
+	for conn, subID := range r.rooms[filter] {
+		if conn != except {
+			targets[conn] = subID
+		}
+	}
+	r.mu.Unlock()
+
+	for conn, subID := range targets {
+		conn.enqueue(build(subID))
+	}
+}
+
+func (r *wsRooms) announce(filter models.EventFilter) {
+	r.mu.Lock()
+	online := len(r.rooms[filter])
+	r.mu.Unlock()
+
+	r.broadcast(filter, nil, func(subID string) wsOutMsg {
+		return wsOutMsg{Type: "presence", Subscription: subID, Online: online}
+	})
+}
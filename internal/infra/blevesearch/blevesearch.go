@@ -0,0 +1,252 @@
+//go:build bleve
+
+package blevesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ infra.Searcher = (*searcher)(nil)
+
+const (
+	// resubscribeDelay - пауза перед повторной подпиской, если хаб отказал в подписке.
+	resubscribeDelay = time.Second
+
+	// mappingVersion меняется вместе со схемой индекса: индекс со старой схемой пересоздается.
+	mappingVersion    = "2"
+	mappingVersionKey = "mapping_version"
+)
+
+// searcher - встроенный индекс Bleve. Индекс хранит id, текст для подсветки и поля фильтров,
+// а сами комментарии после поиска загружаются из Postgres.
+type searcher struct {
+	index     bleve.Index
+	repo      infra.Database
+	batchSize int
+	reindex   bool // полная переиндексация при запуске Run
+}
+
+// New открывает индекс по cfg.Path или создает новый; пустой путь - индекс в памяти.
+// Новый индекс заполняется при запуске Run.
+func New(cfg config.BleveConfig, repo infra.Database) (*searcher, error) {
+	s := &searcher{
+		repo:      repo,
+		batchSize: max(cfg.BatchSize, 1),
+		reindex:   cfg.ReindexOnStart,
+	}
+
+	var err error
+	switch {
+	case cfg.Path == "":
+		s.index, err = bleve.NewMemOnly(newMapping())
+		s.reindex = true
+	default:
+		var created bool
+		s.index, created, err = openIndex(cfg.Path)
+		s.reindex = s.reindex || created
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть индекс Bleve %q: %w", cfg.Path, err)
+	}
+	if err := s.index.SetInternal([]byte(mappingVersionKey), []byte(mappingVersion)); err != nil {
+		_ = s.index.Close()
+		return nil, fmt.Errorf("s.index.SetInternal: %w", err)
+	}
+
+	return s, nil
+}
+
+// openIndex открывает индекс по path; created = true, если индекс создан заново: его не было
+// или он построен по старой схеме.
+func openIndex(path string) (bleve.Index, bool, error) {
+	index, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = bleve.New(path, newMapping())
+		return index, true, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	version, err := index.GetInternal([]byte(mappingVersionKey))
+	if err != nil {
+		_ = index.Close()
+		return nil, false, fmt.Errorf("index.GetInternal: %w", err)
+	}
+	if string(version) == mappingVersion {
+		return index, false, nil
+	}
+
+	zlog.Logger.Info().Str("path", path).Msg("схема поискового индекса изменилась, индекс будет пересоздан")
+	_ = index.Close()
+	if err := os.RemoveAll(path); err != nil {
+		return nil, false, fmt.Errorf("os.RemoveAll: %w", err)
+	}
+	index, err = bleve.New(path, newMapping())
+
+	return index, true, err
+}
+
+// Run синхронизирует индекс с событиями outbox до отмены ctx и закрывает индекс. Индекс подписан и на
+// изменения, невидимые читателям (changed), поэтому неодобренные комментарии и смена имени автора
+// попадают в него сразу. Подписка оформляется до полной переиндексации, чтобы не потерять изменения,
+// сделанные во время нее.
+func (s *searcher) Run(ctx context.Context, hub infra.EventHub) {
+	defer func() {
+		if err := s.index.Close(); err != nil {
+			zlog.Logger.Error().Err(err).Msg("s.index.Close")
+		}
+	}()
+
+	var lastEventID int64
+	reindex := s.reindex
+	for {
+		sub, err := hub.Subscribe(models.EventFilter{Changes: true}, lastEventID)
+		if err != nil {
+			zlog.Logger.Error().Err(err).Msg("hub.Subscribe")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(resubscribeDelay):
+				continue
+			}
+		}
+
+		if reindex {
+			s.reindexAll(ctx)
+			reindex = false
+		}
+		lastEventID = s.consume(ctx, sub, lastEventID)
+		sub.Close()
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Канал закрыт из-за отставания: без id последнего события пропуски не восстановить
+		zlog.Logger.Warn().Int64("last_event_id", lastEventID).Msg("подписка поискового индекса прервана")
+		reindex = lastEventID == 0
+	}
+}
+
+// consume применяет события к индексу, пока канал подписки открыт, и возвращает id последнего события.
+func (s *searcher) consume(ctx context.Context, sub infra.Subscription, lastEventID int64) int64 {
+	for {
+		select {
+		case <-ctx.Done():
+			return lastEventID
+		case event, ok := <-sub.Events():
+			if !ok {
+				return lastEventID
+			}
+			lastEventID = event.ID
+
+			switch {
+			case event.Type == models.EventReset:
+				s.reindexAll(ctx)
+				continue
+			case event.AuthorID != 0:
+				if err := s.reindexAuthor(ctx, event.AuthorID); err != nil {
+					zlog.Logger.Error().Err(err).Int64("author_id", event.AuthorID).Msg("s.reindexAuthor")
+				}
+				continue
+			}
+			if err := s.reindexSubtree(ctx, event.CommentID); err != nil {
+				zlog.Logger.Error().Err(err).Int64("id", event.CommentID).Msg("s.reindexSubtree")
+			}
+		}
+	}
+}
+
+// reindexAll заново индексирует все комментарии пачками по batchSize.
+func (s *searcher) reindexAll(ctx context.Context) {
+	started := time.Now()
+
+	var afterID int64
+	total := 0
+	for {
+		docs, err := s.repo.GetSearchDocs(ctx, afterID, s.batchSize)
+		if err != nil {
+			zlog.Logger.Error().Err(err).Int64("after_id", afterID).Msg("s.repo.GetSearchDocs")
+			return
+		}
+		if len(docs) == 0 {
+			break
+		}
+		if err := s.indexDocs(docs); err != nil {
+			zlog.Logger.Error().Err(err).Int64("after_id", afterID).Msg("s.indexDocs")
+			return
+		}
+
+		afterID = docs[len(docs)-1].Comment.ID
+		total += len(docs)
+		if len(docs) < s.batchSize {
+			break
+		}
+	}
+
+	zlog.Logger.Info().Int("comments", total).Dur("took", time.Since(started)).Msg("поисковый индекс перестроен")
+}
+
+// reindexSubtree обновляет комментарий вместе с веткой: при переносе у ответов меняются тред и предки.
+func (s *searcher) reindexSubtree(ctx context.Context, id int64) error {
+	docs, err := s.repo.GetSubtreeSearchDocs(ctx, id)
+	if err != nil {
+		return fmt.Errorf("s.repo.GetSubtreeSearchDocs: %w", err)
+	}
+	if len(docs) == 0 {
+		if err := s.index.Delete(docID(id)); err != nil {
+			return fmt.Errorf("s.index.Delete: %w", err)
+		}
+		return nil
+	}
+
+	return s.indexDocs(docs)
+}
+
+// reindexAuthor обновляет все комментарии автора пачками по batchSize после смены его профиля.
+func (s *searcher) reindexAuthor(ctx context.Context, authorID int64) error {
+	var afterID int64
+	for {
+		docs, err := s.repo.GetAuthorSearchDocs(ctx, authorID, afterID, s.batchSize)
+		if err != nil {
+			return fmt.Errorf("s.repo.GetAuthorSearchDocs: %w", err)
+		}
+		if err := s.indexDocs(docs); err != nil {
+			return err
+		}
+		if len(docs) < s.batchSize {
+			return nil
+		}
+		afterID = docs[len(docs)-1].Comment.ID
+	}
+}
+
+func (s *searcher) indexDocs(docs []models.SearchDoc) error {
+	batch := s.index.NewBatch()
+	for i := range docs {
+		if err := batch.Index(docID(docs[i].Comment.ID), newDocument(&docs[i])); err != nil {
+			return fmt.Errorf("batch.Index: %w", err)
+		}
+	}
+	if err := s.index.Batch(batch); err != nil {
+		return fmt.Errorf("s.index.Batch: %w", err)
+	}
+
+	return nil
+}
+
+func docID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
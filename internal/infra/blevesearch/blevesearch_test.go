@@ -0,0 +1,282 @@
+//go:build bleve
+
+package blevesearch
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/infra/eventhub"
+	"github.com/sunr3d/comment-tree/mocks"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func newTestSearcher(t *testing.T, docs ...models.SearchDoc) (*searcher, *mocks.Database) {
+	t.Helper()
+	repo := mocks.NewDatabase(t)
+	s, err := New(config.BleveConfig{BatchSize: 10}, repo)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = s.index.Close() })
+	assert.NoError(t, s.indexDocs(docs))
+
+	repo.EXPECT().LoadSearchHits(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, hits []models.SearchHit, _ *models.Actor) ([]models.SearchHit, error) {
+			return hits, nil
+		}).Maybe()
+	return s, repo
+}
+
+func testDoc(id int64, lang, content string, path ...int64) models.SearchDoc {
+	return models.SearchDoc{
+		Comment: models.Comment{
+			ID:        id,
+			ThreadKey: "main",
+			Content:   content,
+			Author:    "alice",
+			Status:    models.StatusApproved,
+			Language:  lang,
+			CreatedAt: time.Date(2025, 1, int(id), 0, 0, 0, 0, time.UTC),
+		},
+		Path: path,
+	}
+}
+
+func hitIDs(res *models.SearchRes) []int64 {
+	ids := make([]int64, len(res.Hits))
+	for i, hit := range res.Hits {
+		ids[i] = hit.Comment.ID
+	}
+	return ids
+}
+
+func TestParseQuery(t *testing.T) {
+	groups, excluded := parseQuery(`кошки OR собаки "рыжий кот" -мышь -"серая мышь" , OR`)
+
+	assert.Equal(t, [][]clause{
+		{{text: "кошки"}, {text: "собаки"}},
+		{{text: "рыжий кот", phrase: true}},
+	}, groups)
+	assert.Equal(t, []clause{
+		{text: "мышь", negate: true},
+		{text: "серая мышь", phrase: true, negate: true},
+	}, excluded)
+}
+
+func TestSearch_StemmingAndLanguages(t *testing.T) {
+	s, _ := newTestSearcher(t,
+		testDoc(1, models.LanguageRussian, "Рыжие кошки спят на диване"),
+		testDoc(2, models.LanguageEnglish, "Cats are sleeping on the sofa"),
+		testDoc(3, models.LanguageRussian, "Собака гуляет во дворе"),
+	)
+
+	res, err := s.Search(context.Background(), &models.SearchQuery{Query: "кошка OR cat", Page: 1, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 2}, hitIDs(res))
+	assert.Equal(t, 2, res.Total)
+	for _, hit := range res.Hits {
+		assert.Contains(t, hit.Headline, models.HighlightStart)
+	}
+}
+
+func TestSearch_PhraseAndNegation(t *testing.T) {
+	s, _ := newTestSearcher(t,
+		testDoc(1, models.LanguageRussian, "рыжий кот и серая мышь"),
+		testDoc(2, models.LanguageRussian, "кот рыжий"),
+		testDoc(3, models.LanguageRussian, "рыжий кот"),
+	)
+
+	res, err := s.Search(context.Background(), &models.SearchQuery{Query: `"рыжий кот" -мышь`, Page: 1, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3}, hitIDs(res))
+}
+
+func TestSearch_Fuzzy(t *testing.T) {
+	s, _ := newTestSearcher(t, testDoc(1, models.LanguageEnglish, "postgres replication lag"))
+	ctx := context.Background()
+
+	res, err := s.Search(ctx, &models.SearchQuery{Query: "replicaton", Page: 1, Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Hits)
+
+	res, err = s.Search(ctx, &models.SearchQuery{
+		Query: "replicaton", Mode: models.SearchModeFuzzy, Threshold: 0.3, Page: 1, Limit: 10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, hitIDs(res))
+	assert.Equal(t, models.SearchModeFuzzy, res.Mode)
+}
+
+func TestSearch_FuzzyAuthorName(t *testing.T) {
+	doc := testDoc(1, models.LanguageRussian, "про индексы")
+	doc.Comment.AuthorName = "Александра"
+	s, _ := newTestSearcher(t, doc)
+
+	res, err := s.Search(context.Background(), &models.SearchQuery{
+		Query: "александр", Mode: models.SearchModeFuzzy, Threshold: 0.3, Page: 1, Limit: 10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, hitIDs(res))
+}
+
+func TestSearch_Filters(t *testing.T) {
+	deletedAt := time.Now()
+	deleted := testDoc(4, models.LanguageRussian, "кот удален", 1)
+	deleted.Comment.DeletedAt = &deletedAt
+	pending := testDoc(5, models.LanguageRussian, "кот на модерации")
+	pending.Comment.Status = models.StatusPending
+	pending.Comment.Author = "bob"
+
+	s, _ := newTestSearcher(t,
+		testDoc(1, models.LanguageRussian, "кот в корне"),
+		testDoc(2, models.LanguageRussian, "кот в ответе", 1),
+		testDoc(3, models.LanguageRussian, "кот в другой ветке"),
+		deleted,
+		pending,
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		q    models.SearchQuery
+		want []int64
+	}{
+		{name: "по умолчанию", q: models.SearchQuery{}, want: []int64{1, 2, 3}},
+		{name: "ветка", q: models.SearchQuery{Subtree: 1}, want: []int64{1, 2}},
+		{name: "с удаленными", q: models.SearchQuery{Subtree: 1, IncludeDeleted: true}, want: []int64{1, 2, 4}},
+		{name: "автор видит свой неодобренный", q: models.SearchQuery{Viewer: &models.Actor{User: "bob"}}, want: []int64{1, 2, 3, 5}},
+		{name: "модератор", q: models.SearchQuery{Viewer: &models.Actor{User: "mod", Role: models.RoleModerator}}, want: []int64{1, 2, 3, 5}},
+		{name: "даты", q: models.SearchQuery{From: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)}, want: []int64{2, 3}},
+		{name: "автор", q: models.SearchQuery{Author: "bob", Viewer: &models.Actor{User: "bob"}}, want: []int64{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.q.Query, tt.q.Page, tt.q.Limit = "кот", 1, 10
+			res, err := s.Search(ctx, &tt.q)
+
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, hitIDs(res))
+		})
+	}
+}
+
+func TestSearch_MergedThread(t *testing.T) {
+	s, repo := newTestSearcher(t, testDoc(1, models.LanguageRussian, "кот"))
+	repo.EXPECT().GetThreadRedirects(mock.Anything, "old").
+		Return([]models.ThreadRedirect{{FromKey: "old", ToKey: "main"}}, nil)
+
+	res, err := s.Search(context.Background(), &models.SearchQuery{Query: "кот", Thread: "old", Page: 1, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, hitIDs(res))
+}
+
+func TestReindexSubtree_DeletesMissing(t *testing.T) {
+	s, repo := newTestSearcher(t, testDoc(1, models.LanguageRussian, "кот"))
+	repo.EXPECT().GetSubtreeSearchDocs(mock.Anything, int64(1)).Return(nil, nil)
+
+	assert.NoError(t, s.reindexSubtree(context.Background(), 1))
+
+	count, err := s.index.DocCount()
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestReindexAuthor_Batches(t *testing.T) {
+	s, repo := newTestSearcher(t, testDoc(1, models.LanguageRussian, "кот"), testDoc(2, models.LanguageRussian, "кот"))
+	s.batchSize = 2
+
+	renamed := func(id int64) models.SearchDoc {
+		doc := testDoc(id, models.LanguageRussian, "кот")
+		doc.Comment.AuthorName = "Алиса"
+		return doc
+	}
+	repo.EXPECT().GetAuthorSearchDocs(mock.Anything, int64(5), int64(0), 2).
+		Return([]models.SearchDoc{renamed(1), renamed(2)}, nil)
+	repo.EXPECT().GetAuthorSearchDocs(mock.Anything, int64(5), int64(2), 2).Return(nil, nil)
+
+	assert.NoError(t, s.reindexAuthor(context.Background(), 5))
+
+	res, err := s.Search(context.Background(), &models.SearchQuery{
+		Query: "алиса", Mode: models.SearchModeFuzzy, Threshold: 0.3, Page: 1, Limit: 10,
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 2}, hitIDs(res))
+}
+
+func TestNew_RecreatesOutdatedIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search.bleve")
+	cfg := config.BleveConfig{Path: path, BatchSize: 10}
+
+	s, err := New(cfg, nil)
+	assert.NoError(t, err)
+	assert.True(t, s.reindex)
+	assert.NoError(t, s.index.SetInternal([]byte(mappingVersionKey), []byte("1")))
+	assert.NoError(t, s.index.Close())
+
+	s, err = New(cfg, nil)
+	assert.NoError(t, err)
+	assert.True(t, s.reindex)
+	assert.NoError(t, s.index.Close())
+
+	s, err = New(cfg, nil)
+	assert.NoError(t, err)
+	assert.False(t, s.reindex)
+	assert.NoError(t, s.index.Close())
+}
+
+func TestReindexAll_Batches(t *testing.T) {
+	s, repo := newTestSearcher(t)
+	s.batchSize = 2
+	repo.EXPECT().GetSearchDocs(mock.Anything, int64(0), 2).Return([]models.SearchDoc{
+		testDoc(1, models.LanguageRussian, "первый"),
+		testDoc(2, models.LanguageRussian, "второй"),
+	}, nil)
+	repo.EXPECT().GetSearchDocs(mock.Anything, int64(2), 2).Return([]models.SearchDoc{
+		testDoc(3, models.LanguageEnglish, strings.Repeat("third ", 3)),
+	}, nil)
+
+	s.reindexAll(context.Background())
+
+	count, err := s.index.DocCount()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+}
+
+func TestRun_IndexesEvents(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	s, err := New(config.BleveConfig{BatchSize: 10}, repo)
+	assert.NoError(t, err)
+	hub := eventhub.New(10, 10)
+
+	repo.EXPECT().GetSearchDocs(mock.Anything, int64(0), 10).Return(nil, nil)
+	pending := testDoc(7, models.LanguageRussian, "новый")
+	pending.Comment.Status = models.StatusPending
+	repo.EXPECT().GetSubtreeSearchDocs(mock.Anything, int64(7)).Return([]models.SearchDoc{pending}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, hub)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		_ = hub.Publish(ctx, &models.CommentEvent{Type: models.EventChanged, CommentID: 7})
+		count, _ := s.index.DocCount()
+		return count == 1
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+}
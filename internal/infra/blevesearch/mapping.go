@@ -0,0 +1,143 @@
+//go:build bleve
+
+package blevesearch
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/lang/ru"
+	"github.com/blevesearch/bleve/v2/mapping"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const docType = "comment"
+
+// Поля индекса. Текст попадает только в поле своего языка, чтобы его разбирал нужный анализатор;
+// для казахского в Bleve нет стеммера, поэтому он разбирается стандартным анализатором.
+// fieldContent - текст без стемминга для нечеткого поиска: опечатки считаются по исходным словам.
+const (
+	fieldContent    = "content"
+	fieldContentRU  = "content_ru"
+	fieldContentEN  = "content_en"
+	fieldContentKK  = "content_kk"
+	fieldAuthor     = "author"
+	fieldAuthorName = "author_name"
+	fieldThread     = "thread"
+	fieldStatus     = "status"
+	fieldDeleted    = "deleted"
+	fieldCreatedAt  = "created_at"
+	fieldAncestors  = "ancestors"
+)
+
+var contentFields = []string{fieldContentRU, fieldContentEN, fieldContentKK}
+
+// document - комментарий в индексе; Ancestors - id предков для фильтра по ветке,
+// AuthorName - отображаемое имя автора для нечеткого поиска.
+type document struct {
+	Type       string    `json:"type"`
+	Content    string    `json:"content"`
+	ContentRU  string    `json:"content_ru,omitempty"`
+	ContentEN  string    `json:"content_en,omitempty"`
+	ContentKK  string    `json:"content_kk,omitempty"`
+	Author     string    `json:"author"`
+	AuthorName string    `json:"author_name,omitempty"`
+	Thread     string    `json:"thread"`
+	Status     string    `json:"status"`
+	Deleted    bool      `json:"deleted"`
+	CreatedAt  time.Time `json:"created_at"`
+	Ancestors  []string  `json:"ancestors,omitempty"`
+}
+
+func newDocument(doc *models.SearchDoc) *document {
+	c := &doc.Comment
+	out := &document{
+		Type:       docType,
+		Content:    c.Content,
+		Author:     c.Author,
+		AuthorName: c.AuthorName,
+		Thread:     c.ThreadKey,
+		Status:     string(c.Status),
+		Deleted:    c.DeletedAt != nil,
+		CreatedAt:  c.CreatedAt,
+		Ancestors:  make([]string, len(doc.Path)),
+	}
+	for i, id := range doc.Path {
+		out.Ancestors[i] = strconv.FormatInt(id, 10)
+	}
+
+	switch c.Language {
+	case models.LanguageEnglish:
+		out.ContentEN = c.Content
+	case models.LanguageKazakh:
+		out.ContentKK = c.Content
+	default:
+		out.ContentRU = c.Content
+	}
+
+	return out
+}
+
+func newMapping() mapping.IndexMapping {
+	text := func(analyzer string) *mapping.FieldMapping {
+		f := mapping.NewTextFieldMapping()
+		f.Analyzer = analyzer
+		f.Store = true // для подсветки совпадений
+		f.IncludeTermVectors = true
+		f.IncludeInAll = false
+		return f
+	}
+	keywordField := func() *mapping.FieldMapping {
+		f := mapping.NewKeywordFieldMapping()
+		f.Analyzer = keyword.Name
+		f.Store = false
+		f.IncludeInAll = false
+		return f
+	}
+
+	// Поля вне схемы (type) не индексируются
+	doc := mapping.NewDocumentMapping()
+	doc.Dynamic = false
+	doc.AddFieldMappingsAt(fieldContentRU, text(ru.AnalyzerName))
+	doc.AddFieldMappingsAt(fieldContentEN, text(en.AnalyzerName))
+	doc.AddFieldMappingsAt(fieldContentKK, text(standard.Name))
+
+	content := mapping.NewTextFieldMapping()
+	content.Analyzer = standard.Name
+	content.Store = false
+	content.IncludeInAll = false
+	doc.AddFieldMappingsAt(fieldContent, content)
+
+	authorName := mapping.NewTextFieldMapping()
+	authorName.Analyzer = standard.Name
+	authorName.Store = false
+	authorName.IncludeInAll = false
+	doc.AddFieldMappingsAt(fieldAuthorName, authorName)
+
+	doc.AddFieldMappingsAt(fieldAuthor, keywordField())
+	doc.AddFieldMappingsAt(fieldThread, keywordField())
+	doc.AddFieldMappingsAt(fieldStatus, keywordField())
+	doc.AddFieldMappingsAt(fieldAncestors, keywordField())
+
+	deleted := mapping.NewBooleanFieldMapping()
+	deleted.Store = false
+	deleted.IncludeInAll = false
+	doc.AddFieldMappingsAt(fieldDeleted, deleted)
+
+	created := mapping.NewDateTimeFieldMapping()
+	created.Store = false
+	created.IncludeInAll = false
+	doc.AddFieldMappingsAt(fieldCreatedAt, created)
+
+	m := mapping.NewIndexMapping()
+	m.AddDocumentMapping(docType, doc)
+	m.TypeField = "type"
+	m.DefaultType = docType
+	m.DefaultAnalyzer = standard.Name
+
+	return m
+}
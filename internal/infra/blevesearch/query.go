@@ -0,0 +1,302 @@
+//go:build bleve
+
+package blevesearch
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/blevesearch/bleve/v2/search/highlight"
+	"github.com/blevesearch/bleve/v2/search/highlight/format/plain"
+	fragsimple "github.com/blevesearch/bleve/v2/search/highlight/fragmenter/simple"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/simple"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+// highlighterName - подсветка границами models.HighlightStart/HighlightStop, как у ts_headline:
+// текст не экранируется, это делает слой HTTP.
+const highlighterName = "comment_tree"
+
+const (
+	fragmentSize      = 200
+	fragmentSeparator = " … "
+)
+
+func init() {
+	registry.RegisterHighlighter(highlighterName, func(map[string]interface{}, *registry.Cache) (highlight.Highlighter, error) {
+		return simple.NewHighlighter(
+			fragsimple.NewFragmenter(fragmentSize),
+			plain.NewFragmentFormatter(models.HighlightStart, models.HighlightStop),
+			fragmentSeparator,
+		), nil
+	})
+}
+
+// clause - слово или "фраза" из запроса в синтаксисе websearch_to_tsquery.
+type clause struct {
+	text   string
+	phrase bool
+	negate bool
+}
+
+// parseQuery разбирает запрос как websearch_to_tsquery: слова объединяются через И, OR между ними
+// дает группу альтернатив, "-" перед словом или фразой исключает ее. Возвращает группы альтернатив
+// и исключения.
+func parseQuery(q string) ([][]clause, []clause) {
+	var groups [][]clause
+	var excluded []clause
+	or := false
+
+	runes := []rune(q)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		var c clause
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			c.negate = true
+			i++
+		}
+		start := i
+		if runes[i] == '"' {
+			c.phrase = true
+			start = i + 1
+			i = start
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			c.text = string(runes[start:i])
+			i++ // закрывающая кавычка
+		} else {
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '"' {
+				i++
+			}
+			c.text = string(runes[start:i])
+		}
+
+		switch {
+		case !c.negate && !c.phrase && c.text == "OR":
+			or = len(groups) > 0
+			continue
+		case !hasWords(c.text):
+			continue
+		case c.negate:
+			excluded = append(excluded, c)
+		case or:
+			groups[len(groups)-1] = append(groups[len(groups)-1], c)
+		default:
+			groups = append(groups, []clause{c})
+		}
+		or = false
+	}
+
+	return groups, excluded
+}
+
+// hasWords отсекает пунктуацию, из которой анализатор не извлечет ни одного слова.
+func hasWords(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0
+}
+
+// fuzziness - допустимое число правок в слове: чем ниже порог сходства, тем больше правок.
+// Bleve поддерживает не больше двух.
+func fuzziness(threshold float64) int {
+	if threshold < 0.5 {
+		return 2
+	}
+	return 1
+}
+
+// clauseQuery ищет слово или фразу в тексте на любом языке. При нечетком поиске слово сравнивается
+// с исходными словами текста без стемминга и может отличаться опечатками, а еще совпадать с именем
+// пользователя или отображаемым именем автора.
+func clauseQuery(c clause, fuzzy int) query.Query {
+	fields := contentFields
+	if fuzzy > 0 {
+		fields = []string{fieldContent}
+	}
+
+	q := bleve.NewDisjunctionQuery()
+	for _, field := range fields {
+		if c.phrase {
+			mq := bleve.NewMatchPhraseQuery(c.text)
+			mq.SetField(field)
+			q.AddQuery(mq)
+			continue
+		}
+		mq := bleve.NewMatchQuery(c.text)
+		mq.SetField(field)
+		mq.SetFuzziness(fuzzy)
+		q.AddQuery(mq)
+	}
+	if fuzzy > 0 && !c.phrase {
+		fq := bleve.NewFuzzyQuery(c.text)
+		fq.SetField(fieldAuthor)
+		fq.SetFuzziness(fuzzy)
+		q.AddQuery(fq)
+
+		nq := bleve.NewMatchQuery(c.text)
+		nq.SetField(fieldAuthorName)
+		nq.SetFuzziness(fuzzy)
+		q.AddQuery(nq)
+	}
+
+	return q
+}
+
+// buildQuery собирает запрос Bleve: совпадение по тексту и фильтры как у поиска в Postgres.
+func (s *searcher) buildQuery(ctx context.Context, q *models.SearchQuery) (query.Query, error) {
+	fuzzy := 0
+	if q.Mode == models.SearchModeFuzzy {
+		fuzzy = fuzziness(q.Threshold)
+	}
+
+	out := bleve.NewBooleanQuery()
+	groups, excluded := parseQuery(q.Query)
+	for _, group := range groups {
+		alternatives := bleve.NewDisjunctionQuery()
+		for _, c := range group {
+			alternatives.AddQuery(clauseQuery(c, fuzzy))
+		}
+		out.AddMust(alternatives)
+	}
+	for _, c := range excluded {
+		out.AddMustNot(clauseQuery(c, 0))
+	}
+	if len(groups) == 0 {
+		if len(excluded) == 0 {
+			// Запрос без слов ничего не находит, как пустой tsquery
+			return bleve.NewMatchNoneQuery(), nil
+		}
+		out.AddMust(bleve.NewMatchAllQuery())
+	}
+
+	if q.Author != "" {
+		out.AddMust(termQuery(fieldAuthor, q.Author))
+	}
+	if !q.From.IsZero() || !q.To.IsZero() {
+		inclusive := true
+		dq := bleve.NewDateRangeInclusiveQuery(q.From, q.To, &inclusive, &inclusive)
+		dq.SetField(fieldCreatedAt)
+		out.AddMust(dq)
+	}
+	if q.Thread != "" {
+		thread, err := s.resolveThread(ctx, q.Thread)
+		if err != nil {
+			return nil, err
+		}
+		out.AddMust(termQuery(fieldThread, thread))
+	}
+	if q.Subtree != 0 {
+		id := docID(q.Subtree)
+		out.AddMust(bleve.NewDisjunctionQuery(bleve.NewDocIDQuery([]string{id}), termQuery(fieldAncestors, id)))
+	}
+	if !q.IncludeDeleted {
+		bq := bleve.NewBoolFieldQuery(false)
+		bq.SetField(fieldDeleted)
+		out.AddMust(bq)
+	}
+	// Неодобренные комментарии находят только их автор и модераторы
+	if q.Viewer == nil || !q.Viewer.IsModerator() {
+		visible := bleve.NewDisjunctionQuery(termQuery(fieldStatus, string(models.StatusApproved)))
+		if q.Viewer != nil && q.Viewer.User != "" {
+			visible.AddQuery(termQuery(fieldAuthor, q.Viewer.User))
+		}
+		out.AddMust(visible)
+	}
+
+	return out, nil
+}
+
+// resolveThread возвращает тред, с которым объединен key, или сам key.
+func (s *searcher) resolveThread(ctx context.Context, key string) (string, error) {
+	redirects, err := s.repo.GetThreadRedirects(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("s.repo.GetThreadRedirects: %w", err)
+	}
+	for _, rd := range redirects {
+		if rd.CommentID == nil {
+			return rd.ToKey, nil
+		}
+	}
+
+	return key, nil
+}
+
+func termQuery(field, term string) *query.TermQuery {
+	q := bleve.NewTermQuery(term)
+	q.SetField(field)
+	return q
+}
+
+// Search ищет по индексу и загружает найденные комментарии из Postgres; Total считает индекс.
+func (s *searcher) Search(ctx context.Context, q *models.SearchQuery) (*models.SearchRes, error) {
+	result := &models.SearchRes{
+		Hits:  make([]models.SearchHit, 0, q.Limit),
+		Total: 0,
+		Page:  q.Page,
+		Limit: q.Limit,
+		Pages: 1,
+		Mode:  q.Mode,
+	}
+
+	bq, err := s.buildQuery(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	req := bleve.NewSearchRequestOptions(bq, q.Limit, (q.Page-1)*q.Limit, false)
+	req.SortBy([]string{"-_score", "-" + fieldCreatedAt, "-_id"})
+	req.Highlight = bleve.NewHighlightWithStyle(highlighterName)
+	req.Highlight.Fields = contentFields
+
+	res, err := s.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("s.index.SearchInContext: %w", err)
+	}
+
+	result.Total = int(res.Total)
+	result.Pages = (result.Total + result.Limit - 1) / result.Limit
+	for _, match := range res.Hits {
+		id, err := strconv.ParseInt(match.ID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("некорректный id документа %q в поисковом индексе", match.ID)
+		}
+		hit := models.SearchHit{Comment: models.Comment{ID: id}, Rank: match.Score}
+		var fragments []string
+		for _, field := range contentFields {
+			fragments = append(fragments, match.Fragments[field]...)
+		}
+		hit.Headline = strings.Join(fragments, fragmentSeparator)
+		result.Hits = append(result.Hits, hit)
+	}
+
+	result.Hits, err = s.repo.LoadSearchHits(ctx, result.Hits, q.Viewer)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.LoadSearchHits: %w", err)
+	}
+	for i := range result.Hits {
+		if result.Hits[i].Headline == "" {
+			result.Hits[i].Headline = excerpt(result.Hits[i].Comment.Content)
+		}
+	}
+
+	return result, nil
+}
+
+// excerpt - начало текста, если подсвечивать нечего: нечеткие совпадения ищутся по полю без хранимого текста.
+func excerpt(content string) string {
+	runes := []rune(content)
+	if len(runes) > fragmentSize {
+		return string(runes[:fragmentSize]) + "…"
+	}
+	return content
+}
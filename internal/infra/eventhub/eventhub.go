@@ -0,0 +1,145 @@
+package eventhub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ infra.EventHub = (*hub)(nil)
+
+// hub - pub/sub в памяти процесса с кольцевым буфером последних событий для Last-Event-ID.
+type hub struct {
+	mu        sync.Mutex
+	seq       int64
+	ring      []models.CommentEvent
+	head      int // индекс самого старого события
+	size      int
+	subs      map[*subscription]struct{}
+	subBuffer int
+	now       func() time.Time
+}
+
+type subscription struct {
+	hub    *hub
+	filter models.EventFilter
+	ch     chan models.CommentEvent
+}
+
+func New(replayBuffer, subscriberBuffer int) infra.EventHub {
+	return &hub{
+		ring:      make([]models.CommentEvent, max(replayBuffer, 1)),
+		subs:      make(map[*subscription]struct{}),
+		subBuffer: max(subscriberBuffer, 1),
+		now:       time.Now,
+	}
+}
+
+// Publish присваивает событию порядковый номер, если его нет, и рассылает подписчикам.
+// Подписчик с переполненным буфером отключается, чтобы не задерживать остальных.
+func (h *hub) Publish(_ context.Context, event *models.CommentEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if event.ID == 0 {
+		event.ID = h.seq + 1
+	}
+	if event.ID <= h.seq {
+		return nil
+	}
+	h.seq = event.ID
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = h.now()
+	}
+
+	h.remember(*event)
+
+	for sub := range h.subs {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.ch <- *event:
+		default:
+			h.drop(sub)
+		}
+	}
+
+	return nil
+}
+
+func (h *hub) Subscribe(filter models.EventFilter, lastEventID int64) (infra.Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	replay := h.replay(filter, lastEventID)
+	sub := &subscription{
+		hub:    h,
+		filter: filter,
+		ch:     make(chan models.CommentEvent, h.subBuffer+len(replay)),
+	}
+	for _, e := range replay {
+		sub.ch <- e
+	}
+	h.subs[sub] = struct{}{}
+
+	return sub, nil
+}
+
+func (h *hub) remember(e models.CommentEvent) {
+	if h.size < len(h.ring) {
+		h.ring[(h.head+h.size)%len(h.ring)] = e
+		h.size++
+		return
+	}
+	h.ring[h.head] = e
+	h.head = (h.head + 1) % len(h.ring)
+}
+
+// replay возвращает события после lastEventID. Если нужные события уже вытеснены из буфера
+// (или счетчик начался заново после рестарта), вместо них отдается одно событие reset.
+func (h *hub) replay(filter models.EventFilter, lastEventID int64) []models.CommentEvent {
+	if lastEventID <= 0 || lastEventID == h.seq {
+		return nil
+	}
+
+	oldest := h.seq + 1
+	if h.size > 0 {
+		oldest = h.ring[h.head].ID
+	}
+	if lastEventID > h.seq || lastEventID < oldest-1 {
+		return []models.CommentEvent{{ID: h.seq, Type: models.EventReset, CreatedAt: h.now()}}
+	}
+
+	var out []models.CommentEvent
+	for i := 0; i < h.size; i++ {
+		e := h.ring[(h.head+i)%len(h.ring)]
+		if e.ID > lastEventID && filter.Match(&e) {
+			out = append(out, e)
+		}
+	}
+
+	return out
+}
+
+func (h *hub) drop(sub *subscription) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	close(sub.ch)
+}
+
+func (s *subscription) Events() <-chan models.CommentEvent {
+	return s.ch
+}
+
+func (s *subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.drop(s)
+}
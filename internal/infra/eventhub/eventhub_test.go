@@ -0,0 +1,156 @@
+package eventhub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func publish(t *testing.T, h *hub, events ...models.CommentEvent) {
+	t.Helper()
+	for i := range events {
+		assert.NoError(t, h.Publish(context.Background(), &events[i]))
+	}
+}
+
+func drain(ch <-chan models.CommentEvent) []models.CommentEvent {
+	var out []models.CommentEvent
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return out
+			}
+			out = append(out, e)
+		default:
+			return out
+		}
+	}
+}
+
+func ids(events []models.CommentEvent) []int64 {
+	out := make([]int64, len(events))
+	for i, e := range events {
+		out[i] = e.ID
+	}
+	return out
+}
+
+func TestPublish_FilterByThreadAndSubtree(t *testing.T) {
+	h := New(10, 10).(*hub)
+
+	thread, err := h.Subscribe(models.EventFilter{Thread: "qa"}, 0)
+	assert.NoError(t, err)
+	subtree, err := h.Subscribe(models.EventFilter{Parent: 5}, 0)
+	assert.NoError(t, err)
+
+	publish(t, h,
+		models.CommentEvent{Type: models.EventCreated, Thread: "qa", CommentID: 6, Path: []int64{5}},
+		models.CommentEvent{Type: models.EventCreated, Thread: "news", CommentID: 7},
+		models.CommentEvent{Type: models.EventEdited, Thread: "qa", CommentID: 5},
+		models.CommentEvent{Type: models.EventCreated, Thread: "qa", CommentID: 8, Path: []int64{1}},
+	)
+
+	assert.Equal(t, []int64{1, 3, 4}, ids(drain(thread.Events())))
+	assert.Equal(t, []int64{1, 3}, ids(drain(subtree.Events())))
+}
+
+func TestPublish_ChangesOnlyForOptIn(t *testing.T) {
+	h := New(10, 10).(*hub)
+
+	readers, err := h.Subscribe(models.EventFilter{}, 0)
+	assert.NoError(t, err)
+	index, err := h.Subscribe(models.EventFilter{Changes: true}, 0)
+	assert.NoError(t, err)
+
+	publish(t, h,
+		models.CommentEvent{Type: models.EventChanged, Thread: "qa", CommentID: 1},
+		models.CommentEvent{Type: models.EventCreated, Thread: "qa", CommentID: 2},
+		models.CommentEvent{Type: models.EventChanged, AuthorID: 3},
+	)
+
+	assert.Equal(t, []int64{2}, ids(drain(readers.Events())))
+	assert.Equal(t, []int64{1, 2, 3}, ids(drain(index.Events())))
+}
+
+func TestSubscribe_ReplayAfterLastEventID(t *testing.T) {
+	h := New(10, 10).(*hub)
+	publish(t, h,
+		models.CommentEvent{Type: models.EventCreated, Thread: "qa", CommentID: 1},
+		models.CommentEvent{Type: models.EventCreated, Thread: "qa", CommentID: 2},
+		models.CommentEvent{Type: models.EventCreated, Thread: "news", CommentID: 3},
+		models.CommentEvent{Type: models.EventDeleted, Thread: "qa", CommentID: 1},
+	)
+
+	sub, err := h.Subscribe(models.EventFilter{Thread: "qa"}, 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int64{2, 4}, ids(drain(sub.Events())))
+}
+
+func TestSubscribe_GapSendsReset(t *testing.T) {
+	h := New(2, 10).(*hub)
+	for i := int64(1); i <= 5; i++ {
+		publish(t, h, models.CommentEvent{Type: models.EventCreated, CommentID: i})
+	}
+
+	sub, err := h.Subscribe(models.EventFilter{}, 1)
+	assert.NoError(t, err)
+
+	events := drain(sub.Events())
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, models.EventReset, events[0].Type)
+		assert.Equal(t, int64(5), events[0].ID)
+	}
+}
+
+func TestSubscribe_UnknownLastEventIDSendsReset(t *testing.T) {
+	h := New(10, 10).(*hub)
+	publish(t, h, models.CommentEvent{Type: models.EventCreated, CommentID: 1})
+
+	sub, err := h.Subscribe(models.EventFilter{}, 100)
+	assert.NoError(t, err)
+
+	events := drain(sub.Events())
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, models.EventReset, events[0].Type)
+	}
+}
+
+func TestPublish_SlowSubscriberDropped(t *testing.T) {
+	h := New(10, 2).(*hub)
+
+	slow, err := h.Subscribe(models.EventFilter{}, 0)
+	assert.NoError(t, err)
+
+	for i := int64(1); i <= 3; i++ {
+		publish(t, h, models.CommentEvent{Type: models.EventCreated, CommentID: i})
+	}
+
+	events := drain(slow.Events())
+	assert.Equal(t, []int64{1, 2}, ids(events))
+	_, ok := <-slow.Events()
+	assert.False(t, ok)
+	assert.Empty(t, h.subs)
+
+	slow.Close()
+}
+
+func TestPublish_KeepsExternalIDs(t *testing.T) {
+	h := New(10, 10).(*hub)
+	sub, err := h.Subscribe(models.EventFilter{}, 0)
+	assert.NoError(t, err)
+
+	publish(t, h,
+		models.CommentEvent{ID: 10, Type: models.EventCreated, CommentID: 1},
+		models.CommentEvent{ID: 10, Type: models.EventCreated, CommentID: 1},
+		models.CommentEvent{ID: 12, Type: models.EventEdited, CommentID: 1},
+	)
+
+	assert.Equal(t, []int64{10, 12}, ids(drain(sub.Events())))
+	sub.Close()
+	assert.Empty(t, h.subs)
+}
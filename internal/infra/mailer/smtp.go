@@ -0,0 +1,138 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ infra.NotificationSender = (*smtpSender)(nil)
+
+// smtpSender отправляет уведомления письмами. STARTTLS используется, если сервер его поддерживает.
+type smtpSender struct {
+	cfg     config.SMTPConfig
+	baseURL string
+	now     func() time.Time
+}
+
+func NewSMTP(cfg config.SMTPConfig, baseURL string) infra.NotificationSender {
+	return &smtpSender{cfg: cfg, baseURL: baseURL, now: time.Now}
+}
+
+func (s *smtpSender) Send(ctx context.Context, to *models.Recipient, n *models.Notification) error {
+	msg, err := s.message(to, n)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("dialer.DialContext: %w", err)
+	}
+	deadline := time.Now().Add(s.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp.NewClient: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(nil); err != nil {
+			return fmt.Errorf("c.StartTLS: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("c.Auth: %w", err)
+		}
+	}
+
+	if err := c.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("c.Mail: %w", err)
+	}
+	if err := c.Rcpt(to.Email); err != nil {
+		return fmt.Errorf("c.Rcpt: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("c.Data: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("w.Write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("w.Close: %w", err)
+	}
+
+	return c.Quit()
+}
+
+func (s *smtpSender) message(to *models.Recipient, n *models.Notification) ([]byte, error) {
+	var body bytes.Buffer
+	qp := quotedprintable.NewWriter(&body)
+	fmt.Fprintf(qp, "%s %s:\r\n\r\n%s\r\n\r\n", n.Actor, action(n), n.Excerpt)
+	fmt.Fprintf(qp, "%s%s\r\n", s.baseURL, link(n))
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("qp.Close: %w", err)
+	}
+
+	// Имена авторов вводят пользователи, поэтому заголовки кодируются (RFC 2047) - это же
+	// исключает внедрение заголовков через перевод строки.
+	subject := mime.QEncoding.Encode("utf-8", subjectPrefix(n)+n.Actor)
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", to.Email)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// link ведет на ветку родителя с якорем на комментарий, а для корневого комментария - на его собственную ветку.
+func link(n *models.Notification) string {
+	if n.ParentID != nil {
+		return fmt.Sprintf("/comments?parent=%d#comment-%d", *n.ParentID, n.CommentID)
+	}
+
+	return fmt.Sprintf("/comments?parent=%d", n.CommentID)
+}
+
+func action(n *models.Notification) string {
+	if n.Type == models.NotificationMention {
+		return "упомянул(а) вас в комментарии"
+	}
+
+	return "ответил(а) на ваш комментарий"
+}
+
+func subjectPrefix(n *models.Notification) string {
+	if n.Type == models.NotificationMention {
+		return "Вас упомянул "
+	}
+
+	return "Новый ответ от "
+}
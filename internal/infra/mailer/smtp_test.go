@@ -0,0 +1,163 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+// fakeSMTP - минимальный SMTP-сервер: принимает одно письмо и отдает конверт и текст в канал.
+type fakeSMTP struct {
+	ln   net.Listener
+	mail chan fakeMail
+}
+
+type fakeMail struct {
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	s := &fakeSMTP{ln: ln, mail: make(chan fakeMail, 1)}
+	go s.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+
+	return s
+}
+
+func (s *fakeSMTP) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) serve() {
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 fake ESMTP")
+
+	var m fakeMail
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250-fake\r\n250 8BITMIME")
+		case "MAIL":
+			m.from = envelopeAddr(line)
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			m.to = append(m.to, envelopeAddr(line))
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, _ := io.ReadAll(tp.DotReader())
+			m.data = string(data)
+			_ = tp.PrintfLine("250 OK")
+			s.mail <- m
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func envelopeAddr(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+
+	return line[start+1 : end]
+}
+
+func TestSMTPSend(t *testing.T) {
+	srv := newFakeSMTP(t)
+	sender := NewSMTP(config.SMTPConfig{
+		Host:    "127.0.0.1",
+		Port:    srv.port(),
+		From:    "noreply@example.com",
+		Timeout: time.Second,
+	}, "http://localhost:8080")
+
+	parentID := int64(7)
+	err := sender.Send(context.Background(), &models.Recipient{User: "alice", Email: "alice@example.com"}, &models.Notification{
+		ID:        1,
+		Recipient: "alice",
+		CommentID: 10,
+		ParentID:  &parentID,
+		Actor:     "Боб\r\nBcc: evil@example.com",
+		Excerpt:   "Согласен с вами",
+	})
+	assert.NoError(t, err)
+
+	var m fakeMail
+	select {
+	case m = <-srv.mail:
+	case <-time.After(time.Second):
+		t.Fatal("письмо не получено")
+	}
+
+	assert.Equal(t, "noreply@example.com", m.from)
+	assert.Equal(t, []string{"alice@example.com"}, m.to)
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(m.data)))
+	assert.NoError(t, err)
+	assert.Empty(t, msg.Header.Get("Bcc"))
+	assert.Equal(t, "alice@example.com", msg.Header.Get("To"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Новый ответ от Боб\r\nBcc: evil@example.com", subject)
+
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "Согласен с вами")
+	assert.Contains(t, string(body), "http://localhost:8080/comments?parent=7#comment-10")
+}
+
+func TestLink(t *testing.T) {
+	parentID := int64(7)
+
+	assert.Equal(t, "/comments?parent=7#comment-10", link(&models.Notification{Type: models.NotificationReply, CommentID: 10, ParentID: &parentID}))
+	assert.Equal(t, "/comments?parent=10", link(&models.Notification{Type: models.NotificationMention, CommentID: 10}))
+}
+
+func TestSMTPSend_Unavailable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+
+	sender := NewSMTP(config.SMTPConfig{Host: "127.0.0.1", Port: port, Timeout: time.Second}, "")
+
+	err = sender.Send(context.Background(), &models.Recipient{Email: "a@example.com"}, &models.Notification{})
+
+	assert.Error(t, err)
+}
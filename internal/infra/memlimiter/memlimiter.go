@@ -0,0 +1,79 @@
+package memlimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ infra.RateLimiter = (*memLimiter)(nil)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// memLimiter - token bucket в памяти процесса, бакеты простаивающих клиентов удаляются по idleTTL.
+type memLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	idleTTL   time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func New(idleTTL time.Duration) infra.RateLimiter {
+	return &memLimiter{
+		buckets:   make(map[string]*bucket),
+		idleTTL:   idleTTL,
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (l *memLimiter) Allow(_ context.Context, key string, limit models.RateLimit) (bool, time.Duration, error) {
+	if limit.RPS <= 0 || limit.Burst <= 0 {
+		return true, 0, nil
+	}
+
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	} else {
+		elapsed := now.Sub(b.last).Seconds()
+		b.tokens = min(float64(limit.Burst), b.tokens+elapsed*limit.RPS)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+
+	wait := time.Duration((1 - b.tokens) / limit.RPS * float64(time.Second))
+	return false, wait, nil
+}
+
+func (l *memLimiter) sweep(now time.Time) {
+	if l.idleTTL <= 0 || now.Sub(l.lastSweep) < l.idleTTL {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > l.idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
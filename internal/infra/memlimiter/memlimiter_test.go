@@ -0,0 +1,94 @@
+package memlimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func newTestLimiter(now *time.Time) *memLimiter {
+	l := New(time.Minute).(*memLimiter)
+	l.now = func() time.Time { return *now }
+	l.lastSweep = *now
+	return l
+}
+
+func TestAllow_BurstThenReject(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+	ctx := context.Background()
+	limit := models.RateLimit{RPS: 1, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		ok, _, err := l.Allow(ctx, "ip:1.1.1.1", limit)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	ok, retryAfter, err := l.Allow(ctx, "ip:1.1.1.1", limit)
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+}
+
+func TestAllow_Refill(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+	ctx := context.Background()
+	limit := models.RateLimit{RPS: 2, Burst: 1}
+
+	ok, _, _ := l.Allow(ctx, "user:test", limit)
+	assert.True(t, ok)
+
+	ok, retryAfter, _ := l.Allow(ctx, "user:test", limit)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	now = now.Add(500 * time.Millisecond)
+	ok, _, _ = l.Allow(ctx, "user:test", limit)
+	assert.True(t, ok)
+}
+
+func TestAllow_KeysAreIndependent(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+	ctx := context.Background()
+	limit := models.RateLimit{RPS: 1, Burst: 1}
+
+	ok, _, _ := l.Allow(ctx, "ip:1.1.1.1", limit)
+	assert.True(t, ok)
+
+	ok, _, _ = l.Allow(ctx, "ip:2.2.2.2", limit)
+	assert.True(t, ok)
+}
+
+func TestAllow_Disabled(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+
+	for i := 0; i < 10; i++ {
+		ok, _, err := l.Allow(context.Background(), "ip:1.1.1.1", models.RateLimit{})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
+
+func TestSweep_RemovesIdleBuckets(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+	limit := models.RateLimit{RPS: 1, Burst: 1}
+
+	_, _, _ = l.Allow(context.Background(), "ip:1.1.1.1", limit)
+	assert.Len(t, l.buckets, 1)
+
+	now = now.Add(2 * time.Minute)
+	_, _, _ = l.Allow(context.Background(), "ip:2.2.2.2", limit)
+
+	assert.Len(t, l.buckets, 1)
+	assert.Contains(t, l.buckets, "ip:2.2.2.2")
+}
@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qSnapshotComment = `SELECT to_jsonb(c) FROM comments c WHERE c.id = $1 FOR UPDATE`
+	qSnapshotThread  = `SELECT to_jsonb(t) FROM threads t WHERE t.key = $1 FOR UPDATE`
+	qSnapshotAuthor  = `SELECT to_jsonb(a) FROM authors a WHERE a.id = $1 FOR UPDATE`
+
+	qWriteAudit = `
+	INSERT INTO audit_log (actor, action, target_type, target_id, reason, before, after)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, created_at`
+
+	qAuditLogFilter = `
+	WHERE ($1 = '' OR actor = $1)
+		AND ($2 = '' OR action = $2)
+		AND ($3 = '' OR target_type = $3)
+		AND ($4 = '' OR target_id = $4)
+		AND ($5::timestamp IS NULL OR created_at >= $5)
+		AND ($6::timestamp IS NULL OR created_at <= $6)`
+
+	qAuditLog = `
+	SELECT id, actor, action, target_type, target_id, reason, before, after, created_at
+	FROM audit_log` + qAuditLogFilter + `
+	ORDER BY id DESC
+	LIMIT $7 OFFSET $8`
+
+	qAuditLogCount = `SELECT COUNT(*) FROM audit_log` + qAuditLogFilter
+)
+
+// withAudit выполняет изменение fn и пишет запись аудита со снимками объекта до и после изменения в одной транзакции.
+// Для изменений комментария туда же пишутся события outbox для вебхуков и живых обновлений,
+// для изменений профиля автора - событие для поискового индекса.
+func (r *postgresRepo) withAudit(ctx context.Context, entry *models.AuditEntry, fn func(tx *sql.Tx) error) error {
+	snapshotQuery := qSnapshotComment
+	switch entry.TargetType {
+	case models.AuditTargetThread:
+		snapshotQuery = qSnapshotThread
+	case models.AuditTargetAuthor:
+		snapshotQuery = qSnapshotAuthor
+	}
+
+	return retry.Do(func() error {
+		return r.withTx(ctx, func(tx *sql.Tx) error {
+			before, err := snapshot(ctx, tx, snapshotQuery, entry.TargetID)
+			if err != nil {
+				return err
+			}
+
+			var stateBefore *eventState
+			if entry.TargetType == models.AuditTargetComment {
+				if stateBefore, err = loadEventState(ctx, tx, entry.TargetID); err != nil {
+					return err
+				}
+			}
+
+			if err := fn(tx); err != nil {
+				return err
+			}
+
+			after, err := snapshot(ctx, tx, snapshotQuery, entry.TargetID)
+			if err != nil {
+				return err
+			}
+
+			entry.Before, entry.After = before, after
+			if err := tx.QueryRowContext(
+				ctx,
+				qWriteAudit,
+				entry.Actor,
+				entry.Action,
+				entry.TargetType,
+				entry.TargetID,
+				entry.Reason,
+				nullJSON(entry.Before),
+				nullJSON(entry.After),
+			).Scan(&entry.ID, &entry.CreatedAt); err != nil {
+				return fmt.Errorf("tx.QueryRowContext: %w", err)
+			}
+
+			if entry.TargetType == models.AuditTargetAuthor {
+				return writeAuthorEvent(ctx, tx, entry.TargetID)
+			}
+			if entry.TargetType != models.AuditTargetComment {
+				return nil
+			}
+			if eventType, ok := outboxEvents[entry.Action]; ok {
+				if err := writeOutbox(ctx, tx, eventType, entry.TargetID); err != nil {
+					return err
+				}
+			}
+
+			stateAfter, err := loadEventState(ctx, tx, entry.TargetID)
+			if err != nil {
+				return err
+			}
+			for _, e := range liveEvents(entry.Action, stateBefore, stateAfter) {
+				if err := writeLiveEvent(ctx, tx, e.typ, entry.TargetID, e.state); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}, retry.Strategy{Attempts: 3})
+}
+
+func (r *postgresRepo) GetAuditLog(ctx context.Context, filter *models.AuditFilter) (*models.AuditRes, error) {
+	result := &models.AuditRes{
+		Entries: make([]models.AuditEntry, 0, filter.Limit),
+		Total:   0,
+		Page:    filter.Page,
+		Limit:   filter.Limit,
+		Pages:   1,
+	}
+
+	args := []any{
+		filter.Actor,
+		filter.Action,
+		filter.TargetType,
+		filter.TargetID,
+		nullTime(filter.From),
+		nullTime(filter.To),
+	}
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qAuditLog,
+		append(args, filter.Limit, offset)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			e             models.AuditEntry
+			before, after []byte
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&e.Actor,
+			&e.Action,
+			&e.TargetType,
+			&e.TargetID,
+			&e.Reason,
+			&before,
+			&after,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		e.Before, e.After = before, after
+
+		result.Entries = append(result.Entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	countRow, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qAuditLogCount,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+	if err := countRow.Scan(&result.Total); err != nil {
+		return nil, fmt.Errorf("countRow.Scan: %w", err)
+	}
+	result.Pages = (result.Total + result.Limit - 1) / result.Limit
+
+	return result, nil
+}
+
+func snapshot(ctx context.Context, tx *sql.Tx, query string, id string) ([]byte, error) {
+	var out []byte
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&out); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+
+	return out, nil
+}
+
+// nullJSON - []byte драйвер передает как bytea, поэтому jsonb отправляем строкой.
+func nullJSON(b []byte) any {
+	if b == nil {
+		return nil
+	}
+	return string(b)
+}
+
+// nullTime - колонки TIMESTAMP хранят UTC без зоны.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qAuthorColumns = `id, username, display_name, avatar_url, comment_count, created_at, updated_at`
+
+	qGetAuthor = `SELECT ` + qAuthorColumns + ` FROM authors WHERE id = $1`
+
+	qUpdateAuthor = `
+	UPDATE authors SET display_name = $2, avatar_url = $3, updated_at = NOW()
+	WHERE id = $1
+	RETURNING ` + qAuthorColumns
+
+	// Неодобренные комментарии видны только самому автору ($2) и модераторам ($3)
+	qAuthorCommentsFilter = `
+	FROM comments
+	WHERE author_id = $1 AND deleted_at IS NULL AND (status = 'approved' OR $3 OR author = $2)`
+
+	qAuthorComments = `
+	SELECT ` + qCommentColumns + `, 0 AS level` + qAuthorCommentsFilter + `
+	ORDER BY created_at DESC, id DESC
+	LIMIT $4 OFFSET $5`
+
+	qAuthorCommentsCount = `SELECT COUNT(*)` + qAuthorCommentsFilter
+)
+
+func (r *postgresRepo) GetAuthor(ctx context.Context, id int64) (*models.Author, error) {
+	row, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetAuthor,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+
+	var out models.Author
+	if err := scanAuthor(row, &out); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return &out, nil
+}
+
+// UpdateAuthor сохраняет отображаемое имя и аватар и заполняет author актуальным профилем.
+func (r *postgresRepo) UpdateAuthor(ctx context.Context, author *models.Author, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, qUpdateAuthor, author.ID, author.DisplayName, author.AvatarURL)
+		if err := scanAuthor(row, author); err != nil {
+			return fmt.Errorf("tx.QueryRowContext: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetAuthorComments возвращает комментарии автора во всех тредах от новых к старым.
+func (r *postgresRepo) GetAuthorComments(ctx context.Context, id int64, pag *models.PagParam) (*models.CommentsRes, error) {
+	offset := (pag.Page - 1) * pag.Limit
+	viewer, moderator := viewerArgs(pag.Viewer)
+
+	return r.listComments(
+		ctx,
+		pag,
+		qAuthorComments,
+		[]any{id, viewer, moderator, pag.Limit, offset},
+		qAuthorCommentsCount,
+		[]any{id, viewer, moderator},
+	)
+}
+
+func scanAuthor(s scanner, a *models.Author) error {
+	return s.Scan(
+		&a.ID,
+		&a.Username,
+		&a.DisplayName,
+		&a.AvatarURL,
+		&a.CommentCount,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+}
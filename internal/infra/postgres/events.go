@@ -0,0 +1,369 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	eventsChannel   = "comment_events"
+	eventsBatchSize = 500
+	gapRetryDelay   = 250 * time.Millisecond
+
+	// Положение комментария для живого события: тред, родитель, предки от корня и видимость читателям
+	qEventState = `
+	SELECT c.thread_key, c.parent_id, c.status = 'approved' AND c.deleted_at IS NULL,
+		ARRAY(` + qGetAncestorIDs + `)
+	FROM comments c WHERE c.id = $1`
+
+	qWriteLiveEvent = `
+	INSERT INTO outbox (live_type, comment_id, thread_key, parent_id, path)
+	VALUES ($1, $2, $3, $4, $5)`
+
+	qWriteAuthorEvent = `INSERT INTO outbox (live_type, author_id) VALUES ($1, $2)`
+
+	qLastEventID = `SELECT COALESCE(MAX(id), 0) FROM outbox`
+	qEventsAfter = `
+	SELECT id, live_type, thread_key, comment_id, author_id, parent_id, path, created_at
+	FROM outbox
+	WHERE id > $1
+	ORDER BY id
+	LIMIT $2`
+	// Неразобранные события вебхуков не удаляются, даже если устарели
+	qCleanupEvents = `
+	DELETE FROM outbox
+	WHERE created_at < NOW() - make_interval(secs => $1) AND (event_type IS NULL OR dispatched_at IS NOT NULL)`
+)
+
+// eventState - положение комментария в дереве и его видимость читателям живых обновлений.
+type eventState struct {
+	thread   string
+	parentID *int64
+	path     []int64
+	visible  bool
+}
+
+func (s *eventState) samePlace(o *eventState) bool {
+	if s.thread != o.thread || (s.parentID == nil) != (o.parentID == nil) {
+		return false
+	}
+	return s.parentID == nil || *s.parentID == *o.parentID
+}
+
+// liveEvent - живое событие о комментарии в положении state.
+type liveEvent struct {
+	typ   models.EventType
+	state *eventState
+}
+
+// liveEvents определяет живые события изменения action по видимости комментария до и после него.
+// Читатели видят только одобренные неудаленные комментарии: появление - created (restored для
+// восстановления), исчезновение - deleted, перенос - удаление из старого места и появление в новом.
+// Остальные изменения публикуются как changed, их получает только поисковый индекс.
+func liveEvents(action string, before, after *eventState) []liveEvent {
+	if after == nil {
+		if before == nil {
+			return nil
+		}
+		return []liveEvent{{typ: models.EventChanged, state: before}}
+	}
+	if before == nil || !before.visible {
+		if !after.visible {
+			return []liveEvent{{typ: models.EventChanged, state: after}}
+		}
+		if action == models.ActionRestore {
+			return []liveEvent{{typ: models.EventRestored, state: after}}
+		}
+		return []liveEvent{{typ: models.EventCreated, state: after}}
+	}
+
+	switch {
+	case !after.visible:
+		return []liveEvent{{typ: models.EventDeleted, state: before}}
+	case !before.samePlace(after):
+		return []liveEvent{{typ: models.EventDeleted, state: before}, {typ: models.EventCreated, state: after}}
+	case action == models.ActionEdit:
+		return []liveEvent{{typ: models.EventEdited, state: after}}
+	}
+
+	return []liveEvent{{typ: models.EventChanged, state: after}}
+}
+
+// loadEventState читает положение комментария в транзакции изменения; nil - комментария нет.
+func loadEventState(ctx context.Context, tx *sql.Tx, id any) (*eventState, error) {
+	var (
+		s    eventState
+		path pq.Int64Array
+	)
+	if err := tx.QueryRowContext(ctx, qEventState, id).Scan(&s.thread, &s.parentID, &s.visible, &path); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("loadEventState: %w", err)
+	}
+	s.path = path
+
+	return &s, nil
+}
+
+// writeLiveEvent пишет живое событие в outbox в транзакции изменения; commentID = nil - событие о треде.
+func writeLiveEvent(ctx context.Context, tx *sql.Tx, typ models.EventType, commentID any, state *eventState) error {
+	if _, err := tx.ExecContext(
+		ctx,
+		qWriteLiveEvent,
+		typ,
+		commentID,
+		state.thread,
+		state.parentID,
+		pq.Array(state.path),
+	); err != nil {
+		return fmt.Errorf("writeLiveEvent: %w", err)
+	}
+
+	return nil
+}
+
+// writeAuthorEvent пишет в outbox событие об изменении профиля автора в транзакции изменения.
+func writeAuthorEvent(ctx context.Context, tx *sql.Tx, authorID any) error {
+	if _, err := tx.ExecContext(ctx, qWriteAuthorEvent, models.EventChanged, authorID); err != nil {
+		return fmt.Errorf("writeAuthorEvent: %w", err)
+	}
+
+	return nil
+}
+
+// eventRelay рассылает живые события из outbox. События пишутся в outbox в одной транзакции
+// с изменением, триггер отправляет pg_notify с id строки, а каждый экземпляр сервиса слушает канал,
+// дочитывает новые строки и публикует их в свой локальный хаб, откуда их получают подписчики.
+type eventRelay struct {
+	db    *dbpg.DB
+	repo  *postgresRepo
+	local infra.EventPublisher
+	cfg   config.EventsConfig
+
+	// Состояние слушателя, используется только из его горутины
+	lastID    int64
+	holeSince time.Time
+}
+
+// StartEventRelay запускает пересылку событий из outbox в локальный хаб до отмены ctx.
+func StartEventRelay(ctx context.Context, dbCfg config.DBConfig, cfg config.EventsConfig, local infra.EventPublisher) error {
+	db, err := dbpg.New(dbCfg.DSN, nil, &dbpg.Options{MaxOpenConns: 4})
+	if err != nil {
+		return fmt.Errorf("dbpg.New: %w", err)
+	}
+
+	b := &eventRelay{db: db, repo: &postgresRepo{db: db}, local: local, cfg: cfg}
+
+	row, err := db.QueryRowWithRetry(ctx, retry.Strategy{Attempts: 3}, qLastEventID)
+	if err != nil {
+		_ = db.Master.Close()
+		return fmt.Errorf("db.QueryRowWithRetry: %w", err)
+	}
+	if err := row.Scan(&b.lastID); err != nil {
+		_ = db.Master.Close()
+		return fmt.Errorf("row.Scan: %w", err)
+	}
+
+	listener := pq.NewListener(dbCfg.DSN, time.Second, time.Minute, logListenerEvent)
+	if err := listener.Listen(eventsChannel); err != nil {
+		_ = listener.Close()
+		_ = db.Master.Close()
+		return fmt.Errorf("listener.Listen: %w", err)
+	}
+
+	go b.listen(ctx, listener)
+
+	return nil
+}
+
+// listen дочитывает события по уведомлениям и по таймеру. Таймер страхует от потерянных
+// уведомлений; после переподключения (Notify отдает nil) события догоняются по таблице.
+func (b *eventRelay) listen(ctx context.Context, listener *pq.Listener) {
+	poll := time.NewTicker(b.cfg.PollInterval)
+	cleanup := time.NewTicker(time.Hour)
+	defer func() {
+		poll.Stop()
+		cleanup.Stop()
+		_ = listener.Close()
+		_ = b.db.Master.Close()
+	}()
+
+	var gapRetry <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-listener.Notify:
+		case <-poll.C:
+		case <-gapRetry:
+		case <-cleanup.C:
+			b.cleanup(ctx)
+			continue
+		}
+
+		gapRetry = nil
+		if waiting := b.catchUp(ctx); waiting {
+			gapRetry = time.After(gapRetryDelay)
+		}
+	}
+}
+
+// catchUp публикует в локальный хаб все живые события после lastID. Возвращает true, если
+// в последовательности id есть пропуск и слушатель ждет отстающую транзакцию.
+func (b *eventRelay) catchUp(ctx context.Context) bool {
+	for {
+		events, err := b.fetch(ctx)
+		if err != nil {
+			zlog.Logger.Error().Err(err).Msg("eventRelay.fetch")
+			return false
+		}
+
+		ready, waiting := b.advance(events, time.Now())
+		for i := range ready {
+			// Строки только для вебхуков занимают id, но подписчикам не отправляются
+			if ready[i].Type == "" {
+				continue
+			}
+			b.attachComment(ctx, &ready[i])
+			if err := b.local.Publish(ctx, &ready[i]); err != nil {
+				zlog.Logger.Warn().Err(err).Int64("event_id", ready[i].ID).Msg("local.Publish")
+			}
+		}
+
+		if waiting || len(events) < eventsBatchSize {
+			return waiting
+		}
+	}
+}
+
+// attachComment добавляет к событию комментарий в текущем виде. Комментарий, скрытый после
+// события, не отдается: о скрытии подписчики узнают следующим событием. К changed комментарий
+// не добавляется: поисковый индекс сам читает нужные ему поля.
+func (b *eventRelay) attachComment(ctx context.Context, e *models.CommentEvent) {
+	if e.CommentID == 0 || e.Type == models.EventDeleted || e.Type == models.EventReset || e.Type == models.EventChanged {
+		return
+	}
+
+	comment, err := b.repo.GetByID(ctx, e.CommentID)
+	if err != nil {
+		zlog.Logger.Warn().Err(err).Int64("id", e.CommentID).Msg("repo.GetByID")
+		return
+	}
+	if comment == nil || comment.Status != models.StatusApproved || comment.DeletedAt != nil {
+		return
+	}
+	e.Comment = comment
+}
+
+// advance отбирает события, которые можно отдать подписчикам, и сдвигает lastID.
+// id выдаются до коммита, поэтому событие с меньшим id может стать видимым позже
+// большего. На пропуске слушатель останавливается и ждет до GapTimeout; если id так
+// и не появился (откат транзакции, кеш последовательности), пропуск считается пустым.
+func (b *eventRelay) advance(events []models.CommentEvent, now time.Time) ([]models.CommentEvent, bool) {
+	for i := range events {
+		if events[i].ID != b.lastID+1 {
+			if b.holeSince.IsZero() {
+				b.holeSince = now
+			}
+			if now.Sub(b.holeSince) < b.cfg.GapTimeout {
+				return events[:i], true
+			}
+			zlog.Logger.Warn().
+				Int64("from", b.lastID+1).
+				Int64("to", events[i].ID-1).
+				Msg("пропуск в outbox, события не найдены")
+		}
+
+		b.holeSince = time.Time{}
+		b.lastID = events[i].ID
+	}
+
+	return events, false
+}
+
+func (b *eventRelay) fetch(ctx context.Context) ([]models.CommentEvent, error) {
+	rows, err := b.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qEventsAfter,
+		b.lastID,
+		eventsBatchSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("b.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.CommentEvent
+	for rows.Next() {
+		var (
+			e         models.CommentEvent
+			typ       sql.NullString
+			commentID sql.NullInt64
+			authorID  sql.NullInt64
+			path      pq.Int64Array
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&typ,
+			&e.Thread,
+			&commentID,
+			&authorID,
+			&e.ParentID,
+			&path,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+
+		e.Type = models.EventType(typ.String)
+		e.CommentID = commentID.Int64
+		e.AuthorID = authorID.Int64
+		e.Path = path
+		out = append(out, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return out, nil
+}
+
+func (b *eventRelay) cleanup(ctx context.Context) {
+	if b.cfg.Retention <= 0 {
+		return
+	}
+
+	if _, err := b.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qCleanupEvents,
+		b.cfg.Retention.Seconds(),
+	); err != nil {
+		zlog.Logger.Error().Err(err).Msg("eventRelay.cleanup")
+	}
+}
+
+func logListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		zlog.Logger.Warn().Err(err).Msg("соединение LISTEN потеряно")
+	case pq.ListenerEventReconnected:
+		zlog.Logger.Info().Msg("соединение LISTEN восстановлено")
+	case pq.ListenerEventConnectionAttemptFailed:
+		zlog.Logger.Warn().Err(err).Msg("не удалось переподключить LISTEN")
+	}
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func eventIDs(ids ...int64) []models.CommentEvent {
+	out := make([]models.CommentEvent, len(ids))
+	for i, id := range ids {
+		out[i] = models.CommentEvent{ID: id}
+	}
+	return out
+}
+
+func TestAdvance_Sequential(t *testing.T) {
+	b := &eventRelay{lastID: 10, cfg: config.EventsConfig{GapTimeout: time.Second}}
+
+	ready, waiting := b.advance(eventIDs(11, 12, 13), time.Now())
+
+	assert.False(t, waiting)
+	assert.Len(t, ready, 3)
+	assert.Equal(t, int64(13), b.lastID)
+}
+
+func TestAdvance_WaitsForGap(t *testing.T) {
+	now := time.Now()
+	b := &eventRelay{lastID: 10, cfg: config.EventsConfig{GapTimeout: time.Second}}
+
+	ready, waiting := b.advance(eventIDs(11, 13, 14), now)
+
+	assert.True(t, waiting)
+	assert.Equal(t, eventIDs(11), ready)
+	assert.Equal(t, int64(11), b.lastID)
+
+	// Отставшая транзакция закоммитилась
+	ready, waiting = b.advance(eventIDs(12, 13, 14), now.Add(100*time.Millisecond))
+
+	assert.False(t, waiting)
+	assert.Len(t, ready, 3)
+	assert.Equal(t, int64(14), b.lastID)
+	assert.True(t, b.holeSince.IsZero())
+}
+
+func TestAdvance_SkipsGapAfterTimeout(t *testing.T) {
+	now := time.Now()
+	b := &eventRelay{lastID: 10, cfg: config.EventsConfig{GapTimeout: time.Second}}
+
+	_, waiting := b.advance(eventIDs(12), now)
+	assert.True(t, waiting)
+
+	ready, waiting := b.advance(eventIDs(12), now.Add(2*time.Second))
+
+	assert.False(t, waiting)
+	assert.Equal(t, eventIDs(12), ready)
+	assert.Equal(t, int64(12), b.lastID)
+}
+
+func liveTypes(events []liveEvent) []models.EventType {
+	out := make([]models.EventType, len(events))
+	for i, e := range events {
+		out[i] = e.typ
+	}
+	return out
+}
+
+func TestLiveEvents(t *testing.T) {
+	parent, other := int64(1), int64(2)
+	visible := &eventState{thread: "qa", parentID: &parent, path: []int64{1}, visible: true}
+	hidden := &eventState{thread: "qa", parentID: &parent, path: []int64{1}}
+	moved := &eventState{thread: "qa", parentID: &other, path: []int64{2}, visible: true}
+	split := &eventState{thread: "offtopic", visible: true}
+
+	tests := []struct {
+		name          string
+		action        string
+		before, after *eventState
+		want          []models.EventType
+	}{
+		{name: "одобрение", action: models.ActionApprove, before: hidden, after: visible, want: []models.EventType{models.EventCreated}},
+		{name: "восстановление", action: models.ActionRestore, before: hidden, after: visible, want: []models.EventType{models.EventRestored}},
+		{name: "удаление", action: models.ActionDelete, before: visible, after: hidden, want: []models.EventType{models.EventDeleted}},
+		{name: "скрытие по жалобам", action: models.ActionAutoHide, before: visible, after: hidden, want: []models.EventType{models.EventDeleted}},
+		{name: "правка", action: models.ActionEdit, before: visible, after: visible, want: []models.EventType{models.EventEdited}},
+		{name: "правка на проверку", action: models.ActionEdit, before: visible, after: hidden, want: []models.EventType{models.EventDeleted}},
+		{name: "правка неодобренного", action: models.ActionEdit, before: hidden, after: hidden, want: []models.EventType{models.EventChanged}},
+		{name: "новый неодобренный", action: "", before: nil, after: hidden, want: []models.EventType{models.EventChanged}},
+		{name: "закрепление", action: models.ActionPin, before: visible, after: visible, want: []models.EventType{models.EventChanged}},
+		{name: "перенос", action: models.ActionMove, before: visible, after: moved, want: []models.EventType{models.EventDeleted, models.EventCreated}},
+		{name: "выделение ветки", action: models.ActionSplit, before: visible, after: split, want: []models.EventType{models.EventDeleted, models.EventCreated}},
+		{name: "комментария нет", action: models.ActionDelete, before: nil, after: nil, want: []models.EventType{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, liveTypes(liveEvents(tt.action, tt.before, tt.after)))
+		})
+	}
+}
+
+func TestLiveEvents_MoveUsesOldAndNewPlace(t *testing.T) {
+	parent := int64(1)
+	before := &eventState{thread: "qa", parentID: &parent, path: []int64{1}, visible: true}
+	after := &eventState{thread: "offtopic", visible: true}
+
+	events := liveEvents(models.ActionSplit, before, after)
+
+	assert.Same(t, before, events[0].state)
+	assert.Same(t, after, events[1].state)
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qDeleteMentions = `DELETE FROM comment_mentions WHERE comment_id = $1`
+	qInsertMentions = `
+	INSERT INTO comment_mentions (comment_id, username, offsets)
+	SELECT $1::INTEGER, lower(m.username), array_agg(m.pos ORDER BY m.pos)
+	FROM unnest($2::TEXT[], $3::INTEGER[]) AS m(username, pos)
+	GROUP BY lower(m.username)
+	ON CONFLICT DO NOTHING`
+
+	// У упоминаний, сохраненных до появления позиций, offsets пуст - они отдаются одной строкой с NULL
+	qGetMentions = `
+	SELECT m.comment_id, m.username, o.pos
+	FROM comment_mentions m
+	LEFT JOIN LATERAL unnest(m.offsets) AS o(pos) ON TRUE
+	WHERE m.comment_id = ANY($1)
+	ORDER BY m.comment_id, o.pos NULLS FIRST, m.username`
+
+	qMentionsFilter = `
+	FROM comment_mentions m
+	INNER JOIN comments c ON c.id = m.comment_id
+	WHERE m.username = lower($1) AND c.deleted_at IS NULL AND c.status = 'approved'`
+
+	qMentions = `
+	SELECT ` + qCommentColumnsC + `, 0 AS level` + qMentionsFilter + `
+	ORDER BY c.created_at DESC, c.id DESC
+	LIMIT $2 OFFSET $3`
+
+	qMentionsCount = `SELECT COUNT(*)` + qMentionsFilter
+)
+
+// saveMentions заменяет упоминания комментария; вызывается в транзакции создания или правки.
+func saveMentions(ctx context.Context, tx *sql.Tx, commentID int64, mentions []models.Mention) error {
+	if _, err := tx.ExecContext(ctx, qDeleteMentions, commentID); err != nil {
+		return fmt.Errorf("saveMentions: %w", err)
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	usernames := make([]string, len(mentions))
+	offsets := make([]int64, len(mentions))
+	for i, m := range mentions {
+		usernames[i] = m.Username
+		offsets[i] = int64(m.Offset)
+	}
+	if _, err := tx.ExecContext(ctx, qInsertMentions, commentID, pq.Array(usernames), pq.Array(offsets)); err != nil {
+		return fmt.Errorf("saveMentions: %w", err)
+	}
+
+	return nil
+}
+
+// attachMentions одним запросом подставляет сохраненные упоминания в комментарии страницы.
+func (r *postgresRepo) attachMentions(ctx context.Context, comments []models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(comments))
+	byID := make(map[int64]*models.Comment, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+		byID[comments[i].ID] = &comments[i]
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetMentions,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID int64
+		var m models.Mention
+		var offset sql.NullInt64
+		if err := rows.Scan(&commentID, &m.Username, &offset); err != nil {
+			return fmt.Errorf("rows.Scan: %w", err)
+		}
+		if offset.Valid {
+			m.Offset = int(offset.Int64)
+			m.Length = utf8.RuneCountInString(m.Username) + 1
+		}
+
+		c := byID[commentID]
+		c.Mentions = append(c.Mentions, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return nil
+}
+
+// GetMentions возвращает видимые комментарии, в которых упомянут пользователь, от новых к старым.
+func (r *postgresRepo) GetMentions(ctx context.Context, username string, pag *models.PagParam) (*models.CommentsRes, error) {
+	offset := (pag.Page - 1) * pag.Limit
+
+	return r.listComments(
+		ctx,
+		pag,
+		qMentions,
+		[]any{username, pag.Limit, offset},
+		qMentionsCount,
+		[]any{username},
+	)
+}
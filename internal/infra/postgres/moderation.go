@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qPendingComments = `
+	SELECT ` + qCommentColumns + `, 0 as level
+	FROM comments
+	WHERE status = 'pending' AND deleted_at IS NULL AND ($1 = '' OR thread_key = $1)
+	ORDER BY created_at
+	LIMIT $2 OFFSET $3`
+
+	qPendingCommentsCount = `
+	SELECT COUNT(*)
+	FROM comments
+	WHERE status = 'pending' AND deleted_at IS NULL AND ($1 = '' OR thread_key = $1)`
+
+	qSetStatus = `UPDATE comments SET status = $2, updated_at = NOW() WHERE id = $1`
+)
+
+func (r *postgresRepo) GetPending(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
+	offset := (pag.Page - 1) * pag.Limit
+
+	return r.listComments(
+		ctx,
+		pag,
+		qPendingComments, []any{pag.Thread, pag.Limit, offset},
+		qPendingCommentsCount, []any{pag.Thread},
+	)
+}
+
+func (r *postgresRepo) SetStatus(ctx context.Context, id int64, status models.CommentStatus, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, qSetStatus, id, status); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qSubtreeCTE = `
+	WITH RECURSIVE subtree AS (
+		SELECT id, 0 AS depth FROM comments WHERE id = $1
+		UNION ALL
+		SELECT c.id, s.depth + 1 FROM comments c
+		INNER JOIN subtree s ON c.parent_id = s.id
+	)`
+
+	qSubtreeHeight = qSubtreeCTE + `
+	SELECT COALESCE(MAX(depth), 0) FROM subtree`
+
+	qIsAncestor = `
+	WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id FROM comments WHERE id = $2
+		UNION ALL
+		SELECT c.id, c.parent_id FROM comments c
+		INNER JOIN ancestors a ON c.id = a.parent_id
+	)
+	SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $1)`
+
+	qSetParent = `UPDATE comments SET parent_id = $2 WHERE id = $1`
+
+	// Ответы наследуют тред корня, поэтому ветка переезжает целиком
+	qMoveSubtreeThread = qSubtreeCTE + `
+	UPDATE comments SET thread_key = $2
+	WHERE id IN (SELECT id FROM subtree) AND thread_key <> $2`
+
+	// Принятый ответ должен лежать в ветке своего корня; у остальных корней ($2 - новый корень ветки) отметка снимается
+	qClearMovedAnswers = qSubtreeCTE + `
+	UPDATE comments SET accepted_comment_id = NULL
+	WHERE accepted_comment_id IN (SELECT id FROM subtree) AND id <> $2`
+)
+
+// errMoveCycle прерывает транзакцию переноса, если новый родитель оказался в переносимой ветке.
+var errMoveCycle = errors.New("move cycle")
+
+// GetSubtreeHeight возвращает глубину ветки под комментарием: 0 - у комментария нет ответов.
+func (r *postgresRepo) GetSubtreeHeight(ctx context.Context, id int64) (int, error) {
+	row, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qSubtreeHeight,
+		id,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+
+	var height int
+	if err := row.Scan(&height); err != nil {
+		return 0, fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return height, nil
+}
+
+// MoveComment переносит комментарий вместе с веткой под parentID (nil - в корень) в тред thread.
+// rootID - корень ветки после переноса: отметки принятого ответа, оказавшегося вне ветки своего корня, снимаются.
+func (r *postgresRepo) MoveComment(
+	ctx context.Context,
+	id int64,
+	parentID *int64,
+	thread string,
+	rootID int64,
+	entry *models.AuditEntry,
+) error {
+	err := r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		// Блокировка нового родителя сериализует встречные переносы, иначе два параллельных запроса могут замкнуть цикл
+		if parentID != nil {
+			if _, err := tx.ExecContext(ctx, qLockComment, *parentID); err != nil {
+				return fmt.Errorf("tx.ExecContext: %w", err)
+			}
+
+			var cycle bool
+			if err := tx.QueryRowContext(ctx, qIsAncestor, id, *parentID).Scan(&cycle); err != nil {
+				return fmt.Errorf("tx.QueryRowContext: %w", err)
+			}
+			if cycle {
+				return errMoveCycle
+			}
+		}
+
+		return moveSubtree(ctx, tx, id, parentID, thread, rootID)
+	})
+	if errors.Is(err, errMoveCycle) {
+		return fmt.Errorf("нельзя перенести комментарий с id %d в его собственную ветку", id)
+	}
+
+	return err
+}
+
+// moveSubtree переподвешивает комментарий и переносит его ветку в тред thread.
+func moveSubtree(ctx context.Context, tx *sql.Tx, id int64, parentID *int64, thread string, rootID int64) error {
+	if _, err := tx.ExecContext(ctx, qSetParent, id, parentID); err != nil {
+		return fmt.Errorf("tx.ExecContext: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, qMoveSubtreeThread, id, thread); err != nil {
+		return fmt.Errorf("tx.ExecContext: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, qClearMovedAnswers, id, rootID); err != nil {
+		return fmt.Errorf("tx.ExecContext: %w", err)
+	}
+
+	return nil
+}
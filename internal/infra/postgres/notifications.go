@@ -0,0 +1,257 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qAddNotification = `
+	INSERT INTO notifications (recipient, type, comment_id, parent_id, actor)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (comment_id, recipient) DO NOTHING`
+
+	qNotificationColumns = `n.id, n.recipient, n.type, n.comment_id, n.parent_id, c.thread_key, n.actor,
+		LEFT(c.content, 200), n.read_at, n.created_at`
+
+	// Уведомления об удаленных и скрытых ответах не показываются
+	qUnreadNotificationsFilter = `
+	FROM notifications n
+	INNER JOIN comments c ON c.id = n.comment_id AND c.deleted_at IS NULL AND c.status = 'approved'
+	WHERE lower(n.recipient) = lower($1) AND n.read_at IS NULL`
+
+	qUnreadNotifications = `
+	SELECT ` + qNotificationColumns + qUnreadNotificationsFilter + `
+	ORDER BY n.id DESC
+	LIMIT $2 OFFSET $3`
+
+	qUnreadNotificationsCount = `SELECT COUNT(*)` + qUnreadNotificationsFilter
+
+	qMarkNotificationRead = `
+	UPDATE notifications SET read_at = NOW()
+	WHERE id = $1 AND lower(recipient) = lower($2) AND read_at IS NULL`
+
+	qMarkAllNotificationsRead = `
+	UPDATE notifications SET read_at = NOW()
+	WHERE lower(recipient) = lower($1) AND read_at IS NULL`
+
+	// Уведомление «арендуется» на время отправки сдвигом next_attempt_at, чтобы другой экземпляр
+	// не отправил его повторно; sent_at ставится только после успешной отправки. Выбираются уведомления
+	// получателей с известным адресом ($2) о видимых комментариях. Старые неотправленные уведомления
+	// (например, пока отправка была выключена) пропускаются.
+	qClaimNotifications = `
+	WITH claimed AS (
+		UPDATE notifications SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => $4)
+		WHERE id IN (
+			SELECT n.id FROM notifications n
+			INNER JOIN comments c ON c.id = n.comment_id AND c.deleted_at IS NULL AND c.status = 'approved'
+			WHERE n.sent_at IS NULL AND n.read_at IS NULL AND n.next_attempt_at <= NOW()
+				AND n.attempts < $3 AND n.created_at > NOW() - INTERVAL '1 day'
+				AND lower(n.recipient) = ANY($2)
+			ORDER BY n.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF n SKIP LOCKED
+		)
+		RETURNING *
+	)
+	SELECT ` + qNotificationColumns + `, n.attempts
+	FROM claimed n
+	INNER JOIN comments c ON c.id = n.comment_id
+	ORDER BY n.id`
+
+	qSaveNotificationAttempt = `
+	UPDATE notifications SET
+		sent_at = CASE WHEN $2 THEN NOW() ELSE sent_at END,
+		next_attempt_at = NOW() + make_interval(secs => $3)
+	WHERE id = $1`
+)
+
+func (r *postgresRepo) AddNotification(ctx context.Context, n *models.Notification) error {
+	if _, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qAddNotification,
+		n.Recipient,
+		n.Type,
+		n.CommentID,
+		n.ParentID,
+		n.Actor,
+	); err != nil {
+		return fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresRepo) GetUnreadNotifications(
+	ctx context.Context,
+	recipient string,
+	pag *models.PagParam,
+) (*models.NotificationsRes, error) {
+	result := &models.NotificationsRes{
+		Notifications: make([]models.Notification, 0, pag.Limit),
+		Total:         0,
+		Page:          pag.Page,
+		Limit:         pag.Limit,
+		Pages:         1,
+	}
+
+	offset := (pag.Page - 1) * pag.Limit
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qUnreadNotifications,
+		recipient,
+		pag.Limit,
+		offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n models.Notification
+		if err := scanNotification(rows, &n); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		result.Notifications = append(result.Notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	countRow, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qUnreadNotificationsCount,
+		recipient,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+	if err := countRow.Scan(&result.Total); err != nil {
+		return nil, fmt.Errorf("countRow.Scan: %w", err)
+	}
+	result.Pages = (result.Total + result.Limit - 1) / result.Limit
+
+	return result, nil
+}
+
+func (r *postgresRepo) MarkNotificationRead(ctx context.Context, recipient string, id int64) (bool, error) {
+	res, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qMarkNotificationRead,
+		id,
+		recipient,
+	)
+	if err != nil {
+		return false, fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("res.RowsAffected: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+func (r *postgresRepo) MarkAllNotificationsRead(ctx context.Context, recipient string) (int, error) {
+	res, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qMarkAllNotificationsRead,
+		recipient,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("res.RowsAffected: %w", err)
+	}
+
+	return int(n), nil
+}
+
+// ClaimNotifications выбирает до limit неотправленных уведомлений получателей recipients (в нижнем регистре)
+// и откладывает их повторную выборку на lease. Уведомления после maxAttempts попыток больше не выбираются.
+func (r *postgresRepo) ClaimNotifications(
+	ctx context.Context,
+	recipients []string,
+	limit, maxAttempts int,
+	lease time.Duration,
+) ([]models.Notification, error) {
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qClaimNotifications,
+		limit,
+		pq.Array(recipients),
+		maxAttempts,
+		lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.Notification
+	for rows.Next() {
+		var n models.Notification
+		if err := scanNotification(rows, &n, &n.Attempts); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		out = append(out, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return out, nil
+}
+
+// SaveNotificationAttempt сохраняет результат отправки: sent - уведомление отправлено,
+// иначе следующая попытка не раньше чем через retryIn.
+func (r *postgresRepo) SaveNotificationAttempt(ctx context.Context, id int64, sent bool, retryIn time.Duration) error {
+	if _, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qSaveNotificationAttempt,
+		id,
+		sent,
+		retryIn.Seconds(),
+	); err != nil {
+		return fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	return nil
+}
+
+func scanNotification(s scanner, n *models.Notification, extra ...any) error {
+	dest := []any{
+		&n.ID,
+		&n.Recipient,
+		&n.Type,
+		&n.CommentID,
+		&n.ParentID,
+		&n.ThreadKey,
+		&n.Actor,
+		&n.Excerpt,
+		&n.ReadAt,
+		&n.CreatedAt,
+	}
+
+	return s.Scan(append(dest, extra...)...)
+}
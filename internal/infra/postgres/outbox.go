@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	// Снимок строки берется в той же транзакции, что и изменение
+	qWriteOutbox = `
+	INSERT INTO outbox (event_type, comment_id, payload)
+	SELECT $1, c.id, to_jsonb(c) FROM comments c WHERE c.id = $2`
+
+	// Разбирает пачку событий вебхуков из outbox: по строке доставки на каждый подходящий активный
+	// вебхук. Разобранные события помечаются и удаляются по сроку хранения вместе с живыми событиями.
+	// SKIP LOCKED позволяет запускать несколько диспетчеров.
+	qFanOutOutbox = `
+	WITH batch AS (
+		UPDATE outbox SET dispatched_at = NOW()
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE event_type IS NOT NULL AND dispatched_at IS NULL
+			ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, payload
+	), deliveries AS (
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+		SELECT w.id, b.id, b.event_type, b.payload
+		FROM batch b
+		INNER JOIN webhooks w ON w.active AND (cardinality(w.events) = 0 OR b.event_type = ANY(w.events))
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	)
+	SELECT COUNT(*) FROM batch`
+)
+
+// outboxEvents - какие действия из журнала аудита публикуются во внешние системы.
+var outboxEvents = map[string]string{
+	models.ActionDelete:   models.OutboxCommentDeleted,
+	models.ActionRestore:  models.OutboxCommentRestored,
+	models.ActionEdit:     models.OutboxCommentEdited,
+	models.ActionApprove:  models.OutboxCommentApproved,
+	models.ActionReject:   models.OutboxCommentRejected,
+	models.ActionAutoHide: models.OutboxCommentHidden,
+	models.ActionPin:      models.OutboxCommentPinned,
+	models.ActionUnpin:    models.OutboxCommentUnpinned,
+	models.ActionMove:     models.OutboxCommentMoved,
+	models.ActionSplit:    models.OutboxCommentMoved,
+}
+
+func writeOutbox(ctx context.Context, tx *sql.Tx, eventType string, commentID any) error {
+	if _, err := tx.ExecContext(ctx, qWriteOutbox, eventType, commentID); err != nil {
+		return fmt.Errorf("writeOutbox: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresRepo) FanOutOutbox(ctx context.Context, limit int) (int, error) {
+	row, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qFanOutOutbox,
+		limit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+
+	var n int
+	if err := row.Scan(&n); err != nil {
+		return 0, fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	// Блокировка треда сериализует закрепления, чтобы лимит не превысили параллельные запросы
+	qLockThread  = `SELECT 1 FROM threads WHERE key = $1 FOR UPDATE`
+	qCountPinned = `SELECT COUNT(*) FROM comments WHERE thread_key = $1 AND pinned_at IS NOT NULL AND deleted_at IS NULL`
+	qSetPinned   = `UPDATE comments SET pinned_at = NOW() WHERE id = $1`
+	qClearPinned = `UPDATE comments SET pinned_at = NULL WHERE id = $1`
+)
+
+// errPinLimit прерывает транзакцию закрепления, когда лимит треда исчерпан.
+var errPinLimit = errors.New("pin limit reached")
+
+// PinComment закрепляет комментарий, если в треде закреплено меньше maxPinned неудаленных
+// комментариев; pinned = false - лимит исчерпан, ничего не изменено.
+func (r *postgresRepo) PinComment(
+	ctx context.Context,
+	id int64,
+	thread string,
+	maxPinned int,
+	entry *models.AuditEntry,
+) (bool, error) {
+	err := r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, qLockThread, thread); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+
+		var pinned int
+		if err := tx.QueryRowContext(ctx, qCountPinned, thread).Scan(&pinned); err != nil {
+			return fmt.Errorf("tx.QueryRowContext: %w", err)
+		}
+		if pinned >= maxPinned {
+			return errPinLimit
+		}
+
+		if _, err := tx.ExecContext(ctx, qSetPinned, id); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, errPinLimit) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *postgresRepo) UnpinComment(ctx context.Context, id int64, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, qClearPinned, id); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	})
+}
@@ -16,9 +16,34 @@ import (
 )
 
 const (
-	qCreate  = `INSERT INTO comments (parent_id, content, author) VALUES ($1, $2, $3)`
-	qGetByID = `SELECT id, parent_id, content, author, created_at, updated_at, deleted_at FROM comments WHERE id = $1`
-	qDelete  = `
+	// Колонки комментария в порядке scanComment. Отображаемое имя автора читается из authors,
+	// чтобы его смена сразу была видна во всех комментариях
+	qCommentColumns = `id, parent_id, thread_key, content, author, status, revision, created_at, updated_at, deleted_at,
+		upvotes, downvotes, score, pinned_at, accepted_comment_id, language,
+		author_id, (SELECT display_name FROM authors WHERE authors.id = author_id) AS author_name`
+	qCommentColumnsC = `c.id, c.parent_id, c.thread_key, c.content, c.author, c.status, c.revision, c.created_at, c.updated_at, c.deleted_at,
+		c.upvotes, c.downvotes, c.score, c.pinned_at, c.accepted_comment_id, c.language,
+		c.author_id, (SELECT display_name FROM authors WHERE authors.id = c.author_id) AS author_name`
+
+	qEnsureThread = `INSERT INTO threads (key) VALUES ($1) ON CONFLICT (key) DO NOTHING`
+	// Профиль создается с первым комментарием; пустое обновление нужно, чтобы RETURNING вернул существующий
+	qEnsureAuthor = `
+	INSERT INTO authors (username, display_name) VALUES ($1, $1)
+	ON CONFLICT (username) DO UPDATE SET username = EXCLUDED.username
+	RETURNING id, display_name`
+	qCreate = `
+	INSERT INTO comments (parent_id, thread_key, content, author, status, language, author_id) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, revision, created_at, updated_at`
+	qGetByID        = `SELECT ` + qCommentColumns + ` FROM comments WHERE id = $1`
+	qGetAncestorIDs = `
+	WITH RECURSIVE ancestors AS (
+		SELECT parent_id, 1 AS depth FROM comments WHERE id = $1
+		UNION ALL
+		SELECT c.parent_id, a.depth + 1 FROM comments c
+		INNER JOIN ancestors a ON c.id = a.parent_id
+	)
+	SELECT parent_id FROM ancestors WHERE parent_id IS NOT NULL ORDER BY depth DESC`
+	qDelete = `
 	WITH RECURSIVE comment_tree AS (
 		SELECT id FROM comments WHERE id = $1
 		UNION ALL
@@ -28,58 +53,121 @@ const (
 	)
 	UPDATE comments SET deleted_at = NOW() WHERE id IN (SELECT id FROM comment_tree)`
 
+	// Восстанавливаем только ту часть ветки, что была удалена вместе с комментарием
+	qRestore = `
+	WITH RECURSIVE target AS (
+		SELECT deleted_at FROM comments WHERE id = $1
+	),
+	comment_tree AS (
+		SELECT id FROM comments WHERE id = $1
+		UNION ALL
+		SELECT c.id FROM comments c
+		INNER JOIN comment_tree ct ON c.parent_id = ct.id
+		WHERE c.deleted_at = (SELECT deleted_at FROM target)
+	)
+	UPDATE comments SET deleted_at = NULL WHERE id IN (SELECT id FROM comment_tree)`
+
+	qUpdateContent = `
+	UPDATE comments SET content = $2, status = $3, revision = revision + 1, updated_at = NOW()
+	WHERE id = $1
+	RETURNING revision, updated_at`
+
+	// Язык комментария заранее неизвестен, поэтому поисковая строка разбирается конфигурациями
+	// всех языков (см. comment_search_config) и совпадения объединяются
+	qPlainQuery = `(plainto_tsquery('russian', $4) || plainto_tsquery('english', $4) || plainto_tsquery('simple', $4))`
+
+	// Неодобренные комментарии (и их ветки) видны только автору ($2) и модераторам ($3)
 	qCommentTreeCTE = `
 	WITH RECURSIVE comment_tree AS (
-        SELECT id, parent_id, content, author, created_at, updated_at, deleted_at, 0 as level
+        SELECT ` + qCommentColumns + `, search_vector, 0 as level
         FROM comments 
         WHERE id = $1
         
         UNION ALL
         
-        SELECT c.id, c.parent_id, c.content, c.author, c.created_at, c.updated_at, c.deleted_at, ct.level + 1
+        SELECT ` + qCommentColumnsC + `, c.search_vector, ct.level + 1
         FROM comments c
         INNER JOIN comment_tree ct ON c.parent_id = ct.id
+        WHERE c.status = 'approved' OR $3 OR c.author = $2
     )`
 
 	qCommentTreeCount = qCommentTreeCTE + `
 	SELECT COUNT(*) FROM comment_tree
-	WHERE id != $1 AND ($2 = '' OR to_tsvector('russian', content) @@ plainto_tsquery('russian', $2))`
+	WHERE id != $1 AND ($4 = '' OR search_vector @@ ` + qPlainQuery + `)`
 
-	qCommentTreePagAsc = qCommentTreeCTE + `
-	SELECT id, parent_id, content, author, created_at, updated_at, deleted_at, level 
+	qCommentTreePag = qCommentTreeCTE + `
+	SELECT ` + qCommentColumns + `, level
 	FROM comment_tree
-	WHERE id != $1 AND ($4 = '' OR to_tsvector('russian', content) @@ plainto_tsquery('russian', $4))
-	ORDER BY created_at
-	LIMIT $2 OFFSET $3`
-
-	qCommentTreePagDesc = qCommentTreeCTE + `
-	SELECT id, parent_id, content, author, created_at, updated_at, deleted_at, level 
-	FROM comment_tree
-	WHERE id != $1 AND ($4 = '' OR to_tsvector('russian', content) @@ plainto_tsquery('russian', $4))
-	ORDER BY created_at DESC
-	LIMIT $2 OFFSET $3`
+	WHERE id != $1 AND ($4 = '' OR search_vector @@ ` + qPlainQuery + `)
+	ORDER BY %s
+	LIMIT $5 OFFSET $6`
+
+	// Нерешенным ($4) считается тред без принятого ответа или с удаленным или скрытым ответом
+	qRootCommentsFilter = `
+	WHERE parent_id IS NULL AND deleted_at IS NULL AND ($1 = '' OR thread_key = ` + qResolveThread + `)
+		AND (status = 'approved' OR $3 OR author = $2)
+		AND (NOT $4 OR NOT EXISTS (
+			SELECT 1 FROM comments a
+			WHERE a.id = comments.accepted_comment_id AND a.deleted_at IS NULL AND a.status = 'approved'
+		))`
 
 	qRootComments = `
-	SELECT id, parent_id, content, author, created_at, updated_at, deleted_at, 0 as level
-	FROM comments 
-	WHERE parent_id IS NULL AND deleted_at IS NULL
-	ORDER BY created_at`
+	SELECT ` + qCommentColumns + `, 0 as level
+	FROM comments` + qRootCommentsFilter + `
+	ORDER BY %s
+	LIMIT $5 OFFSET $6`
+
+	qRootCommentsCount = `SELECT COUNT(*) FROM comments` + qRootCommentsFilter
 
-	qRootCommentsCount = `
-	SELECT COUNT(*) 
-	FROM comments 
-	WHERE parent_id IS NULL AND deleted_at IS NULL`
+	qSetAcceptedAnswer = `UPDATE comments SET accepted_comment_id = $2 WHERE id = $1`
+
+	qRecentContents = `
+	SELECT content FROM comments
+	WHERE author = $1 AND created_at >= NOW() - make_interval(secs => $2)
+	ORDER BY created_at DESC
+	LIMIT $3`
 
 	capComments = 50
+	// Сколько последних комментариев автора сравнивается с новым при поиске дублей
+	capRecentContents = 100
 )
 
+// commentOrder - выражения ORDER BY для режимов сортировки; закрепленные комментарии всегда идут
+// первыми в порядке закрепления (см. orderBy). Спорность растет с числом голосов
+// и тем сильнее, чем ближе «за» и «против»; горячие - рейтинг, затухающий со временем (как у HN).
+var commentOrder = map[string]string{
+	models.SortCreatedAsc:  `created_at, id`,
+	models.SortCreatedDesc: `created_at DESC, id DESC`,
+	models.SortTop:         `score DESC, created_at DESC, id DESC`,
+	models.SortControversial: `CASE WHEN upvotes = 0 OR downvotes = 0 THEN 0
+		ELSE POWER(upvotes + downvotes, LEAST(upvotes, downvotes)::FLOAT / GREATEST(upvotes, downvotes)) END DESC,
+		created_at DESC, id DESC`,
+	models.SortHot: `score / POWER(EXTRACT(EPOCH FROM NOW() - created_at) / 3600 + 2, 1.8) DESC, created_at DESC, id DESC`,
+}
+
+func orderBy(sort string) string {
+	order, ok := commentOrder[sort]
+	if !ok {
+		order = commentOrder[models.SortCreatedAsc]
+	}
+	return `pinned_at ASC NULLS LAST, ` + order
+}
+
 var _ infra.Database = (*postgresRepo)(nil)
 
 type postgresRepo struct {
 	db *dbpg.DB
 }
 
+type scanner interface {
+	Scan(dest ...any) error
+}
+
 func New(ctx context.Context, cfg config.DBConfig) (infra.Database, error) {
+	return connect(ctx, cfg)
+}
+
+func connect(ctx context.Context, cfg config.DBConfig) (*postgresRepo, error) {
 	db, err := dbpg.New(cfg.DSN, nil, &dbpg.Options{})
 	if err != nil {
 		zlog.Logger.Error().Err(err).Msg("dbpg.New")
@@ -99,16 +187,52 @@ func New(ctx context.Context, cfg config.DBConfig) (infra.Database, error) {
 }
 
 func (r *postgresRepo) Create(ctx context.Context, comment *models.Comment) error {
-	_, err := r.db.ExecWithRetry(
-		ctx,
-		retry.Strategy{Attempts: 3},
-		qCreate,
-		comment.ParentID,
-		comment.Content,
-		comment.Author,
-	)
-
-	return err
+	return retry.Do(func() error {
+		return r.withTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, qEnsureThread, comment.ThreadKey); err != nil {
+				return fmt.Errorf("tx.ExecContext: %w", err)
+			}
+			if err := tx.QueryRowContext(ctx, qEnsureAuthor, comment.Author).Scan(&comment.AuthorID, &comment.AuthorName); err != nil {
+				return fmt.Errorf("tx.QueryRowContext: %w", err)
+			}
+
+			if err := tx.QueryRowContext(
+				ctx,
+				qCreate,
+				comment.ParentID,
+				comment.ThreadKey,
+				comment.Content,
+				comment.Author,
+				comment.Status,
+				comment.Language,
+				comment.AuthorID,
+			).Scan(&comment.ID, &comment.Revision, &comment.CreatedAt, &comment.UpdatedAt); err != nil {
+				return fmt.Errorf("tx.QueryRowContext: %w", err)
+			}
+
+			if err := saveMentions(ctx, tx, comment.ID, comment.Mentions); err != nil {
+				return err
+			}
+			if err := saveQuotes(ctx, tx, comment.ID, comment.Quotes); err != nil {
+				return err
+			}
+
+			if err := writeOutbox(ctx, tx, models.OutboxCommentCreated, comment.ID); err != nil {
+				return err
+			}
+
+			state, err := loadEventState(ctx, tx, comment.ID)
+			if err != nil {
+				return err
+			}
+			for _, e := range liveEvents("", nil, state) {
+				if err := writeLiveEvent(ctx, tx, e.typ, comment.ID, e.state); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}, retry.Strategy{Attempts: 3})
 }
 
 func (r *postgresRepo) GetByID(ctx context.Context, id int64) (*models.Comment, error) {
@@ -123,108 +247,167 @@ func (r *postgresRepo) GetByID(ctx context.Context, id int64) (*models.Comment,
 	}
 
 	var out models.Comment
-	if err := row.Scan(
-		&out.ID,
-		&out.ParentID,
-		&out.Content,
-		&out.Author,
-		&out.CreatedAt,
-		&out.UpdatedAt,
-		&out.DeletedAt,
-	); err != nil {
+	if err := scanComment(row, &out); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("row.Scan: %w", err)
 	}
 
-	return &out, nil
-}
-
-func (r *postgresRepo) GetByParentID(ctx context.Context, parentID int64, pag *models.PagParam) (*models.CommentsRes, error) {
-	result := &models.CommentsRes{
-		Comments: make([]models.Comment, 0, capComments),
-		Total:    0,
-		Page:     pag.Page,
-		Limit:    pag.Limit,
-		Pages:    1,
+	comments := []models.Comment{out}
+	if err := r.attachQuotes(ctx, comments); err != nil {
+		return nil, err
 	}
-
-	query := ""
-	if pag.Sort == "created_at_asc" {
-		query = qCommentTreePagAsc
-	} else {
-		query = qCommentTreePagDesc
+	if err := r.attachMentions(ctx, comments); err != nil {
+		return nil, err
 	}
 
-	args := make([]any, 0, 4)
-	offset := (pag.Page - 1) * pag.Limit
-	args = append(args, parentID, pag.Limit, offset, pag.Search)
+	return &comments[0], nil
+}
 
+// GetAncestorIDs возвращает id предков комментария от корня до непосредственного родителя.
+func (r *postgresRepo) GetAncestorIDs(ctx context.Context, id int64) ([]int64, error) {
 	rows, err := r.db.QueryWithRetry(
 		ctx,
 		retry.Strategy{Attempts: 3},
-		query,
-		args...,
+		qGetAncestorIDs,
+		id,
 	)
 	if err != nil {
-		_ = rows.Close()
 		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
 	}
 	defer rows.Close()
 
+	var out []int64
 	for rows.Next() {
-		var comment models.Comment
-		if err := rows.Scan(
-			&comment.ID,
-			&comment.ParentID,
-			&comment.Content,
-			&comment.Author,
-			&comment.CreatedAt,
-			&comment.UpdatedAt,
-			&comment.DeletedAt,
-			&comment.Level,
-		); err != nil {
+		var ancestorID int64
+		if err := rows.Scan(&ancestorID); err != nil {
 			return nil, fmt.Errorf("rows.Scan: %w", err)
 		}
-
-		result.Comments = append(result.Comments, comment)
+		out = append(out, ancestorID)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows.Err: %w", err)
 	}
 
-	countRow, err := r.db.QueryRowWithRetry(
+	return out, nil
+}
+
+func (r *postgresRepo) GetByParentID(ctx context.Context, parentID int64, pag *models.PagParam) (*models.CommentsRes, error) {
+	query := fmt.Sprintf(qCommentTreePag, orderBy(pag.Sort))
+	viewer, moderator := viewerArgs(pag.Viewer)
+	offset := (pag.Page - 1) * pag.Limit
+
+	return r.listComments(
 		ctx,
-		retry.Strategy{Attempts: 3},
-		qCommentTreeCount,
-		parentID,
-		pag.Search,
+		pag,
+		query, []any{parentID, viewer, moderator, pag.Search, pag.Limit, offset},
+		qCommentTreeCount, []any{parentID, viewer, moderator, pag.Search},
 	)
-	if err != nil {
-		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
-	}
-	if err := countRow.Scan(&result.Total); err != nil {
-		return nil, fmt.Errorf("countRow.Scan: %w", err)
-	}
-	result.Pages = (result.Total + result.Limit - 1) / result.Limit
+}
 
-	return result, nil
+func (r *postgresRepo) Delete(ctx context.Context, id int64, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, qDelete, id); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *postgresRepo) Restore(ctx context.Context, id int64, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, qRestore, id); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *postgresRepo) UpdateContent(ctx context.Context, comment *models.Comment, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(
+			ctx,
+			qUpdateContent,
+			comment.ID,
+			comment.Content,
+			comment.Status,
+		).Scan(&comment.Revision, &comment.UpdatedAt); err != nil {
+			return fmt.Errorf("tx.QueryRowContext: %w", err)
+		}
+
+		return saveMentions(ctx, tx, comment.ID, comment.Mentions)
+	})
 }
 
-func (r *postgresRepo) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.ExecWithRetry(
+func (r *postgresRepo) GetRootComments(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
+	query, args, countArgs := rootCommentsQuery(pag)
+
+	return r.listComments(ctx, pag, query, args, qRootCommentsCount, countArgs)
+}
+
+// rootCommentsQuery собирает запрос страницы корневых комментариев и аргументы запросов страницы и количества.
+func rootCommentsQuery(pag *models.PagParam) (string, []any, []any) {
+	viewer, moderator := viewerArgs(pag.Viewer)
+	offset := (pag.Page - 1) * pag.Limit
+
+	query := fmt.Sprintf(qRootComments, orderBy(pag.Sort))
+	args := []any{pag.Thread, viewer, moderator, pag.Unresolved, pag.Limit, offset}
+
+	return query, args, args[:4]
+}
+
+// SetAcceptedAnswer отмечает принятый ответ (nil - снять отметку) у корневого комментария rootID.
+func (r *postgresRepo) SetAcceptedAnswer(ctx context.Context, rootID int64, answerID *int64, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, qSetAcceptedAnswer, rootID, answerID); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetRecentContents возвращает тексты последних комментариев автора за окно window, от новых к старым.
+func (r *postgresRepo) GetRecentContents(ctx context.Context, author string, window time.Duration) ([]string, error) {
+	rows, err := r.db.QueryWithRetry(
 		ctx,
 		retry.Strategy{Attempts: 3},
-		qDelete,
-		id,
+		qRecentContents,
+		author,
+		window.Seconds(),
+		capRecentContents,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var content string
+		if err := rows.Scan(&content); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		out = append(out, content)
+	}
 
-	return err
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return out, nil
 }
 
-func (r *postgresRepo) GetRootComments(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
+// listComments выполняет запрос страницы комментариев (колонки как у scanComment + level) и запрос общего количества.
+func (r *postgresRepo) listComments(
+	ctx context.Context,
+	pag *models.PagParam,
+	query string,
+	args []any,
+	countQuery string,
+	countArgs []any,
+) (*models.CommentsRes, error) {
 	result := &models.CommentsRes{
 		Comments: make([]models.Comment, 0, capComments),
 		Total:    0,
@@ -236,25 +419,17 @@ func (r *postgresRepo) GetRootComments(ctx context.Context, pag *models.PagParam
 	rows, err := r.db.QueryWithRetry(
 		ctx,
 		retry.Strategy{Attempts: 3},
-		qRootComments,
+		query,
+		args...,
 	)
 	if err != nil {
-		_ = rows.Close()
 		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
 	}
 	defer rows.Close()
+
 	for rows.Next() {
 		var comment models.Comment
-		if err := rows.Scan(
-			&comment.ID,
-			&comment.ParentID,
-			&comment.Content,
-			&comment.Author,
-			&comment.CreatedAt,
-			&comment.UpdatedAt,
-			&comment.DeletedAt,
-			&comment.Level,
-		); err != nil {
+		if err := scanComment(rows, &comment, &comment.Level); err != nil {
 			return nil, fmt.Errorf("rows.Scan: %w", err)
 		}
 
@@ -265,10 +440,21 @@ func (r *postgresRepo) GetRootComments(ctx context.Context, pag *models.PagParam
 		return nil, fmt.Errorf("rows.Err: %w", err)
 	}
 
+	if err := r.attachQuotes(ctx, result.Comments); err != nil {
+		return nil, err
+	}
+	if err := r.attachMentions(ctx, result.Comments); err != nil {
+		return nil, err
+	}
+	if err := r.attachReactions(ctx, result.Comments, pag.Viewer); err != nil {
+		return nil, err
+	}
+
 	countRow, err := r.db.QueryRowWithRetry(
 		ctx,
 		retry.Strategy{Attempts: 3},
-		qRootCommentsCount,
+		countQuery,
+		countArgs...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
@@ -280,3 +466,35 @@ func (r *postgresRepo) GetRootComments(ctx context.Context, pag *models.PagParam
 
 	return result, nil
 }
+
+func scanComment(s scanner, c *models.Comment, extra ...any) error {
+	dest := []any{
+		&c.ID,
+		&c.ParentID,
+		&c.ThreadKey,
+		&c.Content,
+		&c.Author,
+		&c.Status,
+		&c.Revision,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.DeletedAt,
+		&c.Upvotes,
+		&c.Downvotes,
+		&c.Score,
+		&c.PinnedAt,
+		&c.AcceptedCommentID,
+		&c.Language,
+		&c.AuthorID,
+		&c.AuthorName,
+	}
+
+	return s.Scan(append(dest, extra...)...)
+}
+
+func viewerArgs(viewer *models.Actor) (string, bool) {
+	if viewer == nil {
+		return "", false
+	}
+	return viewer.User, viewer.IsModerator()
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qInsertQuotes = `
+	INSERT INTO comment_quotes (comment_id, quoted_id, position)
+	SELECT $1, q.id, q.position FROM unnest($2::INTEGER[]) WITH ORDINALITY AS q(id, position)
+	ON CONFLICT DO NOTHING`
+
+	// Текст обрезается в запросе, чтобы не тянуть длинные комментарии целиком
+	qGetQuotes = `
+	SELECT q.comment_id, c.id, c.author, (SELECT display_name FROM authors WHERE authors.id = c.author_id),
+		LEFT(c.content, 200), c.status, c.deleted_at
+	FROM comment_quotes q
+	INNER JOIN comments c ON c.id = q.quoted_id
+	WHERE q.comment_id = ANY($1)
+	ORDER BY q.comment_id, q.position`
+)
+
+// saveQuotes сохраняет ссылки на цитируемые комментарии; вызывается в транзакции создания.
+func saveQuotes(ctx context.Context, tx *sql.Tx, commentID int64, quotes []models.Quote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(quotes))
+	for i, q := range quotes {
+		ids[i] = q.CommentID
+	}
+	if _, err := tx.ExecContext(ctx, qInsertQuotes, commentID, pq.Array(ids)); err != nil {
+		return fmt.Errorf("saveQuotes: %w", err)
+	}
+
+	return nil
+}
+
+// attachQuotes одним запросом подставляет цитаты в комментарии страницы.
+func (r *postgresRepo) attachQuotes(ctx context.Context, comments []models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(comments))
+	byID := make(map[int64]*models.Comment, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+		byID[comments[i].ID] = &comments[i]
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetQuotes,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID int64
+		var quoted models.Comment
+		if err := rows.Scan(
+			&commentID,
+			&quoted.ID,
+			&quoted.Author,
+			&quoted.AuthorName,
+			&quoted.Content,
+			&quoted.Status,
+			&quoted.DeletedAt,
+		); err != nil {
+			return fmt.Errorf("rows.Scan: %w", err)
+		}
+
+		c := byID[commentID]
+		c.Quotes = append(c.Quotes, models.NewQuote(&quoted))
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qAddReaction = `
+	INSERT INTO comment_reactions (comment_id, username, emoji) VALUES ($1, $2, $3)
+	ON CONFLICT DO NOTHING`
+	qRemoveReaction = `DELETE FROM comment_reactions WHERE comment_id = $1 AND username = $2 AND emoji = $3`
+
+	// Реакции идут в порядке появления первой из них, чтобы кнопки не прыгали при перерисовке
+	qGetReactions = `
+	SELECT comment_id, emoji, COUNT(*), bool_or(username = $2)
+	FROM comment_reactions
+	WHERE comment_id = ANY($1)
+	GROUP BY comment_id, emoji
+	ORDER BY comment_id, MIN(created_at), emoji`
+)
+
+// AddReaction ставит реакцию пользователя; повторная реакция ничего не меняет.
+func (r *postgresRepo) AddReaction(ctx context.Context, commentID int64, user, emoji string) error {
+	if _, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qAddReaction,
+		commentID,
+		user,
+		emoji,
+	); err != nil {
+		return fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveReaction снимает реакцию пользователя, если она была.
+func (r *postgresRepo) RemoveReaction(ctx context.Context, commentID int64, user, emoji string) error {
+	if _, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qRemoveReaction,
+		commentID,
+		user,
+		emoji,
+	); err != nil {
+		return fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	return nil
+}
+
+// GetReactions возвращает сводку реакций по комментариям; Reacted отмечает реакции user.
+func (r *postgresRepo) GetReactions(ctx context.Context, ids []int64, user string) (map[int64][]models.Reaction, error) {
+	out := make(map[int64][]models.Reaction)
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetReactions,
+		pq.Array(ids),
+		user,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID int64
+		var reaction models.Reaction
+		if err := rows.Scan(&commentID, &reaction.Emoji, &reaction.Count, &reaction.Reacted); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		out[commentID] = append(out[commentID], reaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return out, nil
+}
+
+// attachReactions одним запросом подставляет реакции в комментарии страницы.
+func (r *postgresRepo) attachReactions(ctx context.Context, comments []models.Comment, viewer *models.Actor) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+	}
+
+	user, _ := viewerArgs(viewer)
+	reactions, err := r.GetReactions(ctx, ids, user)
+	if err != nil {
+		return err
+	}
+	for i := range comments {
+		comments[i].Reactions = reactions[comments[i].ID]
+	}
+
+	return nil
+}
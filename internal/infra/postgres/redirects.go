@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qIsMerged    = `SELECT EXISTS (SELECT 1 FROM thread_redirects WHERE from_key = $1 AND comment_id IS NULL)`
+	qAddRedirect = `INSERT INTO thread_redirects (from_key, to_key, comment_id) VALUES ($1, $2, $3)`
+
+	// Ссылки на объединяемый тред сразу ведут в итоговый, чтобы разрешение всегда было в один шаг
+	qRetargetRedirects = `UPDATE thread_redirects SET to_key = $2 WHERE to_key = $1`
+	qMergeComments     = `UPDATE comments SET thread_key = $2 WHERE thread_key = $1`
+
+	qGetRedirects = `
+	SELECT from_key, to_key, comment_id, created_at FROM thread_redirects
+	WHERE from_key = $1
+	ORDER BY id`
+)
+
+// errThreadMerged прерывает транзакцию объединения, если целевой тред сам уже объединен с другим.
+var errThreadMerged = errors.New("thread already merged")
+
+// SplitThread делает комментарий id корнем нового треда to и переносит туда его ветку.
+func (r *postgresRepo) SplitThread(ctx context.Context, id int64, from, to string, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, qEnsureThread, to); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		if err := moveSubtree(ctx, tx, id, nil, to, id); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, qAddRedirect, from, to, id); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	})
+}
+
+// MergeThreads переносит все комментарии треда from в тред into. Возвращает число перенесенных комментариев.
+func (r *postgresRepo) MergeThreads(ctx context.Context, from, into string, entry *models.AuditEntry) (int64, error) {
+	var moved int64
+	err := r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		// Тред from заблокирован снимком аудита; блокировка into не дает встречному объединению замкнуть цикл
+		if _, err := tx.ExecContext(ctx, qLockThread, into); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+
+		var merged bool
+		if err := tx.QueryRowContext(ctx, qIsMerged, into).Scan(&merged); err != nil {
+			return fmt.Errorf("tx.QueryRowContext: %w", err)
+		}
+		if merged {
+			return errThreadMerged
+		}
+
+		res, err := tx.ExecContext(ctx, qMergeComments, from, into)
+		if err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		if moved, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("res.RowsAffected: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, qRetargetRedirects, from, into); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, qAddRedirect, from, into, nil); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+
+		// Комментарии переехали пачкой - клиентам проще перезагрузить тред целиком
+		return writeLiveEvent(ctx, tx, models.EventReset, nil, &eventState{thread: into})
+	})
+	if errors.Is(err, errThreadMerged) {
+		return 0, fmt.Errorf("тред %q уже объединен с другим тредом", into)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
+
+func (r *postgresRepo) GetThreadRedirects(ctx context.Context, key string) ([]models.ThreadRedirect, error) {
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetRedirects,
+		key,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]models.ThreadRedirect, 0)
+	for rows.Next() {
+		var rd models.ThreadRedirect
+		if err := rows.Scan(&rd.FromKey, &rd.ToKey, &rd.CommentID, &rd.CreatedAt); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		out = append(out, rd)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qAddReport = `
+	INSERT INTO comment_reports (comment_id, reporter, reason) VALUES ($1, $2, $3)
+	ON CONFLICT (comment_id, reporter) DO NOTHING
+	RETURNING id, created_at`
+
+	qCountReports = `SELECT COUNT(*) FROM comment_reports WHERE comment_id = $1`
+
+	// Комментарий скрывается по жалобам только один раз: после одобрения модератором
+	// новые жалобы его повторно не скрывают
+	qHideReported = `
+	UPDATE comments SET status = 'pending', updated_at = NOW()
+	WHERE id = $1 AND status = 'approved' AND deleted_at IS NULL
+		AND (SELECT COUNT(*) FROM comment_reports WHERE comment_id = $1) >= $2
+		AND NOT EXISTS (
+			SELECT 1 FROM audit_log
+			WHERE target_type = 'comment' AND target_id = $1::text AND action = 'auto_hide'
+		)`
+
+	qReportedComments = `
+	SELECT ` + qCommentColumnsC + `,
+		COUNT(r.id) AS reports, MAX(r.created_at), (array_agg(r.reason ORDER BY r.created_at DESC))[1:5]
+	FROM comment_reports r
+	INNER JOIN comments c ON c.id = r.comment_id
+	WHERE c.deleted_at IS NULL AND ($1 = '' OR c.thread_key = $1)
+	GROUP BY c.id
+	ORDER BY reports DESC, MAX(r.created_at) DESC
+	LIMIT $2 OFFSET $3`
+
+	qReportedCommentsCount = `
+	SELECT COUNT(DISTINCT r.comment_id)
+	FROM comment_reports r
+	INNER JOIN comments c ON c.id = r.comment_id
+	WHERE c.deleted_at IS NULL AND ($1 = '' OR c.thread_key = $1)`
+)
+
+// AddReport сохраняет жалобу и возвращает текущее число жалоб на комментарий.
+// Повторная жалоба того же автора не сохраняется, inserted = false.
+func (r *postgresRepo) AddReport(ctx context.Context, report *models.Report) (int, bool, error) {
+	var (
+		count    int
+		inserted bool
+	)
+
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(
+			ctx,
+			qAddReport,
+			report.CommentID,
+			report.Reporter,
+			report.Reason,
+		).Scan(&report.ID, &report.CreatedAt)
+		switch {
+		case err == sql.ErrNoRows:
+			inserted = false
+		case err != nil:
+			return fmt.Errorf("tx.QueryRowContext: %w", err)
+		default:
+			inserted = true
+		}
+
+		if err := tx.QueryRowContext(ctx, qCountReports, report.CommentID).Scan(&count); err != nil {
+			return fmt.Errorf("tx.QueryRowContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	return count, inserted, nil
+}
+
+// errNotHidden прерывает транзакцию скрытия, когда комментарий скрывать не нужно.
+var errNotHidden = errors.New("comment not hidden")
+
+// HideReported возвращает одобренный комментарий на модерацию, если жалоб на него не меньше
+// threshold и он еще не скрывался по жалобам. Строка комментария заблокирована на время проверки,
+// поэтому одновременные жалобы скрывают его один раз; hidden = false - ничего не изменено.
+func (r *postgresRepo) HideReported(ctx context.Context, id int64, threshold int, entry *models.AuditEntry) (bool, error) {
+	err := r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, qHideReported, id, threshold)
+		if err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("res.RowsAffected: %w", err)
+		}
+		if n == 0 {
+			return errNotHidden
+		}
+		return nil
+	})
+	if errors.Is(err, errNotHidden) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *postgresRepo) GetReported(ctx context.Context, pag *models.PagParam) (*models.ReportedRes, error) {
+	result := &models.ReportedRes{
+		Comments: make([]models.ReportedComment, 0, pag.Limit),
+		Total:    0,
+		Page:     pag.Page,
+		Limit:    pag.Limit,
+		Pages:    1,
+	}
+
+	offset := (pag.Page - 1) * pag.Limit
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qReportedComments,
+		pag.Thread,
+		pag.Limit,
+		offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rc models.ReportedComment
+		if err := scanComment(rows, &rc.Comment, &rc.Reports, &rc.LastReportedAt, pq.Array(&rc.Reasons)); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+
+		result.Comments = append(result.Comments, rc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	comments := make([]models.Comment, len(result.Comments))
+	for i := range result.Comments {
+		comments[i] = result.Comments[i].Comment
+	}
+	if err := r.attachMentions(ctx, comments); err != nil {
+		return nil, err
+	}
+	for i := range comments {
+		result.Comments[i].Comment = comments[i]
+	}
+
+	countRow, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qReportedCommentsCount,
+		pag.Thread,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+	if err := countRow.Scan(&result.Total); err != nil {
+		return nil, fmt.Errorf("countRow.Scan: %w", err)
+	}
+	result.Pages = (result.Total + result.Limit - 1) / result.Limit
+
+	return result, nil
+}
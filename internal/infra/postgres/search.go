@@ -0,0 +1,305 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	// Запрос разбирается конфигурациями всех языков, как и qPlainQuery
+	qWebQuery = `(websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) || websearch_to_tsquery('simple', $1))`
+
+	qSearchFTSMatch = `
+	FROM comments c
+	WHERE c.search_vector @@ ` + qWebQuery
+
+	// Нечеткое совпадение по триграммам: похожее слово в тексте или похожее имя автора.
+	// Порог задается на транзакцию (qSetTrgmThreshold), иначе операторы не используют индексы
+	qSearchFuzzyMatch = `
+	FROM comments c
+	WHERE ($1 <% c.content OR $1 % c.author)`
+
+	// Неодобренные комментарии находят только их автор ($8) и модераторы ($9)
+	qSearchFilter = `
+		AND ($2 = '' OR c.author = $2)
+		AND ($3::timestamp IS NULL OR c.created_at >= $3)
+		AND ($4::timestamp IS NULL OR c.created_at <= $4)
+		AND ($5 = '' OR c.thread_key = COALESCE(
+			(SELECT to_key FROM thread_redirects WHERE from_key = $5 AND comment_id IS NULL), $5
+		))
+		AND ($6 = 0 OR c.id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM comments WHERE id = $6
+				UNION ALL
+				SELECT r.id FROM comments r
+				INNER JOIN subtree s ON r.parent_id = s.id
+			)
+			SELECT id FROM subtree
+		))
+		AND ($7 OR c.deleted_at IS NULL)
+		AND (c.status = 'approved' OR $9 OR c.author = $8)`
+
+	qHeadlineOptions = `StartSel="` + models.HighlightStart + `", StopSel="` + models.HighlightStop + `", ` +
+		`MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … "`
+
+	// ts_headline дорогой, поэтому считается только для строк страницы (%s - релевантность и условие совпадения).
+	// При нечетком поиске совпадений по FTS обычно нет, и ts_headline отдает начало текста без подсветки
+	qSearchPage = `
+	SELECT ` + qCommentColumns + `, rank,
+		ts_headline(comment_search_config(language), content, ` + qWebQuery + `, '` + qHeadlineOptions + `')
+	FROM (
+		SELECT ` + qCommentColumnsC + `, %s AS rank %s` + qSearchFilter + `
+		ORDER BY rank DESC, c.created_at DESC, c.id DESC
+		LIMIT $10 OFFSET $11
+	) hits
+	ORDER BY rank DESC, created_at DESC, id DESC`
+
+	qSearchCount = `SELECT COUNT(*) %s` + qSearchFilter
+
+	qSetTrgmThreshold = `
+	SELECT set_config('pg_trgm.similarity_threshold', $1, true),
+		set_config('pg_trgm.word_similarity_threshold', $1, true)`
+
+	// Предки каждого найденного комментария от корня до родителя; текст обрезается в запросе
+	qSearchPaths = `
+	WITH RECURSIVE ancestors AS (
+		SELECT id AS hit_id, parent_id, 1 AS depth FROM comments WHERE id = ANY($1)
+		UNION ALL
+		SELECT a.hit_id, c.parent_id, a.depth + 1 FROM comments c
+		INNER JOIN ancestors a ON c.id = a.parent_id
+	)
+	SELECT a.hit_id, c.id, c.author, (SELECT display_name FROM authors WHERE authors.id = c.author_id),
+		LEFT(c.content, 80), c.status, c.deleted_at
+	FROM ancestors a
+	INNER JOIN comments c ON c.id = a.parent_id
+	ORDER BY a.hit_id, a.depth DESC`
+)
+
+var (
+	qSearchFTS        = fmt.Sprintf(qSearchPage, `ts_rank(c.search_vector, `+qWebQuery+`)`, qSearchFTSMatch)
+	qSearchFTSCount   = fmt.Sprintf(qSearchCount, qSearchFTSMatch)
+	qSearchFuzzy      = fmt.Sprintf(qSearchPage, `GREATEST(word_similarity($1, c.content), similarity($1, c.author))`, qSearchFuzzyMatch)
+	qSearchFuzzyCount = fmt.Sprintf(qSearchCount, qSearchFuzzyMatch)
+)
+
+var _ infra.Searcher = (*searcher)(nil)
+
+// searcher - поиск средствами Postgres (FTS и pg_trgm) на отдельном пуле соединений,
+// чтобы тяжелые поисковые запросы не занимали соединения основного репозитория.
+type searcher struct {
+	repo *postgresRepo
+}
+
+func NewSearcher(ctx context.Context, cfg config.DBConfig) (infra.Searcher, error) {
+	repo, err := connect(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &searcher{repo: repo}, nil
+}
+
+// Search ищет комментарии в режиме q.Mode: полнотекстовым поиском по запросу в синтаксисе websearch_to_tsquery
+// или нечетким по триграммам с порогом q.Threshold. Самые релевантные идут первыми.
+func (s *searcher) Search(ctx context.Context, q *models.SearchQuery) (*models.SearchRes, error) {
+	result := &models.SearchRes{
+		Hits:  make([]models.SearchHit, 0, q.Limit),
+		Total: 0,
+		Page:  q.Page,
+		Limit: q.Limit,
+		Pages: 1,
+		Mode:  q.Mode,
+	}
+
+	viewer, moderator := viewerArgs(q.Viewer)
+	args := []any{
+		q.Query,
+		q.Author,
+		nullTime(q.From),
+		nullTime(q.To),
+		q.Thread,
+		q.Subtree,
+		q.IncludeDeleted,
+		viewer,
+		moderator,
+	}
+	offset := (q.Page - 1) * q.Limit
+
+	var err error
+	if q.Mode == models.SearchModeFuzzy {
+		err = s.searchFuzzy(ctx, q.Threshold, args, offset, result)
+	} else {
+		err = s.searchFTS(ctx, args, offset, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Pages = (result.Total + result.Limit - 1) / result.Limit
+
+	if err := s.repo.attachSearchHits(ctx, result.Hits, q.Viewer); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *searcher) searchFTS(ctx context.Context, args []any, offset int, result *models.SearchRes) error {
+	rows, err := s.repo.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qSearchFTS,
+		append(args, result.Limit, offset)...,
+	)
+	if err != nil {
+		return fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	if err := scanSearchHits(rows, result); err != nil {
+		return err
+	}
+
+	countRow, err := s.repo.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qSearchFTSCount,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+	if err := countRow.Scan(&result.Total); err != nil {
+		return fmt.Errorf("countRow.Scan: %w", err)
+	}
+
+	return nil
+}
+
+// searchFuzzy выполняет нечеткий поиск в одной транзакции: порог сходства действует только в ней.
+func (s *searcher) searchFuzzy(
+	ctx context.Context,
+	threshold float64,
+	args []any,
+	offset int,
+	result *models.SearchRes,
+) error {
+	return retry.Do(func() error {
+		result.Hits = result.Hits[:0]
+
+		return s.repo.withTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, qSetTrgmThreshold, strconv.FormatFloat(threshold, 'f', -1, 64)); err != nil {
+				return fmt.Errorf("tx.ExecContext: %w", err)
+			}
+
+			rows, err := tx.QueryContext(ctx, qSearchFuzzy, append(args, result.Limit, offset)...)
+			if err != nil {
+				return fmt.Errorf("tx.QueryContext: %w", err)
+			}
+			if err := scanSearchHits(rows, result); err != nil {
+				return err
+			}
+
+			if err := tx.QueryRowContext(ctx, qSearchFuzzyCount, args...).Scan(&result.Total); err != nil {
+				return fmt.Errorf("tx.QueryRowContext: %w", err)
+			}
+
+			return nil
+		})
+	}, retry.Strategy{Attempts: 3})
+}
+
+func scanSearchHits(rows *sql.Rows, result *models.SearchRes) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var hit models.SearchHit
+		if err := scanComment(rows, &hit.Comment, &hit.Rank, &hit.Headline); err != nil {
+			return fmt.Errorf("rows.Scan: %w", err)
+		}
+		result.Hits = append(result.Hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return nil
+}
+
+// attachSearchHits дополняет найденные комментарии цитатами, упоминаниями, реакциями и путями от корня.
+func (r *postgresRepo) attachSearchHits(ctx context.Context, hits []models.SearchHit, viewer *models.Actor) error {
+	comments := make([]models.Comment, len(hits))
+	for i := range hits {
+		comments[i] = hits[i].Comment
+	}
+	if err := r.attachQuotes(ctx, comments); err != nil {
+		return err
+	}
+	if err := r.attachMentions(ctx, comments); err != nil {
+		return err
+	}
+	if err := r.attachReactions(ctx, comments, viewer); err != nil {
+		return err
+	}
+	for i := range comments {
+		hits[i].Comment = comments[i]
+	}
+
+	return r.attachSearchPaths(ctx, hits)
+}
+
+// attachSearchPaths одним запросом подставляет в результаты поиска пути от корня.
+func (r *postgresRepo) attachSearchPaths(ctx context.Context, hits []models.SearchHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(hits))
+	byID := make(map[int64]*models.SearchHit, len(hits))
+	for i := range hits {
+		ids[i] = hits[i].Comment.ID
+		byID[hits[i].Comment.ID] = &hits[i]
+	}
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qSearchPaths,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hitID int64
+		var ancestor models.Comment
+		if err := rows.Scan(
+			&hitID,
+			&ancestor.ID,
+			&ancestor.Author,
+			&ancestor.AuthorName,
+			&ancestor.Content,
+			&ancestor.Status,
+			&ancestor.DeletedAt,
+		); err != nil {
+			return fmt.Errorf("rows.Scan: %w", err)
+		}
+
+		hit := byID[hitID]
+		hit.Path = append(hit.Path, models.NewPathItem(&ancestor))
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return nil
+}
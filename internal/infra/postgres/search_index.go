@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	// Предки строки c от корня до родителя
+	qSearchDocPath = `
+	LEFT JOIN LATERAL (
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id, 1 AS depth FROM comments WHERE id = c.id
+			UNION ALL
+			SELECT p.parent_id, a.depth + 1 FROM comments p
+			INNER JOIN ancestors a ON p.id = a.parent_id
+		)
+		SELECT array_agg(parent_id ORDER BY depth DESC) AS ids FROM ancestors WHERE parent_id IS NOT NULL
+	) path ON true`
+
+	// Индекс хранит и удаленные, и неодобренные комментарии: видимость проверяется при поиске
+	qSearchDocs = `
+	SELECT ` + qCommentColumnsC + `, COALESCE(path.ids, '{}')
+	FROM comments c` + qSearchDocPath + `
+	WHERE c.id > $1
+	ORDER BY c.id
+	LIMIT $2`
+
+	qAuthorSearchDocs = `
+	SELECT ` + qCommentColumnsC + `, COALESCE(path.ids, '{}')
+	FROM comments c` + qSearchDocPath + `
+	WHERE c.author_id = $1 AND c.id > $2
+	ORDER BY c.id
+	LIMIT $3`
+
+	qSubtreeSearchDocs = qSubtreeCTE + `
+	SELECT ` + qCommentColumnsC + `, COALESCE(path.ids, '{}')
+	FROM comments c` + qSearchDocPath + `
+	WHERE c.id IN (SELECT id FROM subtree)
+	ORDER BY c.id`
+
+	// Неодобренные комментарии видны только автору ($2) и модераторам ($3)
+	qLoadSearchHits = `
+	SELECT ` + qCommentColumns + `
+	FROM comments
+	WHERE id = ANY($1) AND (status = 'approved' OR $3 OR author = $2)`
+)
+
+// GetSearchDocs возвращает до limit комментариев с id больше afterID для построения внешнего индекса.
+func (r *postgresRepo) GetSearchDocs(ctx context.Context, afterID int64, limit int) ([]models.SearchDoc, error) {
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qSearchDocs,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+
+	return scanSearchDocs(rows)
+}
+
+// GetAuthorSearchDocs возвращает до limit комментариев автора с id больше afterID: после смены
+// отображаемого имени их нужно переиндексировать.
+func (r *postgresRepo) GetAuthorSearchDocs(ctx context.Context, authorID, afterID int64, limit int) ([]models.SearchDoc, error) {
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qAuthorSearchDocs,
+		authorID,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+
+	return scanSearchDocs(rows)
+}
+
+// GetSubtreeSearchDocs возвращает комментарий со всей веткой под ним, включая удаленные ответы.
+func (r *postgresRepo) GetSubtreeSearchDocs(ctx context.Context, id int64) ([]models.SearchDoc, error) {
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qSubtreeSearchDocs,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+
+	return scanSearchDocs(rows)
+}
+
+// LoadSearchHits подставляет в результаты внешнего индекса актуальные комментарии в исходном порядке.
+// Комментарии, которых уже нет или которые стали не видны viewer, из результатов убираются.
+func (r *postgresRepo) LoadSearchHits(
+	ctx context.Context,
+	hits []models.SearchHit,
+	viewer *models.Actor,
+) ([]models.SearchHit, error) {
+	if len(hits) == 0 {
+		return hits, nil
+	}
+
+	ids := make([]int64, len(hits))
+	for i := range hits {
+		ids[i] = hits[i].Comment.ID
+	}
+
+	user, moderator := viewerArgs(viewer)
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qLoadSearchHits,
+		pq.Array(ids),
+		user,
+		moderator,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[int64]models.Comment, len(hits))
+	for rows.Next() {
+		var comment models.Comment
+		if err := scanComment(rows, &comment); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		byID[comment.ID] = comment
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	out := make([]models.SearchHit, 0, len(byID))
+	for _, hit := range hits {
+		comment, ok := byID[hit.Comment.ID]
+		if !ok {
+			continue
+		}
+		hit.Comment = comment
+		out = append(out, hit)
+	}
+
+	if err := r.attachSearchHits(ctx, out, viewer); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func scanSearchDocs(rows *sql.Rows) ([]models.SearchDoc, error) {
+	defer rows.Close()
+
+	var docs []models.SearchDoc
+	for rows.Next() {
+		var doc models.SearchDoc
+		if err := scanComment(rows, &doc.Comment, pq.Array(&doc.Path)); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return docs, nil
+}
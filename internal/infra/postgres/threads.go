@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	// Объединенный тред разрешается в тред, с которым его объединили
+	qResolveThread = `COALESCE((SELECT to_key FROM thread_redirects WHERE from_key = $1 AND comment_id IS NULL), $1)`
+
+	qGetThread  = `SELECT key, premoderation, locked_at, created_at, updated_at FROM threads WHERE key = ` + qResolveThread
+	qSaveThread = `
+	INSERT INTO threads (key, premoderation) VALUES ($1, $2)
+	ON CONFLICT (key) DO UPDATE SET premoderation = EXCLUDED.premoderation, updated_at = NOW()
+	RETURNING locked_at, created_at, updated_at`
+
+	qSetThreadLock = `
+	UPDATE threads SET locked_at = CASE WHEN $2 THEN COALESCE(locked_at, NOW()) ELSE NULL END, updated_at = NOW()
+	WHERE key = $1`
+)
+
+func (r *postgresRepo) GetThread(ctx context.Context, key string) (*models.Thread, error) {
+	row, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetThread,
+		key,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+
+	var out models.Thread
+	if err := row.Scan(&out.Key, &out.Premoderation, &out.LockedAt, &out.CreatedAt, &out.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return &out, nil
+}
+
+func (r *postgresRepo) SaveThread(ctx context.Context, thread *models.Thread, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(
+			ctx,
+			qSaveThread,
+			thread.Key,
+			thread.Premoderation,
+		).Scan(&thread.LockedAt, &thread.CreatedAt, &thread.UpdatedAt); err != nil {
+			return fmt.Errorf("tx.QueryRowContext: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *postgresRepo) SetThreadLock(ctx context.Context, key string, locked bool, entry *models.AuditEntry) error {
+	return r.withAudit(ctx, entry, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, qSetThreadLock, key, locked); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	})
+}
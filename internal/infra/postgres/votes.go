@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	// Голоса за один комментарий сериализуются блокировкой его строки, поэтому счетчики
+	// не расходятся с таблицей голосов при одновременном голосовании.
+	qLockComment = `SELECT 1 FROM comments WHERE id = $1 FOR UPDATE`
+	qGetVote     = `SELECT value FROM comment_votes WHERE comment_id = $1 AND username = $2`
+	qUpsertVote  = `
+	INSERT INTO comment_votes (comment_id, username, value) VALUES ($1, $2, $3)
+	ON CONFLICT (comment_id, username) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()`
+	qDeleteVote    = `DELETE FROM comment_votes WHERE comment_id = $1 AND username = $2`
+	qUpdateCounter = `
+	UPDATE comments SET upvotes = upvotes + $2, downvotes = downvotes + $3, score = score + $2 - $3
+	WHERE id = $1
+	RETURNING upvotes, downvotes, score`
+)
+
+// Vote сохраняет голос пользователя (VoteUp, VoteDown или VoteNone - отозвать) и пересчитывает
+// счетчики комментария на разницу со старым голосом.
+func (r *postgresRepo) Vote(ctx context.Context, commentID int64, user string, value int) (*models.VoteResult, error) {
+	out := &models.VoteResult{CommentID: commentID, Vote: value}
+
+	err := retry.Do(func() error {
+		return r.withTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, qLockComment, commentID); err != nil {
+				return fmt.Errorf("tx.ExecContext: %w", err)
+			}
+
+			old := models.VoteNone
+			if err := tx.QueryRowContext(ctx, qGetVote, commentID, user).Scan(&old); err != nil && err != sql.ErrNoRows {
+				return fmt.Errorf("tx.QueryRowContext: %w", err)
+			}
+
+			var err error
+			if value == models.VoteNone {
+				_, err = tx.ExecContext(ctx, qDeleteVote, commentID, user)
+			} else {
+				_, err = tx.ExecContext(ctx, qUpsertVote, commentID, user, value)
+			}
+			if err != nil {
+				return fmt.Errorf("tx.ExecContext: %w", err)
+			}
+
+			up, down := voteDelta(old, value)
+			if err := tx.QueryRowContext(ctx, qUpdateCounter, commentID, up, down).
+				Scan(&out.Upvotes, &out.Downvotes, &out.Score); err != nil {
+				return fmt.Errorf("tx.QueryRowContext: %w", err)
+			}
+
+			return nil
+		})
+	}, retry.Strategy{Attempts: 3})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// voteDelta - изменение счетчиков «за» и «против» при смене голоса с old на value.
+func voteDelta(old, value int) (up, down int) {
+	count := func(v int) (int, int) {
+		switch v {
+		case models.VoteUp:
+			return 1, 0
+		case models.VoteDown:
+			return 0, 1
+		}
+		return 0, 0
+	}
+
+	oldUp, oldDown := count(old)
+	newUp, newDown := count(value)
+
+	return newUp - oldUp, newDown - oldDown
+}
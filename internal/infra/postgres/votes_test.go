@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func TestVoteDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new int
+		up, down int
+	}{
+		{name: "новый голос за", old: models.VoteNone, new: models.VoteUp, up: 1, down: 0},
+		{name: "новый голос против", old: models.VoteNone, new: models.VoteDown, up: 0, down: 1},
+		{name: "смена на против", old: models.VoteUp, new: models.VoteDown, up: -1, down: 1},
+		{name: "смена на за", old: models.VoteDown, new: models.VoteUp, up: 1, down: -1},
+		{name: "повтор", old: models.VoteUp, new: models.VoteUp, up: 0, down: 0},
+		{name: "отзыв", old: models.VoteDown, new: models.VoteNone, up: 0, down: -1},
+		{name: "отзыв без голоса", old: models.VoteNone, new: models.VoteNone, up: 0, down: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up, down := voteDelta(tt.old, tt.new)
+
+			assert.Equal(t, tt.up, up)
+			assert.Equal(t, tt.down, down)
+		})
+	}
+}
+
+func TestRootCommentsQuery_SecondPage(t *testing.T) {
+	query, args, countArgs := rootCommentsQuery(&models.PagParam{Page: 2, Limit: 20, Thread: "qa", Sort: models.SortCreatedAsc})
+
+	assert.Contains(t, query, "LIMIT $5 OFFSET $6")
+	assert.Equal(t, []any{"qa", "", false, false, 20, 20}, args)
+	assert.Equal(t, []any{"qa", "", false, false}, countArgs)
+}
+
+func TestOrderBy_PinnedFirst(t *testing.T) {
+	assert.Equal(t, "pinned_at ASC NULLS LAST, "+commentOrder[models.SortCreatedAsc], orderBy("unknown"))
+	assert.Equal(t, "pinned_at ASC NULLS LAST, "+commentOrder[models.SortTop], orderBy(models.SortTop))
+}
@@ -0,0 +1,346 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/retry"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	qWebhookColumns = `id, url, secret, events, active, created_at, updated_at`
+
+	qCreateWebhook = `
+	INSERT INTO webhooks (url, secret, events, active) VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, updated_at`
+	qGetWebhook    = `SELECT ` + qWebhookColumns + ` FROM webhooks WHERE id = $1`
+	qGetWebhooks   = `SELECT ` + qWebhookColumns + ` FROM webhooks ORDER BY id`
+	qUpdateWebhook = `
+	UPDATE webhooks SET url = $2, events = $3, active = $4, updated_at = NOW()
+	WHERE id = $1
+	RETURNING updated_at`
+	qDeleteWebhook = `DELETE FROM webhooks WHERE id = $1`
+
+	qDeliveryColumns = `d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+		d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.updated_at, d.delivered_at`
+
+	// Доставка «арендуется» на время отправки сдвигом next_attempt_at, чтобы другой
+	// диспетчер не отправил ее повторно. Доставки отключенных вебхуков ждут включения.
+	qClaimDeliveries = `
+	UPDATE webhook_deliveries d
+	SET next_attempt_at = NOW() + make_interval(secs => $2), updated_at = NOW()
+	FROM webhooks w
+	WHERE w.id = d.webhook_id AND d.id IN (
+		SELECT dd.id FROM webhook_deliveries dd
+		INNER JOIN webhooks ww ON ww.id = dd.webhook_id AND ww.active
+		WHERE dd.status = 'pending' AND dd.next_attempt_at <= NOW()
+		ORDER BY dd.next_attempt_at
+		LIMIT $1
+		FOR UPDATE OF dd SKIP LOCKED
+	)
+	RETURNING ` + qDeliveryColumns + `, w.url, w.secret`
+
+	qSaveDeliveryAttempt = `
+	UPDATE webhook_deliveries SET
+		status = $2,
+		attempts = $3,
+		last_status_code = $4,
+		last_error = $5,
+		next_attempt_at = NOW() + make_interval(secs => $6),
+		delivered_at = CASE WHEN $2 = 'delivered' THEN NOW() ELSE delivered_at END,
+		updated_at = NOW()
+	WHERE id = $1`
+
+	qGetDelivery = `SELECT ` + qDeliveryColumns + ` FROM webhook_deliveries d WHERE d.id = $1`
+
+	qRedeliver = `
+	UPDATE webhook_deliveries
+	SET status = 'pending', attempts = 0, last_error = '', next_attempt_at = NOW(), updated_at = NOW()
+	WHERE id = $1`
+
+	qDeliveriesFilter = `
+	WHERE ($1 = 0 OR d.webhook_id = $1)
+		AND ($2 = '' OR d.status = $2)`
+
+	qGetDeliveries = `
+	SELECT ` + qDeliveryColumns + `
+	FROM webhook_deliveries d` + qDeliveriesFilter + `
+	ORDER BY d.id DESC
+	LIMIT $3 OFFSET $4`
+
+	qGetDeliveriesCount = `SELECT COUNT(*) FROM webhook_deliveries d` + qDeliveriesFilter
+)
+
+func (r *postgresRepo) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	row, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qCreateWebhook,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(webhook.Events),
+		webhook.Active,
+	)
+	if err != nil {
+		return fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+	if err := row.Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt); err != nil {
+		return fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresRepo) GetWebhook(ctx context.Context, id int64) (*models.Webhook, error) {
+	row, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetWebhook,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+
+	var out models.Webhook
+	if err := scanWebhook(row, &out); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return &out, nil
+}
+
+func (r *postgresRepo) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetWebhooks,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]models.Webhook, 0)
+	for rows.Next() {
+		var w models.Webhook
+		if err := scanWebhook(rows, &w); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		out = append(out, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *postgresRepo) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	row, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qUpdateWebhook,
+		webhook.ID,
+		webhook.URL,
+		pq.Array(webhook.Events),
+		webhook.Active,
+	)
+	if err != nil {
+		return fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+	if err := row.Scan(&webhook.UpdatedAt); err != nil {
+		return fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresRepo) DeleteWebhook(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qDeleteWebhook,
+		id,
+	); err != nil {
+		return fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDeliveries выбирает доставки, время которых пришло, и откладывает их на lease,
+// пока диспетчер их отправляет.
+func (r *postgresRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qClaimDeliveries,
+		limit,
+		lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.WebhookDelivery
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if err := scanDelivery(rows, &d, &d.URL, &d.Secret); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		out = append(out, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return out, nil
+}
+
+// SaveDeliveryAttempt сохраняет результат попытки; следующая попытка - через retryIn.
+func (r *postgresRepo) SaveDeliveryAttempt(ctx context.Context, d *models.WebhookDelivery, retryIn time.Duration) error {
+	if _, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qSaveDeliveryAttempt,
+		d.ID,
+		d.Status,
+		d.Attempts,
+		d.LastStatusCode,
+		d.LastError,
+		retryIn.Seconds(),
+	); err != nil {
+		return fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresRepo) GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	row, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetDelivery,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+
+	var out models.WebhookDelivery
+	if err := scanDelivery(row, &out); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return &out, nil
+}
+
+func (r *postgresRepo) GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) (*models.DeliveriesRes, error) {
+	result := &models.DeliveriesRes{
+		Deliveries: make([]models.WebhookDelivery, 0, filter.Limit),
+		Total:      0,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		Pages:      1,
+	}
+
+	args := []any{filter.WebhookID, filter.Status}
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := r.db.QueryWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetDeliveries,
+		append(args, filter.Limit, offset)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryWithRetry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if err := scanDelivery(rows, &d); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		result.Deliveries = append(result.Deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	countRow, err := r.db.QueryRowWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qGetDeliveriesCount,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.QueryRowWithRetry: %w", err)
+	}
+	if err := countRow.Scan(&result.Total); err != nil {
+		return nil, fmt.Errorf("countRow.Scan: %w", err)
+	}
+	result.Pages = (result.Total + result.Limit - 1) / result.Limit
+
+	return result, nil
+}
+
+// Redeliver возвращает доставку (в том числе из dead-letter) в очередь с обнулением попыток.
+func (r *postgresRepo) Redeliver(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecWithRetry(
+		ctx,
+		retry.Strategy{Attempts: 3},
+		qRedeliver,
+		id,
+	); err != nil {
+		return fmt.Errorf("r.db.ExecWithRetry: %w", err)
+	}
+
+	return nil
+}
+
+func scanWebhook(s scanner, w *models.Webhook) error {
+	return s.Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&w.Events), &w.Active, &w.CreatedAt, &w.UpdatedAt)
+}
+
+func scanDelivery(s scanner, d *models.WebhookDelivery, extra ...any) error {
+	var payload []byte
+	dest := []any{
+		&d.ID,
+		&d.WebhookID,
+		&d.EventID,
+		&d.EventType,
+		&payload,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.DeliveredAt,
+	}
+	if err := s.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	d.Payload = payload
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+var _ infra.WebhookSender = (*httpSender)(nil)
+
+type httpSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func New(timeout time.Duration) infra.WebhookSender {
+	return newSender(timeout, models.PublicIP)
+}
+
+// newSender создает отправителя, который соединяется только с адресами, разрешенными allow.
+// Адрес проверяется после разрешения имени, поэтому имя, указывающее во внутреннюю сеть, тоже
+// отклоняется. Прокси из окружения не используется, а редиректы не выполняются: иначе запрос
+// мог бы уйти на закрытый адрес в обход проверки.
+func newSender(timeout time.Duration, allow func(net.IP) bool) *httpSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return fmt.Errorf("net.SplitHostPort: %w", err)
+			}
+			if ip := net.ParseIP(host); ip == nil || !allow(ip) {
+				return fmt.Errorf("адрес %s закрыт для вебхуков", host)
+			}
+			return nil
+		},
+	}
+
+	return &httpSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{Proxy: nil, DialContext: dialer.DialContext},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Send отправляет подписанный POST. Успехом считается только ответ 2xx; код ответа
+// возвращается и при ошибке, чтобы его можно было сохранить в истории доставки.
+func (s *httpSender) Send(ctx context.Context, req *models.WebhookRequest) (int, error) {
+	ts := strconv.FormatInt(s.now().Unix(), 10)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "comment-tree-webhooks")
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderDelivery, strconv.FormatInt(req.DeliveryID, 10))
+	httpReq.Header.Set(HeaderTimestamp, ts)
+	httpReq.Header.Set(HeaderSignature, Signature(req.Secret, ts, req.Body))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("s.client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("подписчик ответил %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Signature - значение X-Webhook-Signature: HMAC-SHA256 от "<timestamp>.<тело>" на секрете вебхука.
+// Метка времени входит в подпись, чтобы подписчик мог отбрасывать повторы старых запросов.
+func Signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func TestSend_SignsRequest(t *testing.T) {
+	body := []byte(`{"id":1,"type":"comment.created"}`)
+
+	var got *http.Request
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := newSender(time.Second, allowAll)
+	s.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	code, err := s.Send(context.Background(), &models.WebhookRequest{
+		URL:        srv.URL,
+		Secret:     "s3cret",
+		DeliveryID: 42,
+		EventType:  models.OutboxCommentCreated,
+		Body:       body,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Equal(t, body, gotBody)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, models.OutboxCommentCreated, got.Header.Get(HeaderEvent))
+	assert.Equal(t, "42", got.Header.Get(HeaderDelivery))
+	assert.Equal(t, "1700000000", got.Header.Get(HeaderTimestamp))
+	assert.Equal(t, Signature("s3cret", "1700000000", body), got.Header.Get(HeaderSignature))
+}
+
+func TestSend_Non2xxIsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	code, err := newSender(time.Second, allowAll).Send(context.Background(), &models.WebhookRequest{URL: srv.URL})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestSend_RejectsPrivateAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	code, err := New(time.Second).Send(context.Background(), &models.WebhookRequest{URL: srv.URL})
+
+	assert.ErrorContains(t, err, "закрыт для вебхуков")
+	assert.Equal(t, 0, code)
+	assert.False(t, called)
+}
+
+func TestSend_DoesNotFollowRedirects(t *testing.T) {
+	redirected := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			redirected = true
+			return
+		}
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	code, err := newSender(time.Second, allowAll).Send(context.Background(), &models.WebhookRequest{URL: srv.URL})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusFound, code)
+	assert.False(t, redirected)
+}
+
+func allowAll(net.IP) bool { return true }
+
+func TestSignature(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac key
+	assert.Equal(t,
+		"sha256=9d713ed406bb7076d4123f0dc2c39d2df5c654ed4b0cd56b52c8b4c940bd63ae",
+		Signature("key", "1700000000", []byte("{}")),
+	)
+}
@@ -2,6 +2,7 @@ package infra
 
 import (
 	"context"
+	"time"
 
 	"github.com/sunr3d/comment-tree/models"
 )
@@ -10,7 +11,68 @@ import (
 type Database interface {
 	Create(ctx context.Context, comment *models.Comment) error
 	GetByID(ctx context.Context, id int64) (*models.Comment, error)
+	GetAncestorIDs(ctx context.Context, id int64) ([]int64, error)
+	GetSubtreeHeight(ctx context.Context, id int64) (int, error)
 	GetByParentID(ctx context.Context, parentID int64, pag *models.PagParam) (*models.CommentsRes, error)
 	GetRootComments(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error)
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id int64, entry *models.AuditEntry) error
+	Restore(ctx context.Context, id int64, entry *models.AuditEntry) error
+	UpdateContent(ctx context.Context, comment *models.Comment, entry *models.AuditEntry) error
+	SetAcceptedAnswer(ctx context.Context, rootID int64, answerID *int64, entry *models.AuditEntry) error
+	MoveComment(ctx context.Context, id int64, parentID *int64, thread string, rootID int64, entry *models.AuditEntry) error
+	GetMentions(ctx context.Context, username string, pag *models.PagParam) (*models.CommentsRes, error)
+	// Выборки для внешнего поискового индекса: все комментарии пачками по id, ветка комментария
+	// и загрузка найденных комментариев по id с учетом видимости для viewer
+	GetSearchDocs(ctx context.Context, afterID int64, limit int) ([]models.SearchDoc, error)
+	GetSubtreeSearchDocs(ctx context.Context, id int64) ([]models.SearchDoc, error)
+	GetAuthorSearchDocs(ctx context.Context, authorID, afterID int64, limit int) ([]models.SearchDoc, error)
+	LoadSearchHits(ctx context.Context, hits []models.SearchHit, viewer *models.Actor) ([]models.SearchHit, error)
+	GetRecentContents(ctx context.Context, author string, window time.Duration) ([]string, error)
+
+	GetThread(ctx context.Context, key string) (*models.Thread, error)
+	SaveThread(ctx context.Context, thread *models.Thread, entry *models.AuditEntry) error
+	SetThreadLock(ctx context.Context, key string, locked bool, entry *models.AuditEntry) error
+	SplitThread(ctx context.Context, id int64, from, to string, entry *models.AuditEntry) error
+	MergeThreads(ctx context.Context, from, into string, entry *models.AuditEntry) (int64, error)
+	GetThreadRedirects(ctx context.Context, key string) ([]models.ThreadRedirect, error)
+
+	GetAuthor(ctx context.Context, id int64) (*models.Author, error)
+	UpdateAuthor(ctx context.Context, author *models.Author, entry *models.AuditEntry) error
+	GetAuthorComments(ctx context.Context, id int64, pag *models.PagParam) (*models.CommentsRes, error)
+
+	GetPending(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error)
+	SetStatus(ctx context.Context, id int64, status models.CommentStatus, entry *models.AuditEntry) error
+	PinComment(ctx context.Context, id int64, thread string, maxPinned int, entry *models.AuditEntry) (bool, error)
+	UnpinComment(ctx context.Context, id int64, entry *models.AuditEntry) error
+
+	AddReport(ctx context.Context, report *models.Report) (int, bool, error)
+	HideReported(ctx context.Context, id int64, threshold int, entry *models.AuditEntry) (bool, error)
+	GetReported(ctx context.Context, pag *models.PagParam) (*models.ReportedRes, error)
+
+	AddReaction(ctx context.Context, commentID int64, user, emoji string) error
+	RemoveReaction(ctx context.Context, commentID int64, user, emoji string) error
+	GetReactions(ctx context.Context, ids []int64, user string) (map[int64][]models.Reaction, error)
+	Vote(ctx context.Context, commentID int64, user string, value int) (*models.VoteResult, error)
+
+	GetAuditLog(ctx context.Context, filter *models.AuditFilter) (*models.AuditRes, error)
+
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhook(ctx context.Context, id int64) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	FanOutOutbox(ctx context.Context, limit int) (int, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	SaveDeliveryAttempt(ctx context.Context, delivery *models.WebhookDelivery, retryIn time.Duration) error
+	GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) (*models.DeliveriesRes, error)
+	Redeliver(ctx context.Context, id int64) error
+
+	AddNotification(ctx context.Context, n *models.Notification) error
+	GetUnreadNotifications(ctx context.Context, recipient string, pag *models.PagParam) (*models.NotificationsRes, error)
+	MarkNotificationRead(ctx context.Context, recipient string, id int64) (bool, error)
+	MarkAllNotificationsRead(ctx context.Context, recipient string) (int, error)
+	ClaimNotifications(ctx context.Context, recipients []string, limit, maxAttempts int, lease time.Duration) ([]models.Notification, error)
+	SaveNotificationAttempt(ctx context.Context, id int64, sent bool, retryIn time.Duration) error
 }
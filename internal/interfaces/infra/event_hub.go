@@ -0,0 +1,27 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=EventPublisher --output=../../../mocks --filename=mock_event_publisher.go --with-expecter
+type EventPublisher interface {
+	Publish(ctx context.Context, event *models.CommentEvent) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=EventHub --output=../../../mocks --filename=mock_event_hub.go --with-expecter
+type EventHub interface {
+	EventPublisher
+	// Subscribe начинает подписку. Если lastEventID > 0, сначала отдаются пропущенные события
+	// из буфера, а при разрыве истории - событие reset.
+	Subscribe(filter models.EventFilter, lastEventID int64) (Subscription, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Subscription --output=../../../mocks --filename=mock_subscription.go --with-expecter
+type Subscription interface {
+	// Events закрывается, если подписчик не успевает читать события - клиенту нужно переподключиться.
+	Events() <-chan models.CommentEvent
+	Close()
+}
@@ -0,0 +1,12 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=NotificationSender --output=../../../mocks --filename=mock_notification_sender.go --with-expecter
+type NotificationSender interface {
+	Send(ctx context.Context, to *models.Recipient, n *models.Notification) error
+}
@@ -0,0 +1,13 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=RateLimiter --output=../../../mocks --filename=mock_rate_limiter.go --with-expecter
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit models.RateLimit) (bool, time.Duration, error)
+}
@@ -0,0 +1,12 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Searcher --output=../../../mocks --filename=mock_searcher.go --with-expecter
+type Searcher interface {
+	Search(ctx context.Context, q *models.SearchQuery) (*models.SearchRes, error)
+}
@@ -0,0 +1,12 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=WebhookSender --output=../../../mocks --filename=mock_webhook_sender.go --with-expecter
+type WebhookSender interface {
+	Send(ctx context.Context, req *models.WebhookRequest) (int, error)
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Authors --output=../../../mocks --filename=mock_authors.go --with-expecter
+type Authors interface {
+	GetAuthor(ctx context.Context, id int64) (*models.Author, error)
+	UpdateAuthor(ctx context.Context, author *models.Author, actor *models.Actor) error
+	GetAuthorComments(ctx context.Context, id int64, pag *models.PagParam) (*models.CommentsRes, error)
+}
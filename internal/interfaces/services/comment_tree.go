@@ -11,5 +11,12 @@ type CommentTree interface {
 	WriteComment(ctx context.Context, comment *models.Comment) error
 	GetComments(ctx context.Context, parentID int64, pag *models.PagParam) (*models.CommentsRes, error)
 	GetRootComments(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error)
-	DeleteComment(ctx context.Context, id int64) error
+	GetMentions(ctx context.Context, username string, pag *models.PagParam) (*models.CommentsRes, error)
+	EditComment(ctx context.Context, id int64, content string, actor *models.Actor, reason string) (*models.Comment, error)
+	DeleteComment(ctx context.Context, id int64, actor *models.Actor, reason string) error
+	RestoreComment(ctx context.Context, id int64, actor *models.Actor, reason string) error
+	MoveComment(ctx context.Context, id int64, parentID *int64, actor *models.Actor, reason string) (*models.Comment, error)
+	AcceptAnswer(ctx context.Context, id int64, actor *models.Actor) (*models.Comment, error)
+	UnacceptAnswer(ctx context.Context, id int64, actor *models.Actor) (*models.Comment, error)
+	Vote(ctx context.Context, id int64, user string, value int) (*models.VoteResult, error)
 }
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ContentFilter --output=../../../mocks --filename=mock_content_filter.go --with-expecter
+type ContentFilter interface {
+	Check(ctx context.Context, comment *models.Comment) (models.FilterResult, error)
+}
@@ -0,0 +1,9 @@
+package services
+
+import "github.com/sunr3d/comment-tree/models"
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=ContentRenderer --output=../../../mocks --filename=mock_content_renderer.go --with-expecter
+type ContentRenderer interface {
+	// Render возвращает безопасный HTML для текста комментария.
+	Render(comment *models.Comment) string
+}
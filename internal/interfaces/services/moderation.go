@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Moderation --output=../../../mocks --filename=mock_moderation.go --with-expecter
+type Moderation interface {
+	GetQueue(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error)
+	Approve(ctx context.Context, id int64, actor *models.Actor, reason string) error
+	Reject(ctx context.Context, id int64, actor *models.Actor, reason string) error
+	Pin(ctx context.Context, id int64, actor *models.Actor, reason string) error
+	Unpin(ctx context.Context, id int64, actor *models.Actor, reason string) error
+	GetThread(ctx context.Context, key string) (*models.Thread, error)
+	UpdateThread(ctx context.Context, thread *models.Thread, actor *models.Actor) error
+	LockThread(ctx context.Context, key string, locked bool, actor *models.Actor, reason string) error
+	SplitThread(ctx context.Context, id int64, key string, actor *models.Actor, reason string) (*models.Comment, error)
+	MergeThreads(ctx context.Context, from, into string, actor *models.Actor, reason string) (int64, error)
+	GetThreadRedirects(ctx context.Context, key string) ([]models.ThreadRedirect, error)
+	Report(ctx context.Context, report *models.Report) error
+	GetReported(ctx context.Context, pag *models.PagParam) (*models.ReportedRes, error)
+	GetAuditLog(ctx context.Context, filter *models.AuditFilter) (*models.AuditRes, error)
+}
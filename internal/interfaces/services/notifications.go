@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Notifications --output=../../../mocks --filename=mock_notifications.go --with-expecter
+type Notifications interface {
+	NotifyReply(ctx context.Context, reply, parent *models.Comment) error
+	NotifyMentions(ctx context.Context, comment *models.Comment) error
+	GetUnread(ctx context.Context, user string, pag *models.PagParam) (*models.NotificationsRes, error)
+	MarkRead(ctx context.Context, user string, id int64) error
+	MarkAllRead(ctx context.Context, user string) (int, error)
+	Run(ctx context.Context)
+}
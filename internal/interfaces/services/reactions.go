@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Reactions --output=../../../mocks --filename=mock_reactions.go --with-expecter
+type Reactions interface {
+	React(ctx context.Context, commentID int64, user, emoji string) ([]models.Reaction, error)
+	Unreact(ctx context.Context, commentID int64, user, emoji string) ([]models.Reaction, error)
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Search --output=../../../mocks --filename=mock_search.go --with-expecter
+type Search interface {
+	Search(ctx context.Context, q *models.SearchQuery) (*models.SearchRes, error)
+}
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Webhooks --output=../../../mocks --filename=mock_webhooks.go --with-expecter
+type Webhooks interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+	DeleteWebhook(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) (*models.DeliveriesRes, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int64) error
+	Run(ctx context.Context)
+}
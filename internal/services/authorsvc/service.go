@@ -0,0 +1,110 @@
+package authorsvc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.Authors = (*authorSvc)(nil)
+
+type authorSvc struct {
+	repo infra.Database
+}
+
+func New(repo infra.Database) *authorSvc {
+	return &authorSvc{repo: repo}
+}
+
+func (s *authorSvc) GetAuthor(ctx context.Context, id int64) (*models.Author, error) {
+	author, err := s.repo.GetAuthor(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetAuthor: %w", err)
+	}
+	if author == nil {
+		return nil, fmt.Errorf("автор с id %d не найден", id)
+	}
+
+	return author, nil
+}
+
+// UpdateAuthor меняет отображаемое имя и аватар; менять профиль может сам автор или модератор.
+// Пустое имя возвращает имя пользователя, пустой URL убирает аватар.
+func (s *authorSvc) UpdateAuthor(ctx context.Context, author *models.Author, actor *models.Actor) error {
+	current, err := s.GetAuthor(ctx, author.ID)
+	if err != nil {
+		return err
+	}
+	if !actor.IsModerator() && (actor == nil || actor.User == "" || actor.User != current.Username) {
+		return fmt.Errorf("нет прав на изменение профиля автора с id %d", author.ID)
+	}
+
+	author.DisplayName = strings.TrimSpace(author.DisplayName)
+	if author.DisplayName == "" {
+		author.DisplayName = current.Username
+	}
+	author.AvatarURL = strings.TrimSpace(author.AvatarURL)
+	if err := validate(author); err != nil {
+		return err
+	}
+
+	entry := models.NewAuthorAudit(actor.Key(), models.ActionAuthorProfile, author.ID, "")
+	if err := s.repo.UpdateAuthor(ctx, author, entry); err != nil {
+		return fmt.Errorf("s.repo.UpdateAuthor: %w", err)
+	}
+
+	return nil
+}
+
+// GetAuthorComments возвращает историю комментариев автора во всех тредах, от новых к старым.
+func (s *authorSvc) GetAuthorComments(ctx context.Context, id int64, pag *models.PagParam) (*models.CommentsRes, error) {
+	if _, err := s.GetAuthor(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if pag == nil {
+		pag = &models.PagParam{}
+	}
+	if pag.Page == 0 {
+		pag.Page = 1
+	}
+	if pag.Limit == 0 {
+		pag.Limit = 20
+	}
+
+	res, err := s.repo.GetAuthorComments(ctx, id, pag)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetAuthorComments: %w", err)
+	}
+
+	return res, nil
+}
+
+func validate(author *models.Author) error {
+	if utf8.RuneCountInString(author.DisplayName) > models.MaxDisplayNameLen {
+		return fmt.Errorf("некорректное отображаемое имя: не длиннее %d символов", models.MaxDisplayNameLen)
+	}
+	if strings.ContainsFunc(author.DisplayName, unicode.IsControl) {
+		return fmt.Errorf("некорректное отображаемое имя: управляющие символы недопустимы")
+	}
+
+	if author.AvatarURL == "" {
+		return nil
+	}
+	if len(author.AvatarURL) > models.MaxAvatarURLLen {
+		return fmt.Errorf("некорректный URL аватара: не длиннее %d символов", models.MaxAvatarURLLen)
+	}
+	u, err := url.Parse(author.AvatarURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("некорректный URL аватара: %q", author.AvatarURL)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package authorsvc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/comment-tree/mocks"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func alice() *models.Author {
+	return &models.Author{ID: 1, Username: "alice", DisplayName: "alice", CommentCount: 3}
+}
+
+func TestGetAuthor_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo)
+
+	repo.EXPECT().GetAuthor(mock.Anything, int64(7)).Return(nil, nil)
+
+	_, err := svc.GetAuthor(context.Background(), 7)
+
+	assert.ErrorContains(t, err, "не найден")
+}
+
+func TestUpdateAuthor_Self(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo)
+	ctx := context.Background()
+
+	repo.EXPECT().GetAuthor(ctx, int64(1)).Return(alice(), nil)
+	repo.EXPECT().UpdateAuthor(ctx, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, a *models.Author, entry *models.AuditEntry) error {
+			assert.Equal(t, "Алиса", a.DisplayName)
+			assert.Equal(t, "https://example.com/a.png", a.AvatarURL)
+			assert.Equal(t, models.ActionAuthorProfile, entry.Action)
+			assert.Equal(t, models.AuditTargetAuthor, entry.TargetType)
+			assert.Equal(t, "1", entry.TargetID)
+			assert.Equal(t, "user:alice", entry.Actor)
+			return nil
+		})
+
+	err := svc.UpdateAuthor(ctx, &models.Author{
+		ID:          1,
+		DisplayName: "  Алиса ",
+		AvatarURL:   "https://example.com/a.png",
+	}, &models.Actor{User: "alice"})
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateAuthor_EmptyNameResetsToUsername(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo)
+	ctx := context.Background()
+
+	repo.EXPECT().GetAuthor(ctx, int64(1)).Return(alice(), nil)
+	repo.EXPECT().UpdateAuthor(ctx, mock.Anything, mock.Anything).Return(nil)
+
+	author := &models.Author{ID: 1}
+	err := svc.UpdateAuthor(ctx, author, &models.Actor{User: "mod", Role: models.RoleModerator})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", author.DisplayName)
+}
+
+func TestUpdateAuthor_Forbidden(t *testing.T) {
+	actors := map[string]*models.Actor{
+		"аноним":              nil,
+		"другой пользователь": {User: "bob"},
+	}
+
+	for name, actor := range actors {
+		t.Run(name, func(t *testing.T) {
+			repo := mocks.NewDatabase(t)
+			svc := New(repo)
+
+			repo.EXPECT().GetAuthor(mock.Anything, int64(1)).Return(alice(), nil)
+
+			err := svc.UpdateAuthor(context.Background(), &models.Author{ID: 1, DisplayName: "Боб"}, actor)
+
+			assert.ErrorContains(t, err, "нет прав")
+		})
+	}
+}
+
+func TestUpdateAuthor_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		author *models.Author
+	}{
+		{name: "длинное имя", author: &models.Author{ID: 1, DisplayName: strings.Repeat("я", models.MaxDisplayNameLen+1)}},
+		{name: "управляющие символы", author: &models.Author{ID: 1, DisplayName: "Алиса\nadmin"}},
+		{name: "не http", author: &models.Author{ID: 1, AvatarURL: "javascript:alert(1)"}},
+		{name: "без хоста", author: &models.Author{ID: 1, AvatarURL: "https:///a.png"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewDatabase(t)
+			svc := New(repo)
+
+			repo.EXPECT().GetAuthor(mock.Anything, int64(1)).Return(alice(), nil)
+
+			err := svc.UpdateAuthor(context.Background(), tt.author, &models.Actor{User: "alice"})
+
+			assert.ErrorContains(t, err, "некорректн")
+		})
+	}
+}
+
+func TestGetAuthorComments_Defaults(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo)
+	ctx := context.Background()
+	res := &models.CommentsRes{Comments: []models.Comment{{ID: 5, AuthorID: 1}}, Total: 1, Page: 1, Limit: 20, Pages: 1}
+
+	repo.EXPECT().GetAuthor(ctx, int64(1)).Return(alice(), nil)
+	repo.EXPECT().GetAuthorComments(ctx, int64(1), &models.PagParam{Page: 1, Limit: 20}).Return(res, nil)
+
+	got, err := svc.GetAuthorComments(ctx, 1, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, res, got)
+}
+
+func TestGetAuthorComments_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo)
+
+	repo.EXPECT().GetAuthor(mock.Anything, int64(9)).Return(nil, nil)
+
+	_, err := svc.GetAuthorComments(context.Background(), 9, &models.PagParam{})
+
+	assert.ErrorContains(t, err, "не найден")
+}
@@ -3,6 +3,9 @@ package commenttreesvc
 import (
 	"context"
 	"fmt"
+	"slices"
+
+	"github.com/wb-go/wbf/zlog"
 
 	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
 	"github.com/sunr3d/comment-tree/internal/interfaces/services"
@@ -12,28 +15,135 @@ import (
 var _ services.CommentTree = (*commentTreeSvc)(nil)
 
 type commentTreeSvc struct {
-	repo infra.Database
+	repo          infra.Database
+	filter        services.ContentFilter
+	notifications services.Notifications
+	maxDepth      int
+	language      string
 }
 
-func New(repo infra.Database) *commentTreeSvc {
-	return &commentTreeSvc{repo: repo}
+// New создает сервис комментариев. События для подписчиков пишет репозиторий в транзакции изменения.
+// maxDepth - максимальная глубина ответа (у корня 0), 0 - без ограничения.
+// language - язык комментария, если его не удалось определить по тексту.
+func New(
+	repo infra.Database,
+	filter services.ContentFilter,
+	notifications services.Notifications,
+	maxDepth int,
+	language string,
+) *commentTreeSvc {
+	return &commentTreeSvc{
+		repo:          repo,
+		filter:        filter,
+		notifications: notifications,
+		maxDepth:      maxDepth,
+		language:      language,
+	}
 }
 
 func (s *commentTreeSvc) WriteComment(ctx context.Context, comment *models.Comment) error {
+	var parent *models.Comment
 	if comment.ParentID != nil {
-		parent, err := s.repo.GetByID(ctx, *comment.ParentID)
+		var err error
+		parent, err = s.repo.GetByID(ctx, *comment.ParentID)
 		if err != nil {
 			return fmt.Errorf("s.repo.GetByID: %w", err)
 		}
-		if parent == nil {
+		if parent == nil || isHidden(parent) {
 			return fmt.Errorf("родительский комментарий с id %d не найден", *comment.ParentID)
 		}
 		if parent.DeletedAt != nil {
 			return fmt.Errorf("родительский комментарий с id %d уже удален", *comment.ParentID)
 		}
+		if err := s.checkDepth(ctx, parent.ID); err != nil {
+			return err
+		}
+		comment.ThreadKey = parent.ThreadKey
+	}
+	if comment.ThreadKey == "" {
+		comment.ThreadKey = models.DefaultThread
+	}
+
+	comment.Status = models.StatusApproved
+	thread, err := s.repo.GetThread(ctx, comment.ThreadKey)
+	if err != nil {
+		return fmt.Errorf("s.repo.GetThread: %w", err)
+	}
+	if thread != nil {
+		// Объединенный тред разрешается в тред, с которым его объединили
+		comment.ThreadKey = thread.Key
+	}
+	if thread != nil && thread.LockedAt != nil {
+		return fmt.Errorf("тред %q закрыт для новых комментариев", comment.ThreadKey)
+	}
+	if thread != nil && thread.Premoderation {
+		comment.Status = models.StatusPending
+	}
+
+	if err := s.resolveQuotes(ctx, comment); err != nil {
+		return err
+	}
+
+	if err := s.applyFilter(ctx, comment); err != nil {
+		return err
+	}
+
+	if comment.Language == "" {
+		comment.Language = models.DetectLanguage(comment.Content, s.language)
+	}
+	comment.Mentions = models.ParseMentions(comment.Content)
+	if err := s.repo.Create(ctx, comment); err != nil {
+		return err
 	}
 
-	return s.repo.Create(ctx, comment)
+	if comment.Status == models.StatusApproved {
+		s.notifyReply(ctx, comment, parent)
+		s.notifyMentions(ctx, comment)
+	}
+
+	return nil
+}
+
+func (s *commentTreeSvc) EditComment(
+	ctx context.Context,
+	id int64,
+	content string,
+	actor *models.Actor,
+	reason string,
+) (*models.Comment, error) {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil || (isHidden(comment) && !canManage(comment, actor)) {
+		return nil, fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if comment.DeletedAt != nil {
+		return nil, fmt.Errorf("комментарий с id %d уже удален", id)
+	}
+	if !canManage(comment, actor) {
+		return nil, fmt.Errorf("нет прав на редактирование комментария с id %d", id)
+	}
+	if comment.Content == content {
+		return comment, nil
+	}
+
+	comment.Content = content
+	if err := s.applyFilter(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	comment.Mentions = models.ParseMentions(comment.Content)
+	entry := models.NewCommentAudit(actor.Key(), models.ActionEdit, id, reason)
+	if err := s.repo.UpdateContent(ctx, comment, entry); err != nil {
+		return nil, fmt.Errorf("s.repo.UpdateContent: %w", err)
+	}
+
+	if comment.Status == models.StatusApproved {
+		s.notifyMentions(ctx, comment)
+	}
+
+	return comment, nil
 }
 
 func (s *commentTreeSvc) GetComments(ctx context.Context, parentID int64, pag *models.PagParam) (*models.CommentsRes, error) {
@@ -41,7 +151,7 @@ func (s *commentTreeSvc) GetComments(ctx context.Context, parentID int64, pag *m
 		pag = &models.PagParam{
 			Page:  1,
 			Limit: 20,
-			Sort:  "created_at_asc",
+			Sort:  models.SortCreatedAsc,
 		}
 	}
 	if pag.Page == 0 {
@@ -51,24 +161,146 @@ func (s *commentTreeSvc) GetComments(ctx context.Context, parentID int64, pag *m
 		pag.Limit = 20
 	}
 	if pag.Sort == "" {
-		pag.Sort = "created_at_asc"
+		pag.Sort = models.SortCreatedAsc
 	}
 
 	comment, err := s.repo.GetByID(ctx, parentID)
 	if err != nil {
 		return nil, fmt.Errorf("s.repo.GetByID: %w", err)
 	}
-	if comment == nil {
+	if comment == nil || (isHidden(comment) && !canManage(comment, pag.Viewer)) {
 		return nil, fmt.Errorf("комментарий с id %d не найден", parentID)
 	}
 	/* if comment.DeletedAt != nil {
 		return nil, fmt.Errorf("комментарий с id %d уже удален", parentID)
 	} */
 
-	return s.repo.GetByParentID(ctx, parentID, pag)
+	result, err := s.repo.GetByParentID(ctx, parentID, pag)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.markAccepted(ctx, comment, result.Comments); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// markAccepted отмечает в выбранной ветке принятый ответ ее корневого комментария.
+func (s *commentTreeSvc) markAccepted(ctx context.Context, parent *models.Comment, comments []models.Comment) error {
+	root, err := s.getRoot(ctx, parent)
+	if err != nil {
+		return err
+	}
+	if root == nil || root.AcceptedCommentID == nil {
+		return nil
+	}
+
+	for i := range comments {
+		if comments[i].ID == *root.AcceptedCommentID {
+			comments[i].Accepted = true
+		}
+	}
+
+	return nil
+}
+
+// getRoot возвращает корневой комментарий ветки, в которой находится comment.
+func (s *commentTreeSvc) getRoot(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	if comment.ParentID == nil {
+		return comment, nil
+	}
+
+	path, err := s.repo.GetAncestorIDs(ctx, comment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetAncestorIDs: %w", err)
+	}
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	root, err := s.repo.GetByID(ctx, path[0])
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+
+	return root, nil
+}
+
+// AcceptAnswer отмечает ответ id принятым в его ветке. Отмечать может автор корневого комментария
+// или модератор; в ветке один принятый ответ, новая отметка заменяет прежнюю. Возвращает корень.
+func (s *commentTreeSvc) AcceptAnswer(ctx context.Context, id int64, actor *models.Actor) (*models.Comment, error) {
+	answer, root, err := s.answerRoot(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if answer.DeletedAt != nil {
+		return nil, fmt.Errorf("комментарий с id %d уже удален", id)
+	}
+	if root.AcceptedCommentID != nil && *root.AcceptedCommentID == id {
+		return root, nil
+	}
+
+	entry := models.NewCommentAudit(actor.Key(), models.ActionAcceptAnswer, root.ID, "")
+	if err := s.repo.SetAcceptedAnswer(ctx, root.ID, &id, entry); err != nil {
+		return nil, fmt.Errorf("s.repo.SetAcceptedAnswer: %w", err)
+	}
+	root.AcceptedCommentID = &id
+
+	return root, nil
+}
+
+// UnacceptAnswer снимает отметку с принятого ответа id. Возвращает корень ветки.
+func (s *commentTreeSvc) UnacceptAnswer(ctx context.Context, id int64, actor *models.Actor) (*models.Comment, error) {
+	_, root, err := s.answerRoot(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if root.AcceptedCommentID == nil || *root.AcceptedCommentID != id {
+		return nil, fmt.Errorf("комментарий с id %d не отмечен как принятый ответ", id)
+	}
+
+	entry := models.NewCommentAudit(actor.Key(), models.ActionUnacceptAnswer, root.ID, "")
+	if err := s.repo.SetAcceptedAnswer(ctx, root.ID, nil, entry); err != nil {
+		return nil, fmt.Errorf("s.repo.SetAcceptedAnswer: %w", err)
+	}
+	root.AcceptedCommentID = nil
+
+	return root, nil
 }
 
-func (s *commentTreeSvc) DeleteComment(ctx context.Context, id int64) error {
+// answerRoot находит ответ и корень его ветки и проверяет права actor на корень.
+func (s *commentTreeSvc) answerRoot(ctx context.Context, id int64, actor *models.Actor) (*models.Comment, *models.Comment, error) {
+	answer, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if answer == nil || isHidden(answer) {
+		return nil, nil, fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if answer.ParentID == nil {
+		return nil, nil, fmt.Errorf("комментарий с id %d корневой и не может быть ответом", id)
+	}
+
+	root, err := s.getRoot(ctx, answer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root == nil {
+		return nil, nil, fmt.Errorf("корневой комментарий для id %d не найден", id)
+	}
+	if root.DeletedAt != nil {
+		return nil, nil, fmt.Errorf("корневой комментарий с id %d уже удален", root.ID)
+	}
+	if !canManage(root, actor) {
+		return nil, nil, fmt.Errorf("нет прав отмечать ответ в ветке комментария с id %d", root.ID)
+	}
+
+	return answer, root, nil
+}
+
+func (s *commentTreeSvc) DeleteComment(ctx context.Context, id int64, actor *models.Actor, reason string) error {
 	comment, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("s.repo.GetByID: %w", err)
@@ -80,8 +312,135 @@ func (s *commentTreeSvc) DeleteComment(ctx context.Context, id int64) error {
 	if comment.DeletedAt != nil {
 		return fmt.Errorf("комментарий с id %d уже удален", id)
 	}
+	if !canManage(comment, actor) {
+		return fmt.Errorf("нет прав на удаление комментария с id %d", id)
+	}
 
-	return s.repo.Delete(ctx, id)
+	return s.repo.Delete(ctx, id, models.NewCommentAudit(actor.Key(), models.ActionDelete, id, reason))
+}
+
+func (s *commentTreeSvc) RestoreComment(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil {
+		return fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if comment.DeletedAt == nil {
+		return fmt.Errorf("комментарий с id %d не удален", id)
+	}
+
+	if comment.ParentID != nil {
+		parent, err := s.repo.GetByID(ctx, *comment.ParentID)
+		if err != nil {
+			return fmt.Errorf("s.repo.GetByID: %w", err)
+		}
+		if parent != nil && parent.DeletedAt != nil {
+			return fmt.Errorf("родительский комментарий с id %d удален, сначала восстановите его", parent.ID)
+		}
+	}
+
+	return s.repo.Restore(ctx, id, models.NewCommentAudit(actor.Key(), models.ActionRestore, id, reason))
+}
+
+// MoveComment переносит комментарий вместе с веткой под parentID (nil - в корень). Ветка переезжает
+// в тред нового родителя; перенос в собственную ветку и сверх максимальной глубины запрещен.
+func (s *commentTreeSvc) MoveComment(
+	ctx context.Context,
+	id int64,
+	parentID *int64,
+	actor *models.Actor,
+	reason string,
+) (*models.Comment, error) {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil {
+		return nil, fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if comment.DeletedAt != nil {
+		return nil, fmt.Errorf("комментарий с id %d уже удален", id)
+	}
+	if sameParent(comment.ParentID, parentID) {
+		return comment, nil
+	}
+
+	rootID, thread := id, comment.ThreadKey
+	if parentID != nil {
+		if *parentID == id {
+			return nil, fmt.Errorf("нельзя перенести комментарий с id %d в его собственную ветку", id)
+		}
+
+		parent, err := s.repo.GetByID(ctx, *parentID)
+		if err != nil {
+			return nil, fmt.Errorf("s.repo.GetByID: %w", err)
+		}
+		if parent == nil || isHidden(parent) {
+			return nil, fmt.Errorf("родительский комментарий с id %d не найден", *parentID)
+		}
+		if parent.DeletedAt != nil {
+			return nil, fmt.Errorf("родительский комментарий с id %d уже удален", *parentID)
+		}
+
+		path, err := s.repo.GetAncestorIDs(ctx, parent.ID)
+		if err != nil {
+			return nil, fmt.Errorf("s.repo.GetAncestorIDs: %w", err)
+		}
+		if slices.Contains(path, id) {
+			return nil, fmt.Errorf("нельзя перенести комментарий с id %d в его собственную ветку", id)
+		}
+
+		rootID, thread = parent.ID, parent.ThreadKey
+		if len(path) > 0 {
+			rootID = path[0]
+		}
+
+		if s.maxDepth > 0 {
+			height, err := s.repo.GetSubtreeHeight(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("s.repo.GetSubtreeHeight: %w", err)
+			}
+			if len(path)+1+height > s.maxDepth {
+				return nil, fmt.Errorf("превышена максимальная глубина вложенности %d", s.maxDepth)
+			}
+		}
+	}
+
+	if err := s.repo.MoveComment(ctx, id, parentID, thread, rootID, models.NewCommentAudit(actor.Key(), models.ActionMove, id, reason)); err != nil {
+		return nil, fmt.Errorf("s.repo.MoveComment: %w", err)
+	}
+	comment.ParentID, comment.ThreadKey = parentID, thread
+	if rootID != id {
+		comment.AcceptedCommentID = nil
+	}
+
+	return comment, nil
+}
+
+// checkDepth проверяет, что под родителем parentID еще можно отвечать.
+func (s *commentTreeSvc) checkDepth(ctx context.Context, parentID int64) error {
+	if s.maxDepth == 0 {
+		return nil
+	}
+
+	path, err := s.repo.GetAncestorIDs(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("s.repo.GetAncestorIDs: %w", err)
+	}
+	if len(path)+1 > s.maxDepth {
+		return fmt.Errorf("превышена максимальная глубина вложенности %d", s.maxDepth)
+	}
+
+	return nil
+}
+
+func sameParent(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
 func (s *commentTreeSvc) GetRootComments(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
@@ -89,7 +448,7 @@ func (s *commentTreeSvc) GetRootComments(ctx context.Context, pag *models.PagPar
 		pag = &models.PagParam{
 			Page:   1,
 			Limit:  20,
-			Sort:   "created_at_asc",
+			Sort:   models.SortCreatedAsc,
 			Search: "",
 		}
 	}
@@ -100,8 +459,129 @@ func (s *commentTreeSvc) GetRootComments(ctx context.Context, pag *models.PagPar
 		pag.Limit = 20
 	}
 	if pag.Sort == "" {
-		pag.Sort = "created_at_asc"
+		pag.Sort = models.SortCreatedAsc
 	}
 
 	return s.repo.GetRootComments(ctx, pag)
 }
+
+// Vote ставит, меняет или отзывает (VoteNone) голос пользователя за видимый комментарий.
+// Голосовать за свои комментарии нельзя.
+func (s *commentTreeSvc) Vote(ctx context.Context, id int64, user string, value int) (*models.VoteResult, error) {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil || isHidden(comment) {
+		return nil, fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if comment.DeletedAt != nil {
+		return nil, fmt.Errorf("комментарий с id %d уже удален", id)
+	}
+	if comment.Author == user {
+		return nil, fmt.Errorf("нет прав голосовать за свой комментарий с id %d", id)
+	}
+
+	res, err := s.repo.Vote(ctx, id, user, value)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.Vote: %w", err)
+	}
+
+	return res, nil
+}
+
+func (s *commentTreeSvc) GetMentions(ctx context.Context, username string, pag *models.PagParam) (*models.CommentsRes, error) {
+	if pag == nil {
+		pag = &models.PagParam{}
+	}
+	if pag.Page == 0 {
+		pag.Page = 1
+	}
+	if pag.Limit == 0 {
+		pag.Limit = 20
+	}
+
+	return s.repo.GetMentions(ctx, username, pag)
+}
+
+// resolveQuotes проверяет, что цитируемые комментарии существуют в том же треде, и заполняет цитаты.
+// Повторные ссылки на один комментарий схлопываются.
+func (s *commentTreeSvc) resolveQuotes(ctx context.Context, comment *models.Comment) error {
+	if len(comment.Quotes) == 0 {
+		return nil
+	}
+
+	seen := make(map[int64]struct{}, len(comment.Quotes))
+	quotes := make([]models.Quote, 0, len(comment.Quotes))
+	for _, q := range comment.Quotes {
+		if _, ok := seen[q.CommentID]; ok {
+			continue
+		}
+		seen[q.CommentID] = struct{}{}
+
+		quoted, err := s.repo.GetByID(ctx, q.CommentID)
+		if err != nil {
+			return fmt.Errorf("s.repo.GetByID: %w", err)
+		}
+		if quoted == nil || isHidden(quoted) || quoted.ThreadKey != comment.ThreadKey {
+			return fmt.Errorf("цитируемый комментарий с id %d не найден в треде %q", q.CommentID, comment.ThreadKey)
+		}
+		if quoted.DeletedAt != nil {
+			return fmt.Errorf("цитируемый комментарий с id %d уже удален", q.CommentID)
+		}
+
+		quotes = append(quotes, models.NewQuote(quoted))
+	}
+	comment.Quotes = quotes
+
+	return nil
+}
+
+func isHidden(c *models.Comment) bool {
+	return c.Status == models.StatusPending || c.Status == models.StatusRejected
+}
+
+// canManage - автор комментария или модератор: видит скрытые комментарии и может редактировать.
+func canManage(c *models.Comment, actor *models.Actor) bool {
+	return actor.IsModerator() || (actor != nil && actor.User != "" && actor.User == c.Author)
+}
+
+func (s *commentTreeSvc) applyFilter(ctx context.Context, comment *models.Comment) error {
+	if s.filter == nil {
+		return nil
+	}
+
+	res, err := s.filter.Check(ctx, comment)
+	if err != nil {
+		return fmt.Errorf("s.filter.Check: %w", err)
+	}
+	switch res.Verdict {
+	case models.VerdictReject:
+		return fmt.Errorf("комментарий отклонен фильтром: %s", res.Reason)
+	case models.VerdictHold:
+		comment.Status = models.StatusPending
+	}
+
+	return nil
+}
+
+// notifyReply уведомляет автора родителя об ответе. Комментарий уже сохранен, поэтому ошибки только логируются.
+func (s *commentTreeSvc) notifyReply(ctx context.Context, reply, parent *models.Comment) {
+	if s.notifications == nil || parent == nil {
+		return
+	}
+
+	if err := s.notifications.NotifyReply(ctx, reply, parent); err != nil {
+		zlog.Logger.Warn().Err(err).Int64("id", reply.ID).Msg("s.notifications.NotifyReply")
+	}
+}
+
+func (s *commentTreeSvc) notifyMentions(ctx context.Context, comment *models.Comment) {
+	if s.notifications == nil || len(comment.Mentions) == 0 {
+		return
+	}
+
+	if err := s.notifications.NotifyMentions(ctx, comment); err != nil {
+		zlog.Logger.Warn().Err(err).Int64("id", comment.ID).Msg("s.notifications.NotifyMentions")
+	}
+}
@@ -2,10 +2,12 @@ package commenttreesvc
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/sunr3d/comment-tree/mocks"
 	"github.com/sunr3d/comment-tree/models"
@@ -14,7 +16,7 @@ import (
 // WriteComment tests.
 func TestWriteComment_OK(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	comment := &models.Comment{
@@ -23,6 +25,10 @@ func TestWriteComment_OK(t *testing.T) {
 		Author:   "Тестер",
 	}
 
+	repo.EXPECT().
+		GetThread(ctx, models.DefaultThread).
+		Return(nil, nil)
+
 	repo.EXPECT().
 		Create(ctx, comment).
 		Return(nil)
@@ -30,11 +36,60 @@ func TestWriteComment_OK(t *testing.T) {
 	err := svc.WriteComment(ctx, comment)
 
 	assert.NoError(t, err)
+	assert.Equal(t, models.LanguageRussian, comment.Language)
+}
+
+func TestWriteComment_DetectsLanguage(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{Content: "How to build a comment tree?", Author: "alice"}
+
+	repo.EXPECT().GetThread(ctx, models.DefaultThread).Return(nil, nil)
+	repo.EXPECT().Create(ctx, comment).Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.LanguageEnglish, comment.Language)
+}
+
+func TestWriteComment_KeepsSuppliedLanguage(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{Content: "PostgreSQL", Author: "alice", Language: models.LanguageKazakh}
+
+	repo.EXPECT().GetThread(ctx, models.DefaultThread).Return(nil, nil)
+	repo.EXPECT().Create(ctx, comment).Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.LanguageKazakh, comment.Language)
+}
+
+func TestWriteComment_DefaultLanguageWithoutLetters(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageKazakh)
+
+	ctx := context.Background()
+	comment := &models.Comment{Content: "👍 +1", Author: "alice"}
+
+	repo.EXPECT().GetThread(ctx, models.DefaultThread).Return(nil, nil)
+	repo.EXPECT().Create(ctx, comment).Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.LanguageKazakh, comment.Language)
 }
 
 func TestWriteComment_WithParentID_OK(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	parentID := int64(1)
@@ -59,6 +114,34 @@ func TestWriteComment_WithParentID_OK(t *testing.T) {
 		GetByID(ctx, parentID).
 		Return(parentComment, nil)
 
+	repo.EXPECT().
+		GetThread(ctx, models.DefaultThread).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		Create(ctx, comment).
+		Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+}
+
+func TestWriteComment_Premoderation(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{
+		ThreadKey: "qa",
+		Content:   "Комментарий в премодерируемом треде",
+		Author:    "Тестер",
+	}
+
+	repo.EXPECT().
+		GetThread(ctx, "qa").
+		Return(&models.Thread{Key: "qa", Premoderation: true}, nil)
+
 	repo.EXPECT().
 		Create(ctx, comment).
 		Return(nil)
@@ -66,11 +149,66 @@ func TestWriteComment_WithParentID_OK(t *testing.T) {
 	err := svc.WriteComment(ctx, comment)
 
 	assert.NoError(t, err)
+	assert.Equal(t, models.StatusPending, comment.Status)
+}
+
+func TestWriteComment_ReplyInheritsThread(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	parentID := int64(1)
+	comment := &models.Comment{
+		ParentID:  &parentID,
+		ThreadKey: "other",
+		Content:   "Ответ",
+		Author:    "Тестер",
+	}
+
+	repo.EXPECT().
+		GetByID(ctx, parentID).
+		Return(&models.Comment{ID: parentID, ThreadKey: "qa", Status: models.StatusApproved}, nil)
+
+	repo.EXPECT().
+		GetThread(ctx, "qa").
+		Return(&models.Thread{Key: "qa"}, nil)
+
+	repo.EXPECT().
+		Create(ctx, comment).
+		Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "qa", comment.ThreadKey)
+	assert.Equal(t, models.StatusApproved, comment.Status)
+}
+
+func TestWriteComment_WithParentID_Pending(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	parentID := int64(7)
+	comment := &models.Comment{
+		ParentID: &parentID,
+		Content:  "Ответ на неодобренный комментарий",
+		Author:   "Тестер",
+	}
+
+	repo.EXPECT().
+		GetByID(ctx, parentID).
+		Return(&models.Comment{ID: parentID, Status: models.StatusPending}, nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "родительский комментарий с id 7 не найден")
 }
 
 func TestWriteComment_WithParentID_NotFound(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	parentID := int64(42)
@@ -92,7 +230,7 @@ func TestWriteComment_WithParentID_NotFound(t *testing.T) {
 
 func TestWriteComment_WithParentID_Deleted(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	parentID := int64(1)
@@ -127,7 +265,7 @@ func TestWriteComment_WithParentID_Deleted(t *testing.T) {
 // GetComments tests.
 func TestGetComments_OK(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	parentID := int64(1)
@@ -179,7 +317,7 @@ func TestGetComments_OK(t *testing.T) {
 
 func TestGetComments_WithNilPagination(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	parentID := int64(1)
@@ -221,7 +359,7 @@ func TestGetComments_WithNilPagination(t *testing.T) {
 
 func TestGetComments_ParentDeleted(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	parentID := int64(1)
@@ -261,10 +399,44 @@ func TestGetComments_ParentDeleted(t *testing.T) {
 	assert.Equal(t, expectedResult, result)
 }
 
+func TestGetComments_HiddenParent(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	parentID := int64(3)
+	pag := &models.PagParam{Page: 1, Limit: 20, Sort: "created_at_asc", Viewer: &models.Actor{User: "чужой"}}
+
+	repo.EXPECT().GetByID(ctx, parentID).Return(&models.Comment{ID: parentID, Author: "автор", Status: models.StatusPending}, nil)
+
+	_, err := svc.GetComments(ctx, parentID, pag)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "комментарий с id 3 не найден")
+}
+
+func TestGetComments_HiddenParentVisibleToAuthor(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	parentID := int64(3)
+	pag := &models.PagParam{Page: 1, Limit: 20, Sort: "created_at_asc", Viewer: &models.Actor{User: "автор"}}
+	expectedResult := &models.CommentsRes{Comments: []models.Comment{}, Page: 1, Limit: 20, Pages: 1}
+
+	repo.EXPECT().GetByID(ctx, parentID).Return(&models.Comment{ID: parentID, Author: "автор", Status: models.StatusPending}, nil)
+	repo.EXPECT().GetByParentID(ctx, parentID, pag).Return(expectedResult, nil)
+
+	result, err := svc.GetComments(ctx, parentID, pag)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResult, result)
+}
+
 // DeleteComment tests.
 func TestDeleteComment_OK(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	commentID := int64(1)
@@ -273,7 +445,7 @@ func TestDeleteComment_OK(t *testing.T) {
 		ID:        commentID,
 		ParentID:  nil,
 		Content:   "Комментарий для удаления",
-		Author:    "Автор",
+		Author:    "alice",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 		DeletedAt: nil,
@@ -281,23 +453,29 @@ func TestDeleteComment_OK(t *testing.T) {
 	}
 
 	repo.EXPECT().GetByID(ctx, commentID).Return(comment, nil)
-	repo.EXPECT().Delete(ctx, commentID).Return(nil)
+	repo.EXPECT().Delete(ctx, commentID, &models.AuditEntry{
+		Actor:      "user:alice",
+		Action:     models.ActionDelete,
+		TargetType: models.AuditTargetComment,
+		TargetID:   "1",
+		Reason:     "спам",
+	}).Return(nil)
 
-	err := svc.DeleteComment(ctx, commentID)
+	err := svc.DeleteComment(ctx, commentID, &models.Actor{User: "alice"}, "спам")
 
 	assert.NoError(t, err)
 }
 
 func TestDeleteComment_NotFound(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	commentID := int64(42)
 
 	repo.EXPECT().GetByID(ctx, commentID).Return(nil, nil)
 
-	err := svc.DeleteComment(ctx, commentID)
+	err := svc.DeleteComment(ctx, commentID, &models.Actor{User: "alice"}, "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "комментарий с id 42 не найден")
@@ -305,7 +483,7 @@ func TestDeleteComment_NotFound(t *testing.T) {
 
 func TestDeleteComment_AlreadyDeleted(t *testing.T) {
 	repo := mocks.NewDatabase(t)
-	svc := New(repo)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
 
 	ctx := context.Background()
 	commentID := int64(1)
@@ -315,7 +493,7 @@ func TestDeleteComment_AlreadyDeleted(t *testing.T) {
 		ID:        commentID,
 		ParentID:  nil,
 		Content:   "Уже удаленный комментарий",
-		Author:    "Автор",
+		Author:    "alice",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 		DeletedAt: &now,
@@ -324,8 +502,686 @@ func TestDeleteComment_AlreadyDeleted(t *testing.T) {
 
 	repo.EXPECT().GetByID(ctx, commentID).Return(comment, nil)
 
-	err := svc.DeleteComment(ctx, commentID)
+	err := svc.DeleteComment(ctx, commentID, &models.Actor{User: "alice"}, "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "комментарий с id 1 уже удален")
 }
+
+// Anonymous delete is rejected: only the author or a moderator may delete, Delete is never called.
+func TestDeleteComment_Forbidden(t *testing.T) {
+	actors := map[string]*models.Actor{
+		"аноним":              nil,
+		"другой пользователь": {User: "bob"},
+	}
+
+	for name, actor := range actors {
+		t.Run(name, func(t *testing.T) {
+			repo := mocks.NewDatabase(t)
+			svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+			ctx := context.Background()
+			comment := &models.Comment{ID: 1, Content: "Текст", Author: "alice", Status: models.StatusApproved}
+
+			repo.EXPECT().GetByID(ctx, int64(1)).Return(comment, nil)
+
+			err := svc.DeleteComment(ctx, 1, actor, "")
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "нет прав")
+		})
+	}
+}
+
+// EditComment tests.
+func TestEditComment_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{
+		ID:       1,
+		Content:  "Старый текст",
+		Author:   "alice",
+		Status:   models.StatusApproved,
+		Revision: 1,
+	}
+
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(comment, nil)
+	repo.EXPECT().UpdateContent(ctx, mock.MatchedBy(func(c *models.Comment) bool {
+		return c.Content == "Новый текст"
+	}), &models.AuditEntry{
+		Actor:      "user:alice",
+		Action:     models.ActionEdit,
+		TargetType: models.AuditTargetComment,
+		TargetID:   "1",
+		Reason:     "опечатка",
+	}).Return(nil)
+
+	result, err := svc.EditComment(ctx, 1, "Новый текст", &models.Actor{User: "alice"}, "опечатка")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Новый текст", result.Content)
+}
+
+func TestEditComment_Forbidden(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{ID: 1, Content: "Текст", Author: "alice", Status: models.StatusApproved}
+
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(comment, nil)
+
+	_, err := svc.EditComment(ctx, 1, "Чужая правка", &models.Actor{User: "bob"}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нет прав")
+}
+
+func TestEditComment_ModeratorCanEdit(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{ID: 1, Content: "Текст", Author: "alice", Status: models.StatusPending}
+	moderator := &models.Actor{User: "mod", Role: models.RoleModerator}
+
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(comment, nil)
+	repo.EXPECT().UpdateContent(ctx, comment, mock.Anything).Return(nil)
+
+	_, err := svc.EditComment(ctx, 1, "Исправленный текст", moderator, "")
+
+	assert.NoError(t, err)
+}
+
+func TestEditComment_FilterReject(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	filter := mocks.NewContentFilter(t)
+	svc := New(repo, filter, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{ID: 1, Content: "Текст", Author: "alice", Status: models.StatusApproved}
+
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(comment, nil)
+	filter.EXPECT().Check(ctx, comment).Return(models.FilterResult{
+		Verdict: models.VerdictReject,
+		Filter:  "blocklist",
+		Reason:  "запрещенное слово",
+	}, nil)
+
+	_, err := svc.EditComment(ctx, 1, "спам", &models.Actor{User: "alice"}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "фильтром")
+}
+
+// RestoreComment tests.
+func TestRestoreComment_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	now := time.Now()
+	parentID := int64(1)
+	comment := &models.Comment{ID: 2, ParentID: &parentID, DeletedAt: &now}
+	parent := &models.Comment{ID: 1}
+
+	repo.EXPECT().GetByID(ctx, int64(2)).Return(comment, nil)
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(parent, nil)
+	repo.EXPECT().Restore(ctx, int64(2), mock.MatchedBy(func(e *models.AuditEntry) bool {
+		return e.Action == models.ActionRestore && e.TargetID == "2"
+	})).Return(nil)
+
+	err := svc.RestoreComment(ctx, 2, &models.Actor{User: "mod", Role: models.RoleModerator}, "ошибочное удаление")
+
+	assert.NoError(t, err)
+}
+
+func TestRestoreComment_NotDeleted(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1}, nil)
+
+	err := svc.RestoreComment(ctx, 1, nil, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не удален")
+}
+
+func TestRestoreComment_ParentDeleted(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	now := time.Now()
+	parentID := int64(1)
+
+	repo.EXPECT().GetByID(ctx, int64(2)).Return(&models.Comment{ID: 2, ParentID: &parentID, DeletedAt: &now}, nil)
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, DeletedAt: &now}, nil)
+
+	err := svc.RestoreComment(ctx, 2, nil, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "сначала восстановите")
+}
+
+func TestWriteComment_ThreadLocked(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	now := time.Now()
+	comment := &models.Comment{ThreadKey: "news", Content: "Текст", Author: "alice"}
+
+	repo.EXPECT().GetThread(ctx, "news").Return(&models.Thread{Key: "news", LockedAt: &now}, nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "закрыт")
+}
+
+// Content filter tests.
+func TestWriteComment_FilterReject(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	filter := mocks.NewContentFilter(t)
+	svc := New(repo, filter, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{
+		Content: "Лучшее онлайн казино",
+		Author:  "Спамер",
+	}
+
+	repo.EXPECT().
+		GetThread(ctx, models.DefaultThread).
+		Return(nil, nil)
+
+	filter.EXPECT().
+		Check(ctx, comment).
+		Return(models.FilterResult{Verdict: models.VerdictReject, Filter: "blocklist", Reason: "содержит запрещенные слова"}, nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "комментарий отклонен фильтром: содержит запрещенные слова")
+}
+
+func TestWriteComment_FilterHold(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	filter := mocks.NewContentFilter(t)
+	svc := New(repo, filter, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{
+		Content: "http://a.ru http://b.ru http://c.ru http://d.ru",
+		Author:  "Тестер",
+	}
+
+	repo.EXPECT().
+		GetThread(ctx, models.DefaultThread).
+		Return(nil, nil)
+
+	filter.EXPECT().
+		Check(ctx, comment).
+		Return(models.FilterResult{Verdict: models.VerdictHold, Filter: "links", Reason: "слишком много ссылок"}, nil)
+
+	repo.EXPECT().
+		Create(ctx, comment).
+		Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusPending, comment.Status)
+}
+
+func TestWriteComment_FilterAccept(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	filter := mocks.NewContentFilter(t)
+	svc := New(repo, filter, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{
+		Content: "Обычный комментарий",
+		Author:  "Тестер",
+	}
+
+	filter.EXPECT().
+		Check(ctx, comment).
+		Return(models.FilterResult{Verdict: models.VerdictAccept}, nil)
+	repo.EXPECT().
+		GetThread(ctx, models.DefaultThread).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		Create(ctx, comment).
+		Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+}
+
+func TestWriteComment_NotifiesParentAuthor(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	notifications := mocks.NewNotifications(t)
+	svc := New(repo, nil, notifications, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	parentID := int64(1)
+	parent := &models.Comment{ID: parentID, Author: "Алиса", Status: models.StatusApproved}
+	comment := &models.Comment{ParentID: &parentID, Content: "Ответ", Author: "Боб"}
+
+	repo.EXPECT().GetByID(ctx, parentID).Return(parent, nil)
+	repo.EXPECT().GetThread(ctx, models.DefaultThread).Return(nil, nil)
+	repo.EXPECT().Create(ctx, comment).Return(nil)
+	notifications.EXPECT().NotifyReply(ctx, comment, parent).Return(errors.New("ошибка БД"))
+
+	err := svc.WriteComment(ctx, comment)
+
+	// Ошибка уведомления не отменяет созданный комментарий
+	assert.NoError(t, err)
+}
+
+func TestWriteComment_StoresAndNotifiesMentions(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	notifications := mocks.NewNotifications(t)
+	svc := New(repo, nil, notifications, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{Content: "@alice посмотри `@bob`", Author: "carol"}
+
+	repo.EXPECT().GetThread(ctx, models.DefaultThread).Return(nil, nil)
+	repo.EXPECT().Create(ctx, mock.MatchedBy(func(c *models.Comment) bool {
+		return len(c.Mentions) == 1 && c.Mentions[0].Username == "alice"
+	})).Return(nil)
+	notifications.EXPECT().NotifyMentions(ctx, comment).Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+}
+
+func TestEditComment_UpdatesMentions(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	notifications := mocks.NewNotifications(t)
+	svc := New(repo, nil, notifications, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	actor := &models.Actor{User: "carol"}
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Author: "carol", Content: "привет", Status: models.StatusApproved}, nil)
+	repo.EXPECT().UpdateContent(ctx, mock.MatchedBy(func(c *models.Comment) bool {
+		return len(c.Mentions) == 1 && c.Mentions[0].Username == "dave"
+	}), mock.Anything).Return(nil)
+	notifications.EXPECT().NotifyMentions(ctx, mock.Anything).Return(nil)
+
+	_, err := svc.EditComment(ctx, 1, "привет, @dave", actor, "")
+
+	assert.NoError(t, err)
+}
+
+func TestWriteComment_ResolvesQuotes(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{
+		ThreadKey: "qa",
+		Content:   "Не согласен",
+		Author:    "Тестер",
+		Quotes:    []models.Quote{{CommentID: 5}, {CommentID: 7}, {CommentID: 5}},
+	}
+
+	repo.EXPECT().GetThread(ctx, "qa").Return(nil, nil)
+	repo.EXPECT().GetByID(ctx, int64(5)).Return(&models.Comment{
+		ID: 5, ThreadKey: "qa", Author: "alice", Content: "Первый тезис", Status: models.StatusApproved,
+	}, nil)
+	repo.EXPECT().GetByID(ctx, int64(7)).Return(&models.Comment{
+		ID: 7, ThreadKey: "qa", Author: "bob", Content: "Второй тезис", Status: models.StatusApproved,
+	}, nil)
+	repo.EXPECT().Create(ctx, comment).Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Quote{
+		{CommentID: 5, Author: "alice", Excerpt: "Первый тезис"},
+		{CommentID: 7, Author: "bob", Excerpt: "Второй тезис"},
+	}, comment.Quotes)
+}
+
+func TestWriteComment_QuoteInvalid(t *testing.T) {
+	deletedAt := time.Now()
+	tests := []struct {
+		name   string
+		quoted *models.Comment
+		errMsg string
+	}{
+		{
+			name:   "не существует",
+			quoted: nil,
+			errMsg: "цитируемый комментарий с id 5 не найден",
+		},
+		{
+			name:   "другой тред",
+			quoted: &models.Comment{ID: 5, ThreadKey: "other", Status: models.StatusApproved},
+			errMsg: "цитируемый комментарий с id 5 не найден",
+		},
+		{
+			name:   "на модерации",
+			quoted: &models.Comment{ID: 5, ThreadKey: "qa", Status: models.StatusPending},
+			errMsg: "цитируемый комментарий с id 5 не найден",
+		},
+		{
+			name:   "удален",
+			quoted: &models.Comment{ID: 5, ThreadKey: "qa", Status: models.StatusApproved, DeletedAt: &deletedAt},
+			errMsg: "цитируемый комментарий с id 5 уже удален",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewDatabase(t)
+			svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+			ctx := context.Background()
+			comment := &models.Comment{
+				ThreadKey: "qa",
+				Content:   "Ответ",
+				Author:    "Тестер",
+				Quotes:    []models.Quote{{CommentID: 5}},
+			}
+
+			repo.EXPECT().GetThread(ctx, "qa").Return(nil, nil)
+			repo.EXPECT().GetByID(ctx, int64(5)).Return(tt.quoted, nil)
+
+			err := svc.WriteComment(ctx, comment)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestVote_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	want := &models.VoteResult{CommentID: 1, Upvotes: 3, Downvotes: 1, Score: 2, Vote: models.VoteUp}
+
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Author: "alice", Status: models.StatusApproved}, nil)
+	repo.EXPECT().Vote(ctx, int64(1), "bob", models.VoteUp).Return(want, nil)
+
+	got, err := svc.Vote(ctx, 1, "bob", models.VoteUp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestVote_OwnComment(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Author: "alice", Status: models.StatusApproved}, nil)
+
+	_, err := svc.Vote(ctx, 1, "alice", models.VoteUp)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нет прав")
+}
+
+func TestVote_HiddenComment(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Author: "alice", Status: models.StatusPending}, nil)
+
+	_, err := svc.Vote(ctx, 1, "bob", models.VoteDown)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestAcceptAnswer_ByRootAuthor(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	rootID, parentID := int64(1), int64(2)
+	actor := &models.Actor{User: "asker"}
+
+	repo.EXPECT().GetByID(ctx, int64(3)).Return(&models.Comment{ID: 3, ParentID: &parentID, Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetAncestorIDs(ctx, int64(3)).Return([]int64{rootID, parentID}, nil)
+	repo.EXPECT().GetByID(ctx, rootID).Return(&models.Comment{ID: rootID, Author: "asker", Status: models.StatusApproved}, nil)
+	repo.EXPECT().SetAcceptedAnswer(ctx, rootID, mock.MatchedBy(func(id *int64) bool {
+		return id != nil && *id == 3
+	}), mock.MatchedBy(func(e *models.AuditEntry) bool {
+		return e.Action == models.ActionAcceptAnswer && e.TargetID == "1"
+	})).Return(nil)
+
+	root, err := svc.AcceptAnswer(ctx, 3, actor)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, root.AcceptedCommentID) {
+		assert.Equal(t, int64(3), *root.AcceptedCommentID)
+	}
+}
+
+func TestAcceptAnswer_Forbidden(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	rootID := int64(1)
+
+	repo.EXPECT().GetByID(ctx, int64(3)).Return(&models.Comment{ID: 3, ParentID: &rootID, Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetAncestorIDs(ctx, int64(3)).Return([]int64{rootID}, nil)
+	repo.EXPECT().GetByID(ctx, rootID).Return(&models.Comment{ID: rootID, Author: "asker", Status: models.StatusApproved}, nil)
+
+	_, err := svc.AcceptAnswer(ctx, 3, &models.Actor{User: "stranger"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "нет прав")
+}
+
+func TestAcceptAnswer_RootComment(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+
+	_, err := svc.AcceptAnswer(ctx, 1, &models.Actor{User: "mod", Role: models.RoleModerator})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не может быть ответом")
+}
+
+func TestUnacceptAnswer_NotAccepted(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	rootID, otherID := int64(1), int64(5)
+
+	repo.EXPECT().GetByID(ctx, int64(3)).Return(&models.Comment{ID: 3, ParentID: &rootID, Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetAncestorIDs(ctx, int64(3)).Return([]int64{rootID}, nil)
+	repo.EXPECT().GetByID(ctx, rootID).Return(&models.Comment{ID: rootID, Status: models.StatusApproved, AcceptedCommentID: &otherID}, nil)
+
+	_, err := svc.UnacceptAnswer(ctx, 3, &models.Actor{User: "mod", Role: models.RoleModerator})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не отмечен")
+}
+
+func TestGetComments_MarksAcceptedAnswer(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	acceptedID := int64(3)
+	pag := &models.PagParam{Page: 1, Limit: 20, Sort: models.SortCreatedAsc}
+
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved, AcceptedCommentID: &acceptedID}, nil)
+	repo.EXPECT().GetByParentID(ctx, int64(1), pag).Return(&models.CommentsRes{
+		Comments: []models.Comment{{ID: 2}, {ID: 3}},
+	}, nil)
+
+	res, err := svc.GetComments(ctx, 1, pag)
+
+	assert.NoError(t, err)
+	assert.False(t, res.Comments[0].Accepted)
+	assert.True(t, res.Comments[1].Accepted)
+}
+
+func TestMoveComment_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	oldParent, newParent := int64(2), int64(5)
+	moderator := &models.Actor{User: "mod", Role: models.RoleModerator}
+
+	repo.EXPECT().GetByID(ctx, int64(3)).Return(&models.Comment{
+		ID: 3, ParentID: &oldParent, ThreadKey: "qa", Status: models.StatusApproved,
+	}, nil)
+	repo.EXPECT().GetByID(ctx, newParent).Return(&models.Comment{
+		ID: newParent, ParentID: &oldParent, ThreadKey: "news", Status: models.StatusApproved,
+	}, nil)
+	repo.EXPECT().GetAncestorIDs(ctx, newParent).Return([]int64{1, 4}, nil)
+	repo.EXPECT().MoveComment(ctx, int64(3), &newParent, "news", int64(1), mock.MatchedBy(func(e *models.AuditEntry) bool {
+		return e.Action == models.ActionMove && e.TargetID == "3" && e.Reason == "не та ветка"
+	})).Return(nil)
+
+	comment, err := svc.MoveComment(ctx, 3, &newParent, moderator, "не та ветка")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &newParent, comment.ParentID)
+	assert.Equal(t, "news", comment.ThreadKey)
+}
+
+func TestMoveComment_ToRoot(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	parentID := int64(2)
+
+	repo.EXPECT().GetByID(ctx, int64(3)).Return(&models.Comment{
+		ID: 3, ParentID: &parentID, ThreadKey: "qa", Status: models.StatusApproved,
+	}, nil)
+	repo.EXPECT().MoveComment(ctx, int64(3), (*int64)(nil), "qa", int64(3), mock.Anything).Return(nil)
+
+	comment, err := svc.MoveComment(ctx, 3, nil, &models.Actor{User: "mod", Role: models.RoleModerator}, "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, comment.ParentID)
+}
+
+func TestMoveComment_IntoOwnSubtree(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	target := int64(7)
+
+	repo.EXPECT().GetByID(ctx, int64(3)).Return(&models.Comment{ID: 3, Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetByID(ctx, target).Return(&models.Comment{ID: target, Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetAncestorIDs(ctx, target).Return([]int64{3, 5}, nil)
+
+	_, err := svc.MoveComment(ctx, 3, &target, &models.Actor{User: "mod", Role: models.RoleModerator}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "собственную ветку")
+}
+
+func TestMoveComment_UnderItself(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	self := int64(3)
+
+	repo.EXPECT().GetByID(ctx, self).Return(&models.Comment{ID: self, Status: models.StatusApproved}, nil)
+
+	_, err := svc.MoveComment(ctx, self, &self, &models.Actor{User: "mod", Role: models.RoleModerator}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "собственную ветку")
+}
+
+func TestMoveComment_TooDeep(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 4, models.LanguageRussian)
+
+	ctx := context.Background()
+	target := int64(9)
+
+	repo.EXPECT().GetByID(ctx, int64(3)).Return(&models.Comment{ID: 3, Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetByID(ctx, target).Return(&models.Comment{ID: target, Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetAncestorIDs(ctx, target).Return([]int64{1, 8}, nil)
+	repo.EXPECT().GetSubtreeHeight(ctx, int64(3)).Return(2, nil)
+
+	_, err := svc.MoveComment(ctx, 3, &target, &models.Actor{User: "mod", Role: models.RoleModerator}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "глубина")
+}
+
+func TestMoveComment_SameParent(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	parentID := int64(2)
+	target := int64(2)
+
+	repo.EXPECT().GetByID(ctx, int64(3)).Return(&models.Comment{ID: 3, ParentID: &parentID, Status: models.StatusApproved}, nil)
+
+	comment, err := svc.MoveComment(ctx, 3, &target, &models.Actor{User: "mod", Role: models.RoleModerator}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), comment.ID)
+}
+
+func TestWriteComment_TooDeep(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 2, models.LanguageRussian)
+
+	ctx := context.Background()
+	parentID := int64(5)
+	comment := &models.Comment{ParentID: &parentID, Content: "глубокий ответ", Author: "Тестер"}
+
+	repo.EXPECT().GetByID(ctx, parentID).Return(&models.Comment{ID: parentID, Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetAncestorIDs(ctx, parentID).Return([]int64{1, 3}, nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "глубина")
+}
+
+func TestWriteComment_MergedThread(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, nil, 0, models.LanguageRussian)
+
+	ctx := context.Background()
+	comment := &models.Comment{ThreadKey: "dup", Content: "Вопрос", Author: "Тестер"}
+
+	repo.EXPECT().GetThread(ctx, "dup").Return(&models.Thread{Key: "qa"}, nil)
+	repo.EXPECT().Create(ctx, mock.MatchedBy(func(c *models.Comment) bool {
+		return c.ThreadKey == "qa"
+	})).Return(nil)
+
+	err := svc.WriteComment(ctx, comment)
+
+	assert.NoError(t, err)
+}
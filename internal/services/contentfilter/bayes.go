@@ -0,0 +1,104 @@
+package contentfilter
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.ContentFilter = (*bayes)(nil)
+
+// bayes - наивный байесовский классификатор, обучается на корпусах спама и нормальных сообщений при старте.
+type bayes struct {
+	spam     map[string]int
+	ham      map[string]int
+	spamDocs int
+	hamDocs  int
+
+	holdThreshold   float64
+	rejectThreshold float64
+}
+
+func NewBayes(spamCorpus, hamCorpus []string, holdThreshold, rejectThreshold float64) services.ContentFilter {
+	b := &bayes{
+		spam:            make(map[string]int),
+		ham:             make(map[string]int),
+		holdThreshold:   holdThreshold,
+		rejectThreshold: rejectThreshold,
+	}
+
+	for _, doc := range spamCorpus {
+		b.train(b.spam, doc)
+		b.spamDocs++
+	}
+	for _, doc := range hamCorpus {
+		b.train(b.ham, doc)
+		b.hamDocs++
+	}
+
+	return b
+}
+
+func (b *bayes) Check(_ context.Context, comment *models.Comment) (models.FilterResult, error) {
+	score := b.score(comment.Content)
+
+	switch {
+	case b.rejectThreshold > 0 && score >= b.rejectThreshold:
+		return b.match(models.VerdictReject, score), nil
+	case b.holdThreshold > 0 && score >= b.holdThreshold:
+		return b.match(models.VerdictHold, score), nil
+	default:
+		return accept(), nil
+	}
+}
+
+func (b *bayes) match(verdict models.FilterVerdict, score float64) models.FilterResult {
+	return models.FilterResult{
+		Verdict: verdict,
+		Filter:  "bayes",
+		Reason:  fmt.Sprintf("похоже на спам (%.2f)", score),
+	}
+}
+
+func (b *bayes) train(counts map[string]int, doc string) {
+	for token := range uniqueTokens(doc) {
+		counts[token]++
+	}
+}
+
+// score возвращает вероятность спама от 0 до 1.
+func (b *bayes) score(text string) float64 {
+	if b.spamDocs == 0 || b.hamDocs == 0 {
+		return 0
+	}
+
+	logOdds := math.Log(float64(b.spamDocs) / float64(b.hamDocs))
+	known := false
+	for token := range uniqueTokens(text) {
+		s, h := b.spam[token], b.ham[token]
+		if s == 0 && h == 0 {
+			continue
+		}
+		known = true
+
+		pSpam := (float64(s) + 1) / (float64(b.spamDocs) + 2)
+		pHam := (float64(h) + 1) / (float64(b.hamDocs) + 2)
+		logOdds += math.Log(pSpam / pHam)
+	}
+	if !known {
+		return 0
+	}
+
+	return 1 / (1 + math.Exp(-logOdds))
+}
+
+func uniqueTokens(text string) map[string]struct{} {
+	out := make(map[string]struct{})
+	for _, token := range tokenize(text) {
+		out[token] = struct{}{}
+	}
+	return out
+}
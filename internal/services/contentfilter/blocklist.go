@@ -0,0 +1,77 @@
+package contentfilter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.ContentFilter = (*blocklist)(nil)
+
+type blocklist struct {
+	words    map[string]struct{}
+	phrases  []string
+	patterns []*regexp.Regexp
+	action   models.FilterVerdict
+}
+
+// NewBlocklist - слова сравниваются целиком без учета регистра, фразы из нескольких слов ищутся подстрокой.
+func NewBlocklist(words, patterns []string, action models.FilterVerdict) (services.ContentFilter, error) {
+	b := &blocklist{
+		words:  make(map[string]struct{}, len(words)),
+		action: action,
+	}
+
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" {
+			continue
+		}
+		if len(tokenize(w)) > 1 {
+			b.phrases = append(b.phrases, w)
+			continue
+		}
+		b.words[w] = struct{}{}
+	}
+
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("regexp.Compile(%q): %w", p, err)
+		}
+		b.patterns = append(b.patterns, re)
+	}
+
+	return b, nil
+}
+
+func (b *blocklist) Check(_ context.Context, comment *models.Comment) (models.FilterResult, error) {
+	for _, token := range tokenize(comment.Content) {
+		if _, ok := b.words[token]; ok {
+			return b.match("содержит запрещенные слова"), nil
+		}
+	}
+
+	lower := strings.ToLower(comment.Content)
+	for _, phrase := range b.phrases {
+		if strings.Contains(lower, phrase) {
+			return b.match("содержит запрещенные слова"), nil
+		}
+	}
+
+	for _, re := range b.patterns {
+		if re.MatchString(comment.Content) {
+			return b.match("содержит запрещенный шаблон"), nil
+		}
+	}
+
+	return accept(), nil
+}
+
+func (b *blocklist) match(reason string) models.FilterResult {
+	return models.FilterResult{Verdict: b.action, Filter: "blocklist", Reason: reason}
+}
@@ -0,0 +1,46 @@
+package contentfilter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.ContentFilter = (*duplicates)(nil)
+
+type duplicates struct {
+	repo   infra.Database
+	window time.Duration
+	action models.FilterVerdict
+}
+
+func NewDuplicates(repo infra.Database, window time.Duration, action models.FilterVerdict) services.ContentFilter {
+	return &duplicates{repo: repo, window: window, action: action}
+}
+
+// Check сравнивает текст с недавними комментариями того же автора без учета пробелов по краям:
+// сохраненный текст не обрезается, поэтому обрезаются обе стороны.
+func (d *duplicates) Check(ctx context.Context, comment *models.Comment) (models.FilterResult, error) {
+	recent, err := d.repo.GetRecentContents(ctx, comment.Author, d.window)
+	if err != nil {
+		return models.FilterResult{}, fmt.Errorf("d.repo.GetRecentContents: %w", err)
+	}
+
+	content := strings.TrimSpace(comment.Content)
+	for _, r := range recent {
+		if strings.TrimSpace(r) == content {
+			return models.FilterResult{
+				Verdict: d.action,
+				Filter:  "duplicates",
+				Reason:  "такой комментарий уже был опубликован недавно",
+			}, nil
+		}
+	}
+
+	return accept(), nil
+}
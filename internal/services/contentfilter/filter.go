@@ -0,0 +1,142 @@
+package contentfilter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.ContentFilter = (*chain)(nil)
+
+// chain прогоняет комментарий через фильтры по порядку: первый reject прерывает цепочку,
+// hold запоминается и возвращается, если никто дальше не отклонил комментарий.
+type chain struct {
+	filters []services.ContentFilter
+}
+
+func NewChain(filters ...services.ContentFilter) services.ContentFilter {
+	return &chain{filters: filters}
+}
+
+// New собирает цепочку фильтров из конфига.
+func New(cfg config.FiltersConfig, repo infra.Database) (services.ContentFilter, error) {
+	filters := make([]services.ContentFilter, 0, 4)
+
+	blocklistAction, err := parseAction(cfg.Blocklist.Action)
+	if err != nil {
+		return nil, fmt.Errorf("BLOCKLIST: %w", err)
+	}
+	blocklist, err := NewBlocklist(cfg.Blocklist.Words, cfg.Blocklist.Patterns, blocklistAction)
+	if err != nil {
+		return nil, fmt.Errorf("NewBlocklist: %w", err)
+	}
+	filters = append(filters, blocklist)
+
+	if cfg.Links.Max > 0 {
+		linksAction, err := parseAction(cfg.Links.Action)
+		if err != nil {
+			return nil, fmt.Errorf("LINKS: %w", err)
+		}
+		filters = append(filters, NewLinkLimit(cfg.Links.Max, linksAction))
+	}
+
+	if cfg.Duplicates.Window > 0 {
+		duplicatesAction, err := parseAction(cfg.Duplicates.Action)
+		if err != nil {
+			return nil, fmt.Errorf("DUPLICATES: %w", err)
+		}
+		filters = append(filters, NewDuplicates(repo, cfg.Duplicates.Window, duplicatesAction))
+	}
+
+	spam, err := loadCorpus(cfg.Bayes.SpamCorpus)
+	if err != nil {
+		return nil, fmt.Errorf("loadCorpus: %w", err)
+	}
+	ham, err := loadCorpus(cfg.Bayes.HamCorpus)
+	if err != nil {
+		return nil, fmt.Errorf("loadCorpus: %w", err)
+	}
+	if len(spam) > 0 && len(ham) > 0 {
+		filters = append(filters, NewBayes(spam, ham, cfg.Bayes.HoldThreshold, cfg.Bayes.RejectThreshold))
+	} else {
+		zlog.Logger.Warn().Msg("корпус для байесовского фильтра не найден, фильтр отключен")
+	}
+
+	return NewChain(filters...), nil
+}
+
+func (c *chain) Check(ctx context.Context, comment *models.Comment) (models.FilterResult, error) {
+	result := accept()
+
+	for _, f := range c.filters {
+		res, err := f.Check(ctx, comment)
+		if err != nil {
+			return models.FilterResult{}, err
+		}
+
+		switch res.Verdict {
+		case models.VerdictReject:
+			return res, nil
+		case models.VerdictHold:
+			if result.Verdict != models.VerdictHold {
+				result = res
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func accept() models.FilterResult {
+	return models.FilterResult{Verdict: models.VerdictAccept}
+}
+
+func parseAction(action string) (models.FilterVerdict, error) {
+	switch v := models.FilterVerdict(strings.ToLower(strings.TrimSpace(action))); v {
+	case models.VerdictReject, models.VerdictHold:
+		return v, nil
+	default:
+		return "", fmt.Errorf("неизвестное действие фильтра %q (ожидается reject или hold)", action)
+	}
+}
+
+func loadCorpus(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, line)
+	}
+
+	return out, nil
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
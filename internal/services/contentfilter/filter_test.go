@@ -0,0 +1,165 @@
+package contentfilter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/comment-tree/mocks"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+// Blocklist tests.
+func TestBlocklist_Word(t *testing.T) {
+	f, err := NewBlocklist([]string{"Казино"}, nil, models.VerdictReject)
+	assert.NoError(t, err)
+
+	res, err := f.Check(context.Background(), &models.Comment{Content: "Лучшее КАЗИНО в городе"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictReject, res.Verdict)
+	assert.Equal(t, "blocklist", res.Filter)
+}
+
+func TestBlocklist_WholeWordsOnly(t *testing.T) {
+	f, err := NewBlocklist([]string{"кот"}, nil, models.VerdictReject)
+	assert.NoError(t, err)
+
+	res, err := f.Check(context.Background(), &models.Comment{Content: "Который час?"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictAccept, res.Verdict)
+}
+
+func TestBlocklist_PhraseAndPattern(t *testing.T) {
+	f, err := NewBlocklist([]string{"ставки на спорт"}, []string{`(?i)заработ\p{L}*\s+от\s+\d+`}, models.VerdictHold)
+	assert.NoError(t, err)
+
+	res, _ := f.Check(context.Background(), &models.Comment{Content: "Лучшие Ставки на спорт тут"})
+	assert.Equal(t, models.VerdictHold, res.Verdict)
+
+	res, _ = f.Check(context.Background(), &models.Comment{Content: "Заработок от 5000 в день"})
+	assert.Equal(t, models.VerdictHold, res.Verdict)
+}
+
+func TestBlocklist_InvalidPattern(t *testing.T) {
+	_, err := NewBlocklist(nil, []string{"("}, models.VerdictReject)
+
+	assert.Error(t, err)
+}
+
+// Links tests.
+func TestLinkLimit(t *testing.T) {
+	f := NewLinkLimit(2, models.VerdictHold)
+
+	res, _ := f.Check(context.Background(), &models.Comment{Content: "см. https://a.ru и www.b.ru"})
+	assert.Equal(t, models.VerdictAccept, res.Verdict)
+
+	res, _ = f.Check(context.Background(), &models.Comment{Content: "https://a.ru http://b.ru www.c.ru"})
+	assert.Equal(t, models.VerdictHold, res.Verdict)
+	assert.Contains(t, res.Reason, "слишком много ссылок (3, максимум 2)")
+}
+
+// Duplicates tests.
+func TestDuplicates_Found(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	f := NewDuplicates(repo, 10*time.Minute, models.VerdictReject)
+
+	ctx := context.Background()
+	repo.EXPECT().GetRecentContents(ctx, "Автор", 10*time.Minute).Return([]string{"Другое", "Привет"}, nil)
+
+	res, err := f.Check(ctx, &models.Comment{Author: "Автор", Content: " Привет "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictReject, res.Verdict)
+}
+
+func TestDuplicates_StoredWithWhitespace(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	f := NewDuplicates(repo, 10*time.Minute, models.VerdictReject)
+
+	ctx := context.Background()
+	repo.EXPECT().GetRecentContents(ctx, "Автор", 10*time.Minute).Return([]string{"  Привет\n"}, nil)
+
+	res, err := f.Check(ctx, &models.Comment{Author: "Автор", Content: "Привет"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictReject, res.Verdict)
+}
+
+func TestDuplicates_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	f := NewDuplicates(repo, 10*time.Minute, models.VerdictReject)
+
+	ctx := context.Background()
+	repo.EXPECT().GetRecentContents(ctx, "Автор", 10*time.Minute).Return([]string{"Привет всем"}, nil)
+
+	res, err := f.Check(ctx, &models.Comment{Author: "Автор", Content: "Привет"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictAccept, res.Verdict)
+}
+
+func TestDuplicates_RepoError(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	f := NewDuplicates(repo, time.Minute, models.VerdictReject)
+
+	ctx := context.Background()
+	repo.EXPECT().GetRecentContents(ctx, "Автор", time.Minute).Return(nil, errors.New("db down"))
+
+	_, err := f.Check(ctx, &models.Comment{Author: "Автор", Content: "Привет"})
+
+	assert.Error(t, err)
+}
+
+// Bayes tests.
+func TestBayes_Score(t *testing.T) {
+	spam := []string{
+		"бесплатный бонус переходи по ссылке",
+		"бонус за регистрацию в казино",
+		"быстрый заработок переходи по ссылке",
+	}
+	ham := []string{
+		"спасибо за подробный разбор запроса",
+		"индекс по дате тут не поможет",
+		"интересный вопрос про пагинацию",
+	}
+	f := NewBayes(spam, ham, 0.7, 0.99)
+
+	res, _ := f.Check(context.Background(), &models.Comment{Content: "бонус, переходи по ссылке"})
+	assert.NotEqual(t, models.VerdictAccept, res.Verdict)
+
+	res, _ = f.Check(context.Background(), &models.Comment{Content: "спасибо за разбор запроса"})
+	assert.Equal(t, models.VerdictAccept, res.Verdict)
+
+	res, _ = f.Check(context.Background(), &models.Comment{Content: "совершенно новые слова"})
+	assert.Equal(t, models.VerdictAccept, res.Verdict)
+}
+
+// Chain tests.
+func TestChain_RejectWins(t *testing.T) {
+	links := NewLinkLimit(0, models.VerdictHold)
+	blocklist, _ := NewBlocklist([]string{"казино"}, nil, models.VerdictReject)
+	f := NewChain(links, blocklist)
+
+	res, err := f.Check(context.Background(), &models.Comment{Content: "казино https://a.ru"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictReject, res.Verdict)
+	assert.Equal(t, "blocklist", res.Filter)
+}
+
+func TestChain_Hold(t *testing.T) {
+	links := NewLinkLimit(0, models.VerdictHold)
+	blocklist, _ := NewBlocklist([]string{"казино"}, nil, models.VerdictReject)
+	f := NewChain(links, blocklist)
+
+	res, err := f.Check(context.Background(), &models.Comment{Content: "см. https://a.ru"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictHold, res.Verdict)
+	assert.Equal(t, "links", res.Filter)
+}
@@ -0,0 +1,35 @@
+package contentfilter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.ContentFilter = (*linkLimit)(nil)
+
+var linkRe = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+type linkLimit struct {
+	max    int
+	action models.FilterVerdict
+}
+
+func NewLinkLimit(max int, action models.FilterVerdict) services.ContentFilter {
+	return &linkLimit{max: max, action: action}
+}
+
+func (l *linkLimit) Check(_ context.Context, comment *models.Comment) (models.FilterResult, error) {
+	if n := len(linkRe.FindAllStringIndex(comment.Content, -1)); n > l.max {
+		return models.FilterResult{
+			Verdict: l.action,
+			Filter:  "links",
+			Reason:  fmt.Sprintf("слишком много ссылок (%d, максимум %d)", n, l.max),
+		}, nil
+	}
+
+	return accept(), nil
+}
@@ -0,0 +1,153 @@
+package markdown
+
+import (
+	"bytes"
+	"container/list"
+	stdhtml "html"
+	"regexp"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/wb-go/wbf/zlog"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.ContentRenderer = (*renderer)(nil)
+
+type cacheKey struct {
+	id       int64
+	revision int
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	html string
+}
+
+// renderer переводит Markdown в HTML и очищает результат по белому списку. Результат кешируется
+// по (id, revision): ревизия меняется при каждой правке, поэтому устаревший HTML не отдается.
+type renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	cache map[cacheKey]*list.Element
+}
+
+func New(cacheSize int) services.ContentRenderer {
+	return &renderer{
+		md:     newMarkdown(),
+		policy: newPolicy(),
+		size:   cacheSize,
+		order:  list.New(),
+		cache:  make(map[cacheKey]*list.Element, cacheSize),
+	}
+}
+
+func (r *renderer) Render(comment *models.Comment) string {
+	if comment.ID == 0 || r.size <= 0 {
+		return r.render(comment.Content)
+	}
+
+	key := cacheKey{id: comment.ID, revision: comment.Revision}
+	if html, ok := r.get(key); ok {
+		return html
+	}
+
+	html := r.render(comment.Content)
+	r.put(key, html)
+
+	return html
+}
+
+func (r *renderer) render(content string) string {
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(content), &buf); err != nil {
+		zlog.Logger.Warn().Err(err).Msg("md.Convert")
+		return "<p>" + stdhtml.EscapeString(content) + "</p>"
+	}
+
+	return r.policy.SanitizeReader(&buf).String()
+}
+
+func (r *renderer) get(key cacheKey) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	el, ok := r.cache[key]
+	if !ok {
+		return "", false
+	}
+	r.order.MoveToFront(el)
+
+	return el.Value.(*cacheEntry).html, true
+}
+
+func (r *renderer) put(key cacheKey, html string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.cache[key]; ok {
+		r.order.MoveToFront(el)
+		return
+	}
+
+	r.cache[key] = r.order.PushFront(&cacheEntry{key: key, html: html})
+	for r.order.Len() > r.size {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.cache, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// newMarkdown - подмножество CommonMark: абзацы, цитаты, списки, блоки и фрагменты кода,
+// ссылки и выделение. Заголовки, разделители и сырой HTML не разбираются и остаются текстом.
+func newMarkdown() goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithParser(parser.NewParser(
+			parser.WithBlockParsers(
+				util.Prioritized(parser.NewListParser(), 300),
+				util.Prioritized(parser.NewListItemParser(), 400),
+				util.Prioritized(parser.NewCodeBlockParser(), 500),
+				util.Prioritized(parser.NewFencedCodeBlockParser(), 700),
+				util.Prioritized(parser.NewBlockquoteParser(), 800),
+				util.Prioritized(parser.NewParagraphParser(), 1000),
+			),
+			parser.WithInlineParsers(
+				util.Prioritized(parser.NewCodeSpanParser(), 100),
+				util.Prioritized(parser.NewLinkParser(), 200),
+				util.Prioritized(parser.NewAutoLinkParser(), 300),
+				util.Prioritized(parser.NewEmphasisParser(), 500),
+			),
+			parser.WithParagraphTransformers(
+				util.Prioritized(parser.LinkReferenceParagraphTransformer, 100),
+			),
+		)),
+		goldmark.WithRendererOptions(html.WithHardWraps()),
+	)
+}
+
+// newPolicy - строгий белый список: только разметка, которую порождает newMarkdown. Ссылки - только
+// http(s) и mailto, с rel="nofollow noopener" и открытием внешних ссылок в новой вкладке.
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "em", "strong", "code", "pre", "blockquote", "ul", "ol", "li")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]{1,32}$`)).OnElements("code")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	p.AllowRelativeURLs(false)
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+
+	return p
+}
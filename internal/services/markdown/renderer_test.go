@@ -0,0 +1,162 @@
+package markdown
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func render(content string) string {
+	return New(0).Render(&models.Comment{Content: content})
+}
+
+func TestRender_Markdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "выделение",
+			content: "*курсив* и **жирный**",
+			want:    "<p><em>курсив</em> и <strong>жирный</strong></p>\n",
+		},
+		{
+			name:    "перевод строки",
+			content: "первая\nвторая",
+			want:    "<p>первая<br>\nвторая</p>\n",
+		},
+		{
+			name:    "встроенный код",
+			content: "вызовите `fmt.Println(\"<b>\")`",
+			want:    "<p>вызовите <code>fmt.Println(&#34;&lt;b&gt;&#34;)</code></p>\n",
+		},
+		{
+			name:    "блок кода",
+			content: "```go\nif a < b {}\n```",
+			want:    "<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>\n",
+		},
+		{
+			name:    "цитата",
+			content: "> цитата",
+			want:    "<blockquote>\n<p>цитата</p>\n</blockquote>\n",
+		},
+		{
+			name:    "ссылка",
+			content: "[сайт](https://example.com)",
+			want:    "<p><a href=\"https://example.com\" rel=\"nofollow noopener\" target=\"_blank\">сайт</a></p>\n",
+		},
+		{
+			name:    "заголовок остается текстом",
+			content: "# не заголовок",
+			want:    "<p># не заголовок</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, render(tt.content))
+		})
+	}
+}
+
+func TestRender_XSS(t *testing.T) {
+	vectors := []string{
+		`<script>alert(1)</script>`,
+		`<img src=x onerror=alert(1)>`,
+		`<svg/onload=alert(1)>`,
+		`<iframe src="javascript:alert(1)"></iframe>`,
+		`[click](javascript:alert(1))`,
+		`[click](JaVaScRiPt:alert(1))`,
+		`[click](java&#x09;script:alert(1))`,
+		`[click](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)`,
+		`[click](vbscript:msgbox(1))`,
+		`<javascript:alert(1)>`,
+		`![x](https://example.com/x.png" onerror="alert(1))`,
+		`![x](javascript:alert(1))`,
+		`[x](https://example.com "title\" onmouseover=\"alert(1)")`,
+		"<a href=\"https://example.com\" onclick=\"alert(1)\">x</a>",
+		"<div style=\"background:url(javascript:alert(1))\">x</div>",
+		"```\n</code></pre><script>alert(1)</script>\n```",
+		"```\" onclick=\"alert(1)\nx\n```",
+		"`<script>alert(1)</script>`",
+		"> <script>alert(1)</script>",
+		"<<script>script>alert(1)<</script>/script>",
+		"[x](//evil.example.com)",
+	}
+
+	for _, v := range vectors {
+		assertSafe(t, v, render(v))
+	}
+}
+
+// assertSafe разбирает результат как HTML и проверяет, что в нем только разрешенные
+// теги и атрибуты, а ссылки ведут на http(s) или mailto.
+func assertSafe(t *testing.T, input, out string) {
+	t.Helper()
+
+	allowed := map[string][]string{
+		"p": nil, "br": nil, "em": nil, "strong": nil, "pre": nil, "blockquote": nil,
+		"ul": nil, "li": nil,
+		"ol":   {"start"},
+		"code": {"class"},
+		"a":    {"href", "rel", "target"},
+	}
+
+	z := html.NewTokenizer(strings.NewReader(out))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		tok := z.Token()
+		attrs, ok := allowed[tok.Data]
+		if !assert.True(t, ok, "тег <%s> в %q из %q", tok.Data, out, input) {
+			continue
+		}
+		for _, a := range tok.Attr {
+			assert.Contains(t, attrs, a.Key, "атрибут %s в %q из %q", a.Key, out, input)
+			if a.Key == "href" {
+				u, err := url.Parse(a.Val)
+				if assert.NoError(t, err, input) {
+					assert.Contains(t, []string{"http", "https", "mailto"}, u.Scheme, "ссылка %q из %q", a.Val, input)
+				}
+			}
+		}
+	}
+}
+
+func TestRender_CachedPerRevision(t *testing.T) {
+	r := New(2)
+	c := &models.Comment{ID: 1, Revision: 1, Content: "*раз*"}
+
+	assert.Equal(t, "<p><em>раз</em></p>\n", r.Render(c))
+
+	// Та же ревизия - ответ из кеша, даже если текст в структуре другой
+	c.Content = "*два*"
+	assert.Equal(t, "<p><em>раз</em></p>\n", r.Render(c))
+
+	c.Revision = 2
+	assert.Equal(t, "<p><em>два</em></p>\n", r.Render(c))
+}
+
+func TestRender_CacheEviction(t *testing.T) {
+	r := New(2).(*renderer)
+
+	for id := int64(1); id <= 3; id++ {
+		r.Render(&models.Comment{ID: id, Revision: 1, Content: "x"})
+	}
+
+	assert.Equal(t, 2, r.order.Len())
+	_, ok := r.cache[cacheKey{id: 1, revision: 1}]
+	assert.False(t, ok)
+}
@@ -0,0 +1,349 @@
+package moderationsvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.Moderation = (*moderationSvc)(nil)
+
+type moderationSvc struct {
+	repo            infra.Database
+	notifications   services.Notifications
+	reportThreshold int
+	maxPinned       int
+}
+
+func New(
+	repo infra.Database,
+	notifications services.Notifications,
+	reportThreshold int,
+	maxPinned int,
+) *moderationSvc {
+	return &moderationSvc{
+		repo:            repo,
+		notifications:   notifications,
+		reportThreshold: reportThreshold,
+		maxPinned:       maxPinned,
+	}
+}
+
+func (s *moderationSvc) GetQueue(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
+	if pag == nil {
+		pag = &models.PagParam{}
+	}
+	if pag.Page == 0 {
+		pag.Page = 1
+	}
+	if pag.Limit == 0 {
+		pag.Limit = 20
+	}
+
+	return s.repo.GetPending(ctx, pag)
+}
+
+func (s *moderationSvc) Approve(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	comment, err := s.decide(ctx, id, models.StatusApproved, models.NewCommentAudit(actor.Key(), models.ActionApprove, id, reason))
+	if err != nil {
+		return err
+	}
+
+	s.notifyReply(ctx, comment)
+	s.notifyMentions(ctx, comment)
+	return nil
+}
+
+func (s *moderationSvc) Reject(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	_, err := s.decide(ctx, id, models.StatusRejected, models.NewCommentAudit(actor.Key(), models.ActionReject, id, reason))
+	return err
+}
+
+// Pin закрепляет видимый комментарий - он идет первым в списках корневых комментариев и ответов
+// при любой сортировке. В одном треде закреплено не больше maxPinned комментариев.
+func (s *moderationSvc) Pin(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	comment, err := s.getVisible(ctx, id)
+	if err != nil {
+		return err
+	}
+	if comment.PinnedAt != nil {
+		return fmt.Errorf("комментарий с id %d уже закреплен", id)
+	}
+
+	pinned, err := s.repo.PinComment(ctx, id, comment.ThreadKey, s.maxPinned, models.NewCommentAudit(actor.Key(), models.ActionPin, id, reason))
+	if err != nil {
+		return fmt.Errorf("s.repo.PinComment: %w", err)
+	}
+	if !pinned {
+		return fmt.Errorf("в треде %q уже закреплено максимум комментариев (%d)", comment.ThreadKey, s.maxPinned)
+	}
+
+	return nil
+}
+
+func (s *moderationSvc) Unpin(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	comment, err := s.getVisible(ctx, id)
+	if err != nil {
+		return err
+	}
+	if comment.PinnedAt == nil {
+		return fmt.Errorf("комментарий с id %d не закреплен", id)
+	}
+
+	if err := s.repo.UnpinComment(ctx, id, models.NewCommentAudit(actor.Key(), models.ActionUnpin, id, reason)); err != nil {
+		return fmt.Errorf("s.repo.UnpinComment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *moderationSvc) getVisible(ctx context.Context, id int64) (*models.Comment, error) {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil || comment.Status != models.StatusApproved {
+		return nil, fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if comment.DeletedAt != nil {
+		return nil, fmt.Errorf("комментарий с id %d уже удален", id)
+	}
+
+	return comment, nil
+}
+
+func (s *moderationSvc) GetThread(ctx context.Context, key string) (*models.Thread, error) {
+	thread, err := s.repo.GetThread(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetThread: %w", err)
+	}
+	if thread == nil {
+		return nil, fmt.Errorf("тред %q не найден", key)
+	}
+
+	return thread, nil
+}
+
+func (s *moderationSvc) UpdateThread(ctx context.Context, thread *models.Thread, actor *models.Actor) error {
+	return s.repo.SaveThread(ctx, thread, models.NewThreadAudit(actor.Key(), models.ActionThreadSettings, thread.Key, ""))
+}
+
+func (s *moderationSvc) LockThread(ctx context.Context, key string, locked bool, actor *models.Actor, reason string) error {
+	thread, err := s.GetThread(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	action := models.ActionUnlock
+	if locked {
+		action = models.ActionLock
+	}
+
+	return s.repo.SetThreadLock(ctx, thread.Key, locked, models.NewThreadAudit(actor.Key(), action, thread.Key, reason))
+}
+
+// SplitThread выделяет комментарий id вместе с веткой в новый тред key, где он становится корневым.
+// В исходном треде остается запись о переезде ветки.
+func (s *moderationSvc) SplitThread(
+	ctx context.Context,
+	id int64,
+	key string,
+	actor *models.Actor,
+	reason string,
+) (*models.Comment, error) {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil {
+		return nil, fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if comment.DeletedAt != nil {
+		return nil, fmt.Errorf("комментарий с id %d уже удален", id)
+	}
+
+	existing, err := s.repo.GetThread(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetThread: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("тред %q уже существует", key)
+	}
+
+	entry := models.NewCommentAudit(actor.Key(), models.ActionSplit, id, reason)
+	if err := s.repo.SplitThread(ctx, id, comment.ThreadKey, key, entry); err != nil {
+		return nil, fmt.Errorf("s.repo.SplitThread: %w", err)
+	}
+	comment.ParentID, comment.ThreadKey = nil, key
+
+	return comment, nil
+}
+
+// MergeThreads переносит все комментарии треда from в тред into. Тред from после этого разрешается
+// в into, поэтому старые ссылки продолжают работать. Возвращает число перенесенных комментариев.
+func (s *moderationSvc) MergeThreads(
+	ctx context.Context,
+	from, into string,
+	actor *models.Actor,
+	reason string,
+) (int64, error) {
+	source, err := s.GetThread(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	if source.Key != from {
+		return 0, fmt.Errorf("тред %q уже объединен с тредом %q", from, source.Key)
+	}
+
+	if into == from {
+		return 0, fmt.Errorf("нельзя объединить тред %q с самим собой", from)
+	}
+	target, err := s.GetThread(ctx, into)
+	if err != nil {
+		return 0, err
+	}
+	if target.Key != into {
+		return 0, fmt.Errorf("тред %q уже объединен с тредом %q", into, target.Key)
+	}
+
+	moved, err := s.repo.MergeThreads(ctx, from, into, models.NewThreadAudit(actor.Key(), models.ActionMerge, from, reason))
+	if err != nil {
+		return 0, fmt.Errorf("s.repo.MergeThreads: %w", err)
+	}
+
+	return moved, nil
+}
+
+func (s *moderationSvc) GetThreadRedirects(ctx context.Context, key string) ([]models.ThreadRedirect, error) {
+	return s.repo.GetThreadRedirects(ctx, key)
+}
+
+func (s *moderationSvc) Report(ctx context.Context, report *models.Report) error {
+	comment, err := s.repo.GetByID(ctx, report.CommentID)
+	if err != nil {
+		return fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil || comment.Status == models.StatusRejected {
+		return fmt.Errorf("комментарий с id %d не найден", report.CommentID)
+	}
+	if comment.DeletedAt != nil {
+		return fmt.Errorf("комментарий с id %d уже удален", report.CommentID)
+	}
+
+	count, inserted, err := s.repo.AddReport(ctx, report)
+	if err != nil {
+		return fmt.Errorf("s.repo.AddReport: %w", err)
+	}
+	if !inserted {
+		return fmt.Errorf("жалоба на комментарий с id %d уже отправлена", report.CommentID)
+	}
+
+	// Скрываем комментарий при достижении порога. Порог проверяется как >=, потому что одновременные
+	// жалобы могут перескочить точное значение; повторно после одобрения модератором комментарий
+	// не скрывается - это проверяет репозиторий под блокировкой строки.
+	if s.reportThreshold > 0 && count >= s.reportThreshold && comment.Status == models.StatusApproved {
+		entry := models.NewCommentAudit(
+			models.AuditActorSystem,
+			models.ActionAutoHide,
+			comment.ID,
+			fmt.Sprintf("получено жалоб: %d", count),
+		)
+		if _, err := s.repo.HideReported(ctx, comment.ID, s.reportThreshold, entry); err != nil {
+			return fmt.Errorf("s.repo.HideReported: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *moderationSvc) GetReported(ctx context.Context, pag *models.PagParam) (*models.ReportedRes, error) {
+	if pag == nil {
+		pag = &models.PagParam{}
+	}
+	if pag.Page == 0 {
+		pag.Page = 1
+	}
+	if pag.Limit == 0 {
+		pag.Limit = 20
+	}
+
+	return s.repo.GetReported(ctx, pag)
+}
+
+func (s *moderationSvc) GetAuditLog(ctx context.Context, filter *models.AuditFilter) (*models.AuditRes, error) {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 50
+	}
+
+	return s.repo.GetAuditLog(ctx, filter)
+}
+
+func (s *moderationSvc) decide(
+	ctx context.Context,
+	id int64,
+	status models.CommentStatus,
+	entry *models.AuditEntry,
+) (*models.Comment, error) {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil {
+		return nil, fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if comment.DeletedAt != nil {
+		return nil, fmt.Errorf("комментарий с id %d уже удален", id)
+	}
+	if comment.Status != models.StatusPending {
+		return nil, fmt.Errorf("комментарий с id %d не ожидает модерации", id)
+	}
+
+	if err := s.repo.SetStatus(ctx, id, status, entry); err != nil {
+		return nil, err
+	}
+	comment.Status = status
+
+	return comment, nil
+}
+
+// notifyReply уведомляет автора родителя об одобренном ответе; ошибки только логируются.
+func (s *moderationSvc) notifyReply(ctx context.Context, reply *models.Comment) {
+	if s.notifications == nil || reply.ParentID == nil {
+		return
+	}
+
+	parent, err := s.repo.GetByID(ctx, *reply.ParentID)
+	if err != nil {
+		zlog.Logger.Warn().Err(err).Int64("id", reply.ID).Msg("s.repo.GetByID")
+		return
+	}
+	if parent == nil || parent.DeletedAt != nil {
+		return
+	}
+
+	if err := s.notifications.NotifyReply(ctx, reply, parent); err != nil {
+		zlog.Logger.Warn().Err(err).Int64("id", reply.ID).Msg("s.notifications.NotifyReply")
+	}
+}
+
+func (s *moderationSvc) notifyMentions(ctx context.Context, comment *models.Comment) {
+	if s.notifications == nil {
+		return
+	}
+
+	comment.Mentions = models.ParseMentions(comment.Content)
+	if len(comment.Mentions) == 0 {
+		return
+	}
+	if err := s.notifications.NotifyMentions(ctx, comment); err != nil {
+		zlog.Logger.Warn().Err(err).Int64("id", comment.ID).Msg("s.notifications.NotifyMentions")
+	}
+}
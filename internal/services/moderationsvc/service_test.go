@@ -0,0 +1,473 @@
+package moderationsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/comment-tree/mocks"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var moderator = &models.Actor{User: "mod", Role: models.RoleModerator}
+var admin = &models.Actor{User: "admin", Role: models.RoleAdmin}
+
+func TestApprove_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusPending}, nil)
+	repo.EXPECT().SetStatus(ctx, int64(1), models.StatusApproved, &models.AuditEntry{
+		Actor:      "user:mod",
+		Action:     models.ActionApprove,
+		TargetType: models.AuditTargetComment,
+		TargetID:   "1",
+		Reason:     "по правилам",
+	}).Return(nil)
+
+	err := svc.Approve(ctx, 1, moderator, "по правилам")
+
+	assert.NoError(t, err)
+}
+
+func TestApprove_NotifiesParentAuthor(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	notifications := mocks.NewNotifications(t)
+	svc := New(repo, notifications, 3, 3)
+
+	ctx := context.Background()
+	parentID := int64(1)
+	parent := &models.Comment{ID: parentID, Author: "alice", Status: models.StatusApproved}
+	repo.EXPECT().GetByID(ctx, int64(2)).Return(&models.Comment{ID: 2, ParentID: &parentID, Author: "bob", Status: models.StatusPending}, nil)
+	repo.EXPECT().SetStatus(ctx, int64(2), models.StatusApproved, mock.Anything).Return(nil)
+	repo.EXPECT().GetByID(ctx, parentID).Return(parent, nil)
+	notifications.EXPECT().NotifyReply(ctx, mock.MatchedBy(func(c *models.Comment) bool {
+		return c.ID == 2 && c.Status == models.StatusApproved
+	}), parent).Return(nil)
+
+	err := svc.Approve(ctx, 2, moderator, "")
+
+	assert.NoError(t, err)
+}
+
+func TestReject_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusPending}, nil)
+	repo.EXPECT().SetStatus(ctx, int64(1), models.StatusRejected, mock.MatchedBy(func(e *models.AuditEntry) bool {
+		return e.Action == models.ActionReject && e.Actor == "user:mod"
+	})).Return(nil)
+
+	err := svc.Reject(ctx, 1, moderator, "")
+
+	assert.NoError(t, err)
+}
+
+func TestApprove_NotPending(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+
+	err := svc.Approve(ctx, 1, moderator, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "комментарий с id 1 не ожидает модерации")
+}
+
+func TestApprove_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(42)).Return(nil, nil)
+
+	err := svc.Approve(ctx, 42, moderator, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "комментарий с id 42 не найден")
+}
+
+func TestReject_Deleted(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	now := time.Now()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusPending, DeletedAt: &now}, nil)
+
+	err := svc.Reject(ctx, 1, moderator, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "комментарий с id 1 уже удален")
+}
+
+func TestGetQueue_Defaults(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	expected := &models.CommentsRes{Comments: []models.Comment{}, Page: 1, Limit: 20}
+	repo.EXPECT().GetPending(ctx, &models.PagParam{Page: 1, Limit: 20}).Return(expected, nil)
+
+	result, err := svc.GetQueue(ctx, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestGetThread_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetThread(ctx, "nope").Return(nil, nil)
+
+	_, err := svc.GetThread(ctx, "nope")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestLockThread_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetThread(ctx, "news").Return(&models.Thread{Key: "news"}, nil)
+	repo.EXPECT().SetThreadLock(ctx, "news", true, &models.AuditEntry{
+		Actor:      "user:mod",
+		Action:     models.ActionLock,
+		TargetType: models.AuditTargetThread,
+		TargetID:   "news",
+		Reason:     "флуд",
+	}).Return(nil)
+
+	err := svc.LockThread(ctx, "news", true, moderator, "флуд")
+
+	assert.NoError(t, err)
+}
+
+func TestLockThread_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetThread(ctx, "nope").Return(nil, nil)
+
+	err := svc.LockThread(ctx, "nope", false, moderator, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestGetAuditLog_Defaults(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	expected := &models.AuditRes{Entries: []models.AuditEntry{}, Page: 1, Limit: 50}
+	repo.EXPECT().GetAuditLog(ctx, &models.AuditFilter{Action: models.ActionDelete, Page: 1, Limit: 50}).Return(expected, nil)
+
+	result, err := svc.GetAuditLog(ctx, &models.AuditFilter{Action: models.ActionDelete})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+// Report tests.
+func TestReport_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	report := &models.Report{CommentID: 1, Reporter: "ip:1.1.1.1", Reason: "спам"}
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+	repo.EXPECT().AddReport(ctx, report).Return(1, true, nil)
+
+	err := svc.Report(ctx, report)
+
+	assert.NoError(t, err)
+}
+
+func TestReport_Duplicate(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	report := &models.Report{CommentID: 1, Reporter: "ip:1.1.1.1", Reason: "спам"}
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+	repo.EXPECT().AddReport(ctx, report).Return(1, false, nil)
+
+	err := svc.Report(ctx, report)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "уже отправлена")
+}
+
+func TestReport_ThresholdHides(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	report := &models.Report{CommentID: 1, Reporter: "user:reader", Reason: "оскорбления"}
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+	repo.EXPECT().AddReport(ctx, report).Return(3, true, nil)
+	repo.EXPECT().HideReported(ctx, int64(1), 3, &models.AuditEntry{
+		Actor:      models.AuditActorSystem,
+		Action:     models.ActionAutoHide,
+		TargetType: models.AuditTargetComment,
+		TargetID:   "1",
+		Reason:     "получено жалоб: 3",
+	}).Return(true, nil)
+
+	err := svc.Report(ctx, report)
+
+	assert.NoError(t, err)
+}
+
+func TestReport_AboveThresholdAfterApprove(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	report := &models.Report{CommentID: 1, Reporter: "user:reader", Reason: "оскорбления"}
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+	repo.EXPECT().AddReport(ctx, report).Return(4, true, nil)
+	repo.EXPECT().HideReported(ctx, int64(1), 3, &models.AuditEntry{
+		Actor:      models.AuditActorSystem,
+		Action:     models.ActionAutoHide,
+		TargetType: models.AuditTargetComment,
+		TargetID:   "1",
+		Reason:     "получено жалоб: 4",
+	}).Return(false, nil)
+
+	err := svc.Report(ctx, report)
+
+	assert.NoError(t, err)
+}
+
+func TestReport_ConcurrentReportsSkipThreshold(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	report := &models.Report{CommentID: 1, Reporter: "user:reader", Reason: "оскорбления"}
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+	// Третья и четвертая жалобы закоммичены одновременно, обе видят 4
+	repo.EXPECT().AddReport(ctx, report).Return(4, true, nil)
+	repo.EXPECT().HideReported(ctx, int64(1), 3, &models.AuditEntry{
+		Actor:      models.AuditActorSystem,
+		Action:     models.ActionAutoHide,
+		TargetType: models.AuditTargetComment,
+		TargetID:   "1",
+		Reason:     "получено жалоб: 4",
+	}).Return(true, nil)
+
+	err := svc.Report(ctx, report)
+
+	assert.NoError(t, err)
+}
+
+func TestReport_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	report := &models.Report{CommentID: 42, Reporter: "ip:1.1.1.1", Reason: "спам"}
+	repo.EXPECT().GetByID(ctx, int64(42)).Return(nil, nil)
+
+	err := svc.Report(ctx, report)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "комментарий с id 42 не найден")
+}
+
+func TestPin_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 2)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, ThreadKey: "qa", Status: models.StatusApproved}, nil)
+	repo.EXPECT().PinComment(ctx, int64(1), "qa", 2, mock.MatchedBy(func(e *models.AuditEntry) bool {
+		return e.Action == models.ActionPin && e.TargetID == "1"
+	})).Return(true, nil)
+
+	err := svc.Pin(ctx, 1, moderator, "лучший ответ")
+
+	assert.NoError(t, err)
+}
+
+func TestPin_LimitReached(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 2)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, ThreadKey: "qa", Status: models.StatusApproved}, nil)
+	repo.EXPECT().PinComment(ctx, int64(1), "qa", 2, mock.Anything).Return(false, nil)
+
+	err := svc.Pin(ctx, 1, moderator, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "закреплено максимум")
+}
+
+func TestPin_AlreadyPinned(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 2)
+
+	ctx := context.Background()
+	pinnedAt := time.Now()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved, PinnedAt: &pinnedAt}, nil)
+
+	err := svc.Pin(ctx, 1, moderator, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "уже закреплен")
+}
+
+func TestPin_Pending(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 2)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusPending}, nil)
+
+	err := svc.Pin(ctx, 1, moderator, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
+
+func TestUnpin_NotPinned(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 2)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+
+	err := svc.Unpin(ctx, 1, moderator, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не закреплен")
+}
+
+func TestUnpin_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 2)
+
+	ctx := context.Background()
+	pinnedAt := time.Now()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved, PinnedAt: &pinnedAt}, nil)
+	repo.EXPECT().UnpinComment(ctx, int64(1), mock.MatchedBy(func(e *models.AuditEntry) bool {
+		return e.Action == models.ActionUnpin
+	})).Return(nil)
+
+	err := svc.Unpin(ctx, 1, moderator, "")
+
+	assert.NoError(t, err)
+}
+
+func TestSplitThread_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	parentID := int64(1)
+	repo.EXPECT().GetByID(ctx, int64(5)).Return(&models.Comment{
+		ID: 5, ParentID: &parentID, ThreadKey: "qa", Status: models.StatusApproved,
+	}, nil)
+	repo.EXPECT().GetThread(ctx, "offtopic").Return(nil, nil)
+	repo.EXPECT().SplitThread(ctx, int64(5), "qa", "offtopic", mock.MatchedBy(func(e *models.AuditEntry) bool {
+		return e.Action == models.ActionSplit && e.TargetID == "5"
+	})).Return(nil)
+
+	comment, err := svc.SplitThread(ctx, 5, "offtopic", admin, "оффтоп")
+
+	assert.NoError(t, err)
+	assert.Nil(t, comment.ParentID)
+	assert.Equal(t, "offtopic", comment.ThreadKey)
+}
+
+func TestSplitThread_ThreadExists(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(5)).Return(&models.Comment{ID: 5, ThreadKey: "qa", Status: models.StatusApproved}, nil)
+	repo.EXPECT().GetThread(ctx, "news").Return(&models.Thread{Key: "news"}, nil)
+
+	_, err := svc.SplitThread(ctx, 5, "news", admin, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "уже существует")
+}
+
+func TestMergeThreads_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetThread(ctx, "dup").Return(&models.Thread{Key: "dup"}, nil)
+	repo.EXPECT().GetThread(ctx, "qa").Return(&models.Thread{Key: "qa"}, nil)
+	repo.EXPECT().MergeThreads(ctx, "dup", "qa", &models.AuditEntry{
+		Actor:      "user:admin",
+		Action:     models.ActionMerge,
+		TargetType: models.AuditTargetThread,
+		TargetID:   "dup",
+		Reason:     "дубль",
+	}).Return(7, nil)
+
+	moved, err := svc.MergeThreads(ctx, "dup", "qa", admin, "дубль")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), moved)
+}
+
+func TestMergeThreads_AlreadyMerged(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	// Объединенный тред разрешается в тред, с которым его объединили
+	repo.EXPECT().GetThread(ctx, "dup").Return(&models.Thread{Key: "qa"}, nil)
+
+	_, err := svc.MergeThreads(ctx, "dup", "news", admin, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "уже объединен")
+}
+
+func TestMergeThreads_IntoItself(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetThread(ctx, "qa").Return(&models.Thread{Key: "qa"}, nil)
+
+	_, err := svc.MergeThreads(ctx, "qa", "qa", admin, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "с самим собой")
+}
+
+func TestMergeThreads_TargetNotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, 3, 3)
+
+	ctx := context.Background()
+	repo.EXPECT().GetThread(ctx, "dup").Return(&models.Thread{Key: "dup"}, nil)
+	repo.EXPECT().GetThread(ctx, "missing").Return(nil, nil)
+
+	_, err := svc.MergeThreads(ctx, "dup", "missing", admin, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не найден")
+}
@@ -0,0 +1,199 @@
+package notificationsvc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.Notifications = (*notificationSvc)(nil)
+
+type notificationSvc struct {
+	repo   infra.Database
+	sender infra.NotificationSender
+	cfg    config.NotificationsConfig
+	// emails - адреса пользователей API-ключей, по имени пользователя в нижнем регистре
+	emails map[string]string
+	// recipients - пользователи с адресом, только их уведомления выбираются на отправку
+	recipients []string
+}
+
+func New(
+	repo infra.Database,
+	sender infra.NotificationSender,
+	cfg config.NotificationsConfig,
+	emails map[string]string,
+) *notificationSvc {
+	recipients := make([]string, 0, len(emails))
+	for user, email := range emails {
+		if email != "" {
+			recipients = append(recipients, user)
+		}
+	}
+	slices.Sort(recipients)
+
+	return &notificationSvc{repo: repo, sender: sender, cfg: cfg, emails: emails, recipients: recipients}
+}
+
+// NotifyReply записывает уведомление автору родительского комментария о видимом ответе.
+// Ответы самому себе и ответы, ожидающие модерации, не уведомляются.
+func (s *notificationSvc) NotifyReply(ctx context.Context, reply, parent *models.Comment) error {
+	if parent == nil || reply.Status != models.StatusApproved || reply.Author == parent.Author {
+		return nil
+	}
+
+	if err := s.repo.AddNotification(ctx, &models.Notification{
+		Recipient: parent.Author,
+		Type:      models.NotificationReply,
+		CommentID: reply.ID,
+		ParentID:  &parent.ID,
+		Actor:     reply.Author,
+	}); err != nil {
+		return fmt.Errorf("s.repo.AddNotification: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyMentions записывает уведомления пользователям, упомянутым в видимом комментарии.
+// Повторная правка не дублирует уведомления: на комментарий у получателя одно уведомление.
+func (s *notificationSvc) NotifyMentions(ctx context.Context, comment *models.Comment) error {
+	if comment.Status != models.StatusApproved {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(comment.Mentions))
+	for _, m := range comment.Mentions {
+		user := strings.ToLower(m.Username)
+		if _, ok := seen[user]; ok || user == strings.ToLower(comment.Author) {
+			continue
+		}
+		seen[user] = struct{}{}
+
+		if err := s.repo.AddNotification(ctx, &models.Notification{
+			Recipient: user,
+			Type:      models.NotificationMention,
+			CommentID: comment.ID,
+			ParentID:  comment.ParentID,
+			Actor:     comment.Author,
+		}); err != nil {
+			return fmt.Errorf("s.repo.AddNotification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *notificationSvc) GetUnread(ctx context.Context, user string, pag *models.PagParam) (*models.NotificationsRes, error) {
+	if pag == nil {
+		pag = &models.PagParam{}
+	}
+	if pag.Page == 0 {
+		pag.Page = 1
+	}
+	if pag.Limit == 0 {
+		pag.Limit = 20
+	}
+
+	return s.repo.GetUnreadNotifications(ctx, user, pag)
+}
+
+func (s *notificationSvc) MarkRead(ctx context.Context, user string, id int64) error {
+	ok, err := s.repo.MarkNotificationRead(ctx, user, id)
+	if err != nil {
+		return fmt.Errorf("s.repo.MarkNotificationRead: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("непрочитанное уведомление с id %d не найдено", id)
+	}
+
+	return nil
+}
+
+func (s *notificationSvc) MarkAllRead(ctx context.Context, user string) (int, error) {
+	return s.repo.MarkAllNotificationsRead(ctx, user)
+}
+
+// Run отправляет новые уведомления через sender раз в PollInterval до отмены ctx.
+// Уведомления без известного адреса получателя остаются только в GET /notifications.
+func (s *notificationSvc) Run(ctx context.Context) {
+	if s.sender == nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *notificationSvc) dispatch(ctx context.Context) {
+	if len(s.recipients) == 0 {
+		return
+	}
+
+	for {
+		batch, err := s.repo.ClaimNotifications(ctx, s.recipients, s.cfg.BatchSize, s.cfg.MaxAttempts, 2*s.cfg.SMTP.Timeout)
+		if err != nil {
+			zlog.Logger.Error().Err(err).Msg("s.repo.ClaimNotifications")
+			return
+		}
+
+		for i := range batch {
+			s.send(ctx, &batch[i])
+		}
+
+		if len(batch) < s.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// send отправляет уведомление и сохраняет результат. Неудачная отправка повторяется
+// с экспоненциальной задержкой, пока не исчерпаны MaxAttempts попыток.
+func (s *notificationSvc) send(ctx context.Context, n *models.Notification) {
+	email := s.emails[strings.ToLower(n.Recipient)]
+
+	sent := true
+	var retryIn time.Duration
+	if err := s.sender.Send(ctx, &models.Recipient{User: n.Recipient, Email: email}, n); err != nil {
+		sent = false
+		retryIn = s.backoff(n.Attempts)
+		zlog.Logger.Warn().
+			Err(err).
+			Int64("notification", n.ID).
+			Int("attempts", n.Attempts).
+			Msg("s.sender.Send")
+	}
+
+	if err := s.repo.SaveNotificationAttempt(ctx, n.ID, sent, retryIn); err != nil {
+		zlog.Logger.Error().Err(err).Int64("notification", n.ID).Msg("s.repo.SaveNotificationAttempt")
+	}
+}
+
+// backoff - задержка перед следующей попыткой: BackoffBase, удваиваемая с каждой неудачной попыткой.
+func (s *notificationSvc) backoff(attempts int) time.Duration {
+	delay := s.cfg.BackoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+	}
+
+	return delay
+}
@@ -0,0 +1,152 @@
+package notificationsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/mocks"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var testCfg = config.NotificationsConfig{
+	PollInterval: time.Second,
+	BatchSize:    2,
+	MaxAttempts:  3,
+	BackoffBase:  time.Minute,
+	SMTP:         config.SMTPConfig{Timeout: 10 * time.Second},
+}
+
+func TestNotifyReply_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg, nil)
+
+	ctx := context.Background()
+	parentID := int64(1)
+	repo.EXPECT().AddNotification(ctx, &models.Notification{
+		Recipient: "alice",
+		Type:      models.NotificationReply,
+		CommentID: 2,
+		ParentID:  &parentID,
+		Actor:     "bob",
+	}).Return(nil)
+
+	err := svc.NotifyReply(ctx,
+		&models.Comment{ID: 2, Author: "bob", Status: models.StatusApproved},
+		&models.Comment{ID: 1, Author: "alice"},
+	)
+
+	assert.NoError(t, err)
+}
+
+func TestNotifyReply_Skipped(t *testing.T) {
+	svc := New(mocks.NewDatabase(t), nil, testCfg, nil)
+	ctx := context.Background()
+	parent := &models.Comment{ID: 1, Author: "alice"}
+
+	// Ответ самому себе
+	assert.NoError(t, svc.NotifyReply(ctx, &models.Comment{ID: 2, Author: "alice", Status: models.StatusApproved}, parent))
+	// Ответ на премодерации
+	assert.NoError(t, svc.NotifyReply(ctx, &models.Comment{ID: 3, Author: "bob", Status: models.StatusPending}, parent))
+}
+
+func TestNotifyMentions(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg, nil)
+
+	ctx := context.Background()
+	comment := &models.Comment{
+		ID:      3,
+		Author:  "Bob",
+		Status:  models.StatusApproved,
+		Content: "@Alice, @alice и @bob",
+	}
+	comment.Mentions = models.ParseMentions(comment.Content)
+	// Одно уведомление на пользователя, автору о самом себе - нет
+	repo.EXPECT().AddNotification(ctx, &models.Notification{
+		Recipient: "alice",
+		Type:      models.NotificationMention,
+		CommentID: 3,
+		Actor:     "Bob",
+	}).Return(nil).Once()
+
+	err := svc.NotifyMentions(ctx, comment)
+
+	assert.NoError(t, err)
+}
+
+func TestMarkRead_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg, nil)
+
+	ctx := context.Background()
+	repo.EXPECT().MarkNotificationRead(ctx, "alice", int64(5)).Return(false, nil)
+
+	err := svc.MarkRead(ctx, "alice", 5)
+
+	assert.ErrorContains(t, err, "не найдено")
+}
+
+func TestGetUnread_Defaults(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg, nil)
+
+	ctx := context.Background()
+	repo.EXPECT().GetUnreadNotifications(ctx, "alice", &models.PagParam{Page: 1, Limit: 20}).
+		Return(&models.NotificationsRes{}, nil)
+
+	_, err := svc.GetUnread(ctx, "alice", nil)
+
+	assert.NoError(t, err)
+}
+
+func TestDispatch_SendsToKnownRecipients(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	sender := mocks.NewNotificationSender(t)
+	svc := New(repo, sender, testCfg, map[string]string{"alice": "alice@example.com", "bob": ""})
+
+	ctx := context.Background()
+	repo.EXPECT().ClaimNotifications(ctx, []string{"alice"}, 2, 3, 20*time.Second).Return([]models.Notification{
+		{ID: 1, Recipient: "Alice", Attempts: 1},
+		{ID: 2, Recipient: "alice", Attempts: 1},
+	}, nil).Once()
+	repo.EXPECT().ClaimNotifications(ctx, []string{"alice"}, 2, 3, 20*time.Second).Return(nil, nil).Once()
+	sender.EXPECT().Send(ctx, &models.Recipient{User: "Alice", Email: "alice@example.com"}, mock.MatchedBy(func(n *models.Notification) bool {
+		return n.ID == 1
+	})).Return(nil)
+	sender.EXPECT().Send(ctx, &models.Recipient{User: "alice", Email: "alice@example.com"}, mock.MatchedBy(func(n *models.Notification) bool {
+		return n.ID == 2
+	})).Return(nil)
+	repo.EXPECT().SaveNotificationAttempt(ctx, int64(1), true, time.Duration(0)).Return(nil)
+	repo.EXPECT().SaveNotificationAttempt(ctx, int64(2), true, time.Duration(0)).Return(nil)
+
+	svc.dispatch(ctx)
+}
+
+func TestDispatch_FailedSendIsRetried(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	sender := mocks.NewNotificationSender(t)
+	svc := New(repo, sender, testCfg, map[string]string{"alice": "alice@example.com"})
+
+	ctx := context.Background()
+	repo.EXPECT().ClaimNotifications(ctx, []string{"alice"}, 2, 3, 20*time.Second).Return([]models.Notification{
+		{ID: 1, Recipient: "alice", Attempts: 2},
+	}, nil).Once()
+	sender.EXPECT().Send(ctx, &models.Recipient{User: "alice", Email: "alice@example.com"}, mock.Anything).
+		Return(errors.New("smtp недоступен"))
+	// Вторая неудачная попытка: BackoffBase удваивается
+	repo.EXPECT().SaveNotificationAttempt(ctx, int64(1), false, 2*time.Minute).Return(nil)
+
+	svc.dispatch(ctx)
+}
+
+func TestDispatch_NoRecipients(t *testing.T) {
+	svc := New(mocks.NewDatabase(t), mocks.NewNotificationSender(t), testCfg, map[string]string{"bob": ""})
+
+	svc.dispatch(context.Background())
+}
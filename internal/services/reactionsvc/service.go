@@ -0,0 +1,80 @@
+package reactionsvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.Reactions = (*reactionSvc)(nil)
+
+type reactionSvc struct {
+	repo    infra.Database
+	allowed map[string]struct{}
+}
+
+func New(repo infra.Database, allowed []string) *reactionSvc {
+	set := make(map[string]struct{}, len(allowed))
+	for _, emoji := range allowed {
+		set[emoji] = struct{}{}
+	}
+
+	return &reactionSvc{repo: repo, allowed: set}
+}
+
+// React ставит реакцию из белого списка на видимый комментарий и возвращает новую сводку.
+// Один пользователь ставит каждую реакцию не больше одного раза.
+func (s *reactionSvc) React(ctx context.Context, commentID int64, user, emoji string) ([]models.Reaction, error) {
+	if _, ok := s.allowed[emoji]; !ok {
+		return nil, fmt.Errorf("реакция %q не разрешена", emoji)
+	}
+	if err := s.checkComment(ctx, commentID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddReaction(ctx, commentID, user, emoji); err != nil {
+		return nil, fmt.Errorf("s.repo.AddReaction: %w", err)
+	}
+
+	return s.summary(ctx, commentID, user)
+}
+
+// Unreact снимает реакцию. Белый список не проверяется: реакцию, убранную из конфига, тоже можно снять.
+func (s *reactionSvc) Unreact(ctx context.Context, commentID int64, user, emoji string) ([]models.Reaction, error) {
+	if err := s.checkComment(ctx, commentID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RemoveReaction(ctx, commentID, user, emoji); err != nil {
+		return nil, fmt.Errorf("s.repo.RemoveReaction: %w", err)
+	}
+
+	return s.summary(ctx, commentID, user)
+}
+
+func (s *reactionSvc) checkComment(ctx context.Context, id int64) error {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("s.repo.GetByID: %w", err)
+	}
+	if comment == nil || comment.Status != models.StatusApproved {
+		return fmt.Errorf("комментарий с id %d не найден", id)
+	}
+	if comment.DeletedAt != nil {
+		return fmt.Errorf("комментарий с id %d уже удален", id)
+	}
+
+	return nil
+}
+
+func (s *reactionSvc) summary(ctx context.Context, commentID int64, user string) ([]models.Reaction, error) {
+	reactions, err := s.repo.GetReactions(ctx, []int64{commentID}, user)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetReactions: %w", err)
+	}
+
+	return reactions[commentID], nil
+}
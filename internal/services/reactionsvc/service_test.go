@@ -0,0 +1,98 @@
+package reactionsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/comment-tree/mocks"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var allowed = []string{"👍", "❤️"}
+
+func TestReact_OK(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, allowed)
+
+	ctx := context.Background()
+	summary := []models.Reaction{{Emoji: "👍", Count: 3, Reacted: true}}
+
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+	repo.EXPECT().AddReaction(ctx, int64(1), "alice", "👍").Return(nil)
+	repo.EXPECT().GetReactions(ctx, []int64{1}, "alice").Return(map[int64][]models.Reaction{1: summary}, nil)
+
+	got, err := svc.React(ctx, 1, "alice", "👍")
+
+	assert.NoError(t, err)
+	assert.Equal(t, summary, got)
+}
+
+func TestReact_NotAllowed(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, allowed)
+
+	_, err := svc.React(context.Background(), 1, "alice", "💩")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не разрешена")
+}
+
+func TestReact_CommentUnavailable(t *testing.T) {
+	deletedAt := time.Now()
+	tests := []struct {
+		name    string
+		comment *models.Comment
+		errMsg  string
+	}{
+		{name: "не существует", comment: nil, errMsg: "не найден"},
+		{name: "на модерации", comment: &models.Comment{ID: 1, Status: models.StatusPending}, errMsg: "не найден"},
+		{name: "удален", comment: &models.Comment{ID: 1, Status: models.StatusApproved, DeletedAt: &deletedAt}, errMsg: "уже удален"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewDatabase(t)
+			svc := New(repo, allowed)
+
+			ctx := context.Background()
+			repo.EXPECT().GetByID(ctx, int64(1)).Return(tt.comment, nil)
+
+			_, err := svc.React(ctx, 1, "alice", "👍")
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestUnreact_RemovedFromAllowlist(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, allowed)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+	repo.EXPECT().RemoveReaction(ctx, int64(1), "alice", "🙈").Return(nil)
+	repo.EXPECT().GetReactions(ctx, []int64{1}, "alice").Return(map[int64][]models.Reaction{}, nil)
+
+	got, err := svc.Unreact(ctx, 1, "alice", "🙈")
+
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestUnreact_RepoError(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, allowed)
+
+	ctx := context.Background()
+	repo.EXPECT().GetByID(ctx, int64(1)).Return(&models.Comment{ID: 1, Status: models.StatusApproved}, nil)
+	repo.EXPECT().RemoveReaction(ctx, int64(1), "alice", "👍").Return(errors.New("db error"))
+
+	_, err := svc.Unreact(ctx, 1, "alice", "👍")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package searchsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var _ services.Search = (*searchSvc)(nil)
+
+type searchSvc struct {
+	searcher  infra.Searcher
+	threshold float64
+}
+
+// New создает сервис поиска поверх движка searcher. threshold - порог сходства для нечеткого поиска.
+func New(searcher infra.Searcher, threshold float64) *searchSvc {
+	return &searchSvc{searcher: searcher, threshold: threshold}
+}
+
+// Search ищет комментарии по запросу с фильтрами; самые релевантные идут первыми.
+// Без явного режима выполняется полнотекстовый поиск, а если он ничего не нашел - нечеткий.
+func (s *searchSvc) Search(ctx context.Context, q *models.SearchQuery) (*models.SearchRes, error) {
+	q.Query = strings.TrimSpace(q.Query)
+	if q.Query == "" {
+		return nil, fmt.Errorf("поисковый запрос не может быть пустым")
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.From.After(q.To) {
+		return nil, fmt.Errorf("начало периода не может быть позже конца")
+	}
+
+	if q.Page == 0 {
+		q.Page = 1
+	}
+	if q.Limit == 0 {
+		q.Limit = 20
+	}
+
+	fallback := q.Mode == ""
+	if fallback {
+		q.Mode = models.SearchModeFTS
+	}
+	q.Threshold = s.threshold
+
+	res, err := s.searcher.Search(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("s.searcher.Search: %w", err)
+	}
+	if !fallback || res.Total > 0 {
+		return res, nil
+	}
+
+	q.Mode = models.SearchModeFuzzy
+	res, err = s.searcher.Search(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("s.searcher.Search: %w", err)
+	}
+
+	return res, nil
+}
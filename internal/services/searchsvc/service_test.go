@@ -0,0 +1,104 @@
+package searchsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/comment-tree/mocks"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+func TestSearch_Defaults(t *testing.T) {
+	searcher := mocks.NewSearcher(t)
+	svc := New(searcher, 0.3)
+
+	ctx := context.Background()
+	searcher.EXPECT().Search(ctx, mock.MatchedBy(func(q *models.SearchQuery) bool {
+		return q.Query == `"дерево комментариев" -спам` && q.Page == 1 && q.Limit == 20 && q.Mode == models.SearchModeFTS
+	})).Return(&models.SearchRes{Total: 1, Page: 1, Limit: 20, Pages: 1, Mode: models.SearchModeFTS}, nil).Once()
+
+	res, err := svc.Search(ctx, &models.SearchQuery{Query: `  "дерево комментариев" -спам `})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Page)
+	assert.Equal(t, models.SearchModeFTS, res.Mode)
+}
+
+func TestSearch_FallbackToFuzzy(t *testing.T) {
+	searcher := mocks.NewSearcher(t)
+	svc := New(searcher, 0.3)
+
+	ctx := context.Background()
+	searcher.EXPECT().Search(ctx, mock.MatchedBy(func(q *models.SearchQuery) bool {
+		return q.Mode == models.SearchModeFTS
+	})).Return(&models.SearchRes{Page: 1, Limit: 20, Pages: 0, Mode: models.SearchModeFTS}, nil).Once()
+	searcher.EXPECT().Search(ctx, mock.MatchedBy(func(q *models.SearchQuery) bool {
+		return q.Mode == models.SearchModeFuzzy && q.Threshold == 0.3
+	})).Return(&models.SearchRes{Total: 2, Page: 1, Limit: 20, Pages: 1, Mode: models.SearchModeFuzzy}, nil).Once()
+
+	res, err := svc.Search(ctx, &models.SearchQuery{Query: "alise"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.SearchModeFuzzy, res.Mode)
+	assert.Equal(t, 2, res.Total)
+}
+
+func TestSearch_ExplicitFTSNoFallback(t *testing.T) {
+	searcher := mocks.NewSearcher(t)
+	svc := New(searcher, 0.3)
+
+	ctx := context.Background()
+	searcher.EXPECT().Search(ctx, mock.MatchedBy(func(q *models.SearchQuery) bool {
+		return q.Mode == models.SearchModeFTS
+	})).Return(&models.SearchRes{Page: 1, Limit: 20, Mode: models.SearchModeFTS}, nil).Once()
+
+	res, err := svc.Search(ctx, &models.SearchQuery{Query: "alise", Mode: models.SearchModeFTS})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.SearchModeFTS, res.Mode)
+	assert.Equal(t, 0, res.Total)
+}
+
+func TestSearch_Fuzzy(t *testing.T) {
+	searcher := mocks.NewSearcher(t)
+	svc := New(searcher, 0.45)
+
+	ctx := context.Background()
+	searcher.EXPECT().Search(ctx, mock.MatchedBy(func(q *models.SearchQuery) bool {
+		return q.Mode == models.SearchModeFuzzy && q.Threshold == 0.45
+	})).Return(&models.SearchRes{Page: 1, Limit: 20, Mode: models.SearchModeFuzzy}, nil).Once()
+
+	res, err := svc.Search(ctx, &models.SearchQuery{Query: "postgersql", Mode: models.SearchModeFuzzy})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.SearchModeFuzzy, res.Mode)
+}
+
+func TestSearch_EmptyQuery(t *testing.T) {
+	searcher := mocks.NewSearcher(t)
+	svc := New(searcher, 0.3)
+
+	_, err := svc.Search(context.Background(), &models.SearchQuery{Query: "   ", Author: "alice"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "не может быть пустым")
+}
+
+func TestSearch_InvalidPeriod(t *testing.T) {
+	searcher := mocks.NewSearcher(t)
+	svc := New(searcher, 0.3)
+
+	now := time.Now()
+	_, err := svc.Search(context.Background(), &models.SearchQuery{
+		Query: "go",
+		From:  now,
+		To:    now.Add(-time.Hour),
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "позже")
+}
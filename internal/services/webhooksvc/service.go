@@ -0,0 +1,280 @@
+package webhooksvc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wb-go/wbf/zlog"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/internal/interfaces/infra"
+	"github.com/sunr3d/comment-tree/internal/interfaces/services"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+const maxErrorLen = 1000
+
+var _ services.Webhooks = (*webhooksSvc)(nil)
+
+var knownEvents = map[string]struct{}{
+	models.OutboxCommentCreated:  {},
+	models.OutboxCommentEdited:   {},
+	models.OutboxCommentDeleted:  {},
+	models.OutboxCommentRestored: {},
+	models.OutboxCommentApproved: {},
+	models.OutboxCommentRejected: {},
+	models.OutboxCommentHidden:   {},
+	models.OutboxCommentPinned:   {},
+	models.OutboxCommentUnpinned: {},
+	models.OutboxCommentMoved:    {},
+}
+
+type webhooksSvc struct {
+	repo   infra.Database
+	sender infra.WebhookSender
+	cfg    config.WebhooksConfig
+}
+
+func New(repo infra.Database, sender infra.WebhookSender, cfg config.WebhooksConfig) *webhooksSvc {
+	return &webhooksSvc{repo: repo, sender: sender, cfg: cfg}
+}
+
+// envelope - тело запроса к подписчику. id совпадает во всех повторах одного события,
+// по нему подписчик может отбрасывать дубликаты.
+type envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func (s *webhooksSvc) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if err := validate(webhook); err != nil {
+		return err
+	}
+	if webhook.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+
+	return s.repo.CreateWebhook(ctx, webhook)
+}
+
+func (s *webhooksSvc) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	return s.repo.GetWebhooks(ctx)
+}
+
+func (s *webhooksSvc) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if err := validate(webhook); err != nil {
+		return err
+	}
+
+	existing, err := s.getWebhook(ctx, webhook.ID)
+	if err != nil {
+		return err
+	}
+	webhook.Secret = existing.Secret
+	webhook.CreatedAt = existing.CreatedAt
+
+	return s.repo.UpdateWebhook(ctx, webhook)
+}
+
+func (s *webhooksSvc) DeleteWebhook(ctx context.Context, id int64) error {
+	if _, err := s.getWebhook(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteWebhook(ctx, id)
+}
+
+func (s *webhooksSvc) GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) (*models.DeliveriesRes, error) {
+	if _, err := s.getWebhook(ctx, filter.WebhookID); err != nil {
+		return nil, err
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 20
+	}
+
+	return s.repo.GetDeliveries(ctx, filter)
+}
+
+func (s *webhooksSvc) Redeliver(ctx context.Context, webhookID, deliveryID int64) error {
+	delivery, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return fmt.Errorf("s.repo.GetDelivery: %w", err)
+	}
+	if delivery == nil || delivery.WebhookID != webhookID {
+		return fmt.Errorf("доставка с id %d не найдена", deliveryID)
+	}
+
+	return s.repo.Redeliver(ctx, deliveryID)
+}
+
+// Run - цикл диспетчера: разбирает outbox в доставки и отправляет те, чье время пришло.
+// Работает до отмены ctx; несколько экземпляров не мешают друг другу.
+func (s *webhooksSvc) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *webhooksSvc) dispatch(ctx context.Context) {
+	for {
+		n, err := s.repo.FanOutOutbox(ctx, s.cfg.BatchSize)
+		if err != nil {
+			zlog.Logger.Error().Err(err).Msg("s.repo.FanOutOutbox")
+			break
+		}
+		if n < s.cfg.BatchSize {
+			break
+		}
+	}
+
+	// Аренда с запасом покрывает отправку: запросы идут параллельно и ограничены Timeout.
+	deliveries, err := s.repo.ClaimDeliveries(ctx, s.cfg.BatchSize, 2*s.cfg.Timeout)
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("s.repo.ClaimDeliveries")
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		wg.Add(1)
+		go func(d *models.WebhookDelivery) {
+			defer wg.Done()
+			s.deliver(ctx, d)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+}
+
+func (s *webhooksSvc) deliver(ctx context.Context, d *models.WebhookDelivery) {
+	body, err := json.Marshal(envelope{
+		ID:        d.EventID,
+		Type:      d.EventType,
+		CreatedAt: d.CreatedAt,
+		Data:      d.Payload,
+	})
+	if err != nil {
+		zlog.Logger.Error().Err(err).Int64("delivery", d.ID).Msg("json.Marshal")
+		return
+	}
+
+	code, err := s.sender.Send(ctx, &models.WebhookRequest{
+		URL:        d.URL,
+		Secret:     d.Secret,
+		DeliveryID: d.ID,
+		EventType:  d.EventType,
+		Body:       body,
+	})
+	if err != nil && ctx.Err() != nil {
+		// Остановка сервиса - не вина подписчика, доставка вернется в очередь по истечении аренды
+		return
+	}
+
+	d.Attempts++
+	d.LastStatusCode = nil
+	if code != 0 {
+		d.LastStatusCode = &code
+	}
+
+	var retryIn time.Duration
+	switch {
+	case err == nil:
+		d.Status = models.DeliveryDelivered
+		d.LastError = ""
+	case d.Attempts >= s.cfg.MaxAttempts:
+		d.Status = models.DeliveryDead
+		d.LastError = truncate(err.Error())
+		zlog.Logger.Warn().Err(err).Int64("delivery", d.ID).Int64("webhook", d.WebhookID).Msg("доставка вебхука исчерпала попытки")
+	default:
+		d.Status = models.DeliveryPending
+		d.LastError = truncate(err.Error())
+		retryIn = s.backoff(d.Attempts)
+	}
+
+	if err := s.repo.SaveDeliveryAttempt(ctx, d, retryIn); err != nil {
+		zlog.Logger.Error().Err(err).Int64("delivery", d.ID).Msg("s.repo.SaveDeliveryAttempt")
+	}
+}
+
+// backoff - экспоненциальная задержка перед попыткой attempts+1: base, 2·base, 4·base... не больше max.
+func (s *webhooksSvc) backoff(attempts int) time.Duration {
+	delay := s.cfg.BackoffBase
+	for i := 1; i < attempts && delay < s.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, s.cfg.BackoffMax)
+}
+
+func (s *webhooksSvc) getWebhook(ctx context.Context, id int64) (*models.Webhook, error) {
+	webhook, err := s.repo.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetWebhook: %w", err)
+	}
+	if webhook == nil {
+		return nil, fmt.Errorf("вебхук с id %d не найден", id)
+	}
+
+	return webhook, nil
+}
+
+func validate(webhook *models.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("некорректный URL вебхука: %q", webhook.URL)
+	}
+	// Имена проверяются еще раз при отправке, после разрешения в адрес
+	host := u.Hostname()
+	if ip := net.ParseIP(host); strings.EqualFold(host, "localhost") || (ip != nil && !models.PublicIP(ip)) {
+		return fmt.Errorf("некорректный URL вебхука: адрес %q во внутренней сети", host)
+	}
+	for _, e := range webhook.Events {
+		if _, ok := knownEvents[e]; !ok {
+			return fmt.Errorf("некорректный тип события: %q", e)
+		}
+	}
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("rand.Read: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func truncate(s string) string {
+	if r := []rune(s); len(r) > maxErrorLen {
+		return string(r[:maxErrorLen])
+	}
+
+	return s
+}
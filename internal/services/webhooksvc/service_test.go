@@ -0,0 +1,162 @@
+package webhooksvc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sunr3d/comment-tree/internal/config"
+	"github.com/sunr3d/comment-tree/mocks"
+	"github.com/sunr3d/comment-tree/models"
+)
+
+var testCfg = config.WebhooksConfig{
+	PollInterval: time.Second,
+	BatchSize:    10,
+	Timeout:      5 * time.Second,
+	MaxAttempts:  3,
+	BackoffBase:  10 * time.Second,
+	BackoffMax:   30 * time.Second,
+}
+
+func TestCreateWebhook_GeneratesSecret(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg)
+
+	ctx := context.Background()
+	repo.EXPECT().CreateWebhook(ctx, mock.MatchedBy(func(w *models.Webhook) bool {
+		return len(w.Secret) == 64
+	})).Return(nil)
+
+	err := svc.CreateWebhook(ctx, &models.Webhook{URL: "https://example.com/hook", Active: true})
+
+	assert.NoError(t, err)
+}
+
+func TestCreateWebhook_Invalid(t *testing.T) {
+	svc := New(mocks.NewDatabase(t), nil, testCfg)
+
+	err := svc.CreateWebhook(context.Background(), &models.Webhook{URL: "ftp://example.com"})
+	assert.ErrorContains(t, err, "некорректный URL")
+
+	err = svc.CreateWebhook(context.Background(), &models.Webhook{URL: "https://example.com", Events: []string{"comment.liked"}})
+	assert.ErrorContains(t, err, "некорректный тип события")
+
+	for _, u := range []string{"http://localhost:8080", "http://127.0.0.1/hook", "http://10.0.0.5", "http://169.254.169.254/latest", "http://[::1]/"} {
+		err = svc.CreateWebhook(context.Background(), &models.Webhook{URL: u})
+		assert.ErrorContains(t, err, "во внутренней сети", u)
+	}
+}
+
+func TestUpdateWebhook_KeepsSecret(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg)
+
+	ctx := context.Background()
+	repo.EXPECT().GetWebhook(ctx, int64(1)).Return(&models.Webhook{ID: 1, Secret: "old"}, nil)
+	repo.EXPECT().UpdateWebhook(ctx, mock.MatchedBy(func(w *models.Webhook) bool {
+		return w.Secret == "old" && w.URL == "https://example.com/new"
+	})).Return(nil)
+
+	err := svc.UpdateWebhook(ctx, &models.Webhook{ID: 1, URL: "https://example.com/new", Secret: "new"})
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteWebhook_NotFound(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg)
+
+	ctx := context.Background()
+	repo.EXPECT().GetWebhook(ctx, int64(7)).Return(nil, nil)
+
+	err := svc.DeleteWebhook(ctx, 7)
+
+	assert.ErrorContains(t, err, "не найден")
+}
+
+func TestRedeliver_OtherWebhook(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg)
+
+	ctx := context.Background()
+	repo.EXPECT().GetDelivery(ctx, int64(5)).Return(&models.WebhookDelivery{ID: 5, WebhookID: 2}, nil)
+
+	err := svc.Redeliver(ctx, 1, 5)
+
+	assert.ErrorContains(t, err, "не найдена")
+}
+
+func TestDispatch_Delivered(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	sender := mocks.NewWebhookSender(t)
+	svc := New(repo, sender, testCfg)
+
+	ctx := context.Background()
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().FanOutOutbox(ctx, 10).Return(3, nil)
+	repo.EXPECT().ClaimDeliveries(ctx, 10, 10*time.Second).Return([]models.WebhookDelivery{{
+		ID:        1,
+		WebhookID: 2,
+		EventID:   3,
+		EventType: models.OutboxCommentCreated,
+		Payload:   json.RawMessage(`{"id":9}`),
+		CreatedAt: created,
+		URL:       "https://example.com/hook",
+		Secret:    "s",
+	}}, nil)
+	sender.EXPECT().Send(ctx, mock.MatchedBy(func(r *models.WebhookRequest) bool {
+		return r.URL == "https://example.com/hook" && r.DeliveryID == 1 &&
+			string(r.Body) == `{"id":3,"type":"comment.created","created_at":"2024-01-01T00:00:00Z","data":{"id":9}}`
+	})).Return(200, nil)
+	repo.EXPECT().SaveDeliveryAttempt(ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+		return d.Status == models.DeliveryDelivered && d.Attempts == 1 && *d.LastStatusCode == 200
+	}), time.Duration(0)).Return(nil)
+
+	svc.dispatch(ctx)
+}
+
+func TestDispatch_FanOutUntilDrained(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	svc := New(repo, nil, testCfg)
+
+	ctx := context.Background()
+	repo.EXPECT().FanOutOutbox(ctx, 10).Return(10, nil).Twice()
+	repo.EXPECT().FanOutOutbox(ctx, 10).Return(0, nil).Once()
+	repo.EXPECT().ClaimDeliveries(ctx, 10, 10*time.Second).Return(nil, nil)
+
+	svc.dispatch(ctx)
+}
+
+func TestDeliver_RetryThenDead(t *testing.T) {
+	repo := mocks.NewDatabase(t)
+	sender := mocks.NewWebhookSender(t)
+	svc := New(repo, sender, testCfg)
+
+	ctx := context.Background()
+	sender.EXPECT().Send(ctx, mock.Anything).Return(503, errors.New("подписчик ответил 503"))
+
+	repo.EXPECT().SaveDeliveryAttempt(ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+		return d.Status == models.DeliveryPending && d.Attempts == 2
+	}), 20*time.Second).Return(nil).Once()
+	svc.deliver(ctx, &models.WebhookDelivery{ID: 1, Attempts: 1})
+
+	repo.EXPECT().SaveDeliveryAttempt(ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+		return d.Status == models.DeliveryDead && d.Attempts == 3 && d.LastError == "подписчик ответил 503"
+	}), time.Duration(0)).Return(nil).Once()
+	svc.deliver(ctx, &models.WebhookDelivery{ID: 1, Attempts: 2})
+}
+
+func TestBackoff(t *testing.T) {
+	svc := New(nil, nil, testCfg)
+
+	assert.Equal(t, 10*time.Second, svc.backoff(1))
+	assert.Equal(t, 20*time.Second, svc.backoff(2))
+	assert.Equal(t, 30*time.Second, svc.backoff(3))
+	assert.Equal(t, 30*time.Second, svc.backoff(100))
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_comments_pending;
+DROP INDEX IF EXISTS idx_comments_thread_key;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS status;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS thread_key;
+DROP TABLE IF EXISTS threads;
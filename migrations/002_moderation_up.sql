@@ -0,0 +1,18 @@
+CREATE TABLE threads (
+    key VARCHAR(255) PRIMARY KEY,
+    premoderation BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+INSERT INTO threads (key) VALUES ('default');
+
+ALTER TABLE comments ADD COLUMN thread_key VARCHAR(255) NOT NULL DEFAULT 'default' REFERENCES threads(key);
+ALTER TABLE comments ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'approved'
+    CHECK (status IN ('pending', 'approved', 'rejected'));
+
+CREATE INDEX idx_comments_thread_key ON comments(thread_key);
+-- Очередь модерации
+CREATE INDEX idx_comments_pending ON comments(created_at) WHERE status = 'pending';
+
+GRANT ALL PRIVILEGES ON TABLE threads TO comment_tree_user;
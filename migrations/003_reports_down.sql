@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_comment_reports_comment_id;
+DROP TABLE IF EXISTS comment_reports;
@@ -0,0 +1,13 @@
+CREATE TABLE comment_reports (
+    id SERIAL PRIMARY KEY,
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    reporter VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (comment_id, reporter)
+);
+
+CREATE INDEX idx_comment_reports_comment_id ON comment_reports(comment_id);
+
+GRANT ALL PRIVILEGES ON TABLE comment_reports TO comment_tree_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO comment_tree_user;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+ALTER TABLE IF EXISTS threads DROP COLUMN IF EXISTS locked_at;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS revision;
@@ -0,0 +1,33 @@
+ALTER TABLE comments ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE threads ADD COLUMN locked_at TIMESTAMP NULL;
+
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    before JSONB NULL,
+    after JSONB NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_target ON audit_log(target_type, target_id);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+
+-- Журнал только дополняется
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log доступен только для добавления записей';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_no_update BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+CREATE TRIGGER trg_audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+GRANT SELECT, INSERT ON TABLE audit_log TO comment_tree_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO comment_tree_user;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS comment_events;
+DROP FUNCTION IF EXISTS notify_comment_event();
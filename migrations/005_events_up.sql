@@ -0,0 +1,28 @@
+-- Лента изменений комментариев для рассылки между экземплярами сервиса
+CREATE TABLE comment_events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(16) NOT NULL,
+    thread_key VARCHAR(255) NOT NULL,
+    comment_id INTEGER NOT NULL,
+    parent_id INTEGER NULL,
+    path BIGINT[] NOT NULL DEFAULT '{}',
+    payload JSONB NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_comment_events_created_at ON comment_events(created_at);
+
+-- В уведомлении передается только id: полезная нагрузка NOTIFY ограничена 8000 байт,
+-- а по id слушатель дочитывает событие и все пропущенные до него из таблицы
+CREATE FUNCTION notify_comment_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('comment_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_comment_events_notify AFTER INSERT ON comment_events
+    FOR EACH ROW EXECUTE FUNCTION notify_comment_event();
+
+GRANT ALL PRIVILEGES ON TABLE comment_events TO comment_tree_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO comment_tree_user;
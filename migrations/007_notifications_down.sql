@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notifications_unsent;
+DROP INDEX IF EXISTS idx_notifications_unread;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,18 @@
+CREATE TABLE notifications (
+    id SERIAL PRIMARY KEY,
+    recipient VARCHAR(255) NOT NULL,
+    type VARCHAR(32) NOT NULL DEFAULT 'reply',
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    parent_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    actor VARCHAR(255) NOT NULL,
+    read_at TIMESTAMP NULL,
+    sent_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (comment_id, recipient)
+);
+
+CREATE INDEX idx_notifications_unread ON notifications(recipient, id) WHERE read_at IS NULL;
+CREATE INDEX idx_notifications_unsent ON notifications(id) WHERE sent_at IS NULL;
+
+GRANT ALL PRIVILEGES ON TABLE notifications TO comment_tree_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO comment_tree_user;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_notifications_unread;
+DO $$
+BEGIN
+    IF to_regclass('notifications') IS NOT NULL THEN
+        DELETE FROM notifications WHERE parent_id IS NULL;
+        ALTER TABLE notifications ALTER COLUMN parent_id SET NOT NULL;
+        CREATE INDEX idx_notifications_unread ON notifications(recipient, id) WHERE read_at IS NULL;
+    END IF;
+END $$;
+DROP INDEX IF EXISTS idx_comment_mentions_username;
+DROP TABLE IF EXISTS comment_mentions;
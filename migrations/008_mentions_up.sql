@@ -0,0 +1,15 @@
+CREATE TABLE comment_mentions (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    username VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (comment_id, username)
+);
+
+CREATE INDEX idx_comment_mentions_username ON comment_mentions(username, comment_id);
+
+-- Упоминания не привязаны к родителю, а имена в них сравниваются без учета регистра
+ALTER TABLE notifications ALTER COLUMN parent_id DROP NOT NULL;
+DROP INDEX IF EXISTS idx_notifications_unread;
+CREATE INDEX idx_notifications_unread ON notifications(lower(recipient), id) WHERE read_at IS NULL;
+
+GRANT ALL PRIVILEGES ON TABLE comment_mentions TO comment_tree_user;
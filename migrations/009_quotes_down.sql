@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_comment_quotes_quoted_id;
+DROP TABLE IF EXISTS comment_quotes;
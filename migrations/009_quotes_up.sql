@@ -0,0 +1,10 @@
+CREATE TABLE comment_quotes (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    quoted_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    PRIMARY KEY (comment_id, quoted_id)
+);
+
+CREATE INDEX idx_comment_quotes_quoted_id ON comment_quotes(quoted_id);
+
+GRANT ALL PRIVILEGES ON TABLE comment_quotes TO comment_tree_user;
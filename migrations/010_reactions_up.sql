@@ -0,0 +1,9 @@
+CREATE TABLE comment_reactions (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    username VARCHAR(255) NOT NULL,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (comment_id, emoji, username)
+);
+
+GRANT ALL PRIVILEGES ON TABLE comment_reactions TO comment_tree_user;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_comments_score;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS score;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS downvotes;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS upvotes;
+DROP TABLE IF EXISTS comment_votes;
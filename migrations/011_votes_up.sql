@@ -0,0 +1,18 @@
+CREATE TABLE comment_votes (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    username VARCHAR(255) NOT NULL,
+    value SMALLINT NOT NULL CHECK (value IN (-1, 1)),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (comment_id, username)
+);
+
+-- Счетчики хранятся в comments, чтобы сортировать без агрегации голосов
+ALTER TABLE comments
+    ADD COLUMN upvotes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN downvotes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN score INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_comments_score ON comments(parent_id, score DESC);
+
+GRANT ALL PRIVILEGES ON TABLE comment_votes TO comment_tree_user;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_comments_pinned;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS pinned_at;
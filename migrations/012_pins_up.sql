@@ -0,0 +1,4 @@
+ALTER TABLE comments ADD COLUMN pinned_at TIMESTAMP NULL;
+
+-- Для проверки лимита закрепленных в треде
+CREATE INDEX idx_comments_pinned ON comments(thread_key) WHERE pinned_at IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS accepted_comment_id;
@@ -0,0 +1 @@
+ALTER TABLE comments ADD COLUMN accepted_comment_id INTEGER NULL REFERENCES comments(id) ON DELETE SET NULL;
@@ -0,0 +1,17 @@
+-- Куда переехали комментарии треда: comment_id IS NULL - тред целиком объединен с to_key,
+-- иначе в to_key выделена ветка comment_id
+CREATE TABLE thread_redirects (
+    id SERIAL PRIMARY KEY,
+    from_key VARCHAR(255) NOT NULL,
+    to_key VARCHAR(255) NOT NULL REFERENCES threads(key),
+    comment_id INTEGER NULL REFERENCES comments(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Тред объединяется только один раз
+CREATE UNIQUE INDEX idx_thread_redirects_merged ON thread_redirects(from_key) WHERE comment_id IS NULL;
+CREATE INDEX idx_thread_redirects_from_key ON thread_redirects(from_key);
+CREATE INDEX idx_thread_redirects_to_key ON thread_redirects(to_key);
+
+GRANT ALL PRIVILEGES ON TABLE thread_redirects TO comment_tree_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO comment_tree_user;
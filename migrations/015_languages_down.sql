@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_comments_search_vector;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS language;
+DROP FUNCTION IF EXISTS comment_search_config(TEXT);
+
+CREATE INDEX IF NOT EXISTS idx_comments_content_gin ON comments USING gin(to_tsvector('russian', content));
@@ -0,0 +1,17 @@
+-- Конфигурация полнотекстового поиска для языка комментария; у казахского нет стеммера
+CREATE OR REPLACE FUNCTION comment_search_config(lang TEXT) RETURNS regconfig AS $$
+    SELECT CASE lang
+        WHEN 'en' THEN 'english'::regconfig
+        WHEN 'kk' THEN 'simple'::regconfig
+        ELSE 'russian'::regconfig
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Существующие комментарии индексировались русской конфигурацией
+ALTER TABLE comments ADD COLUMN language VARCHAR(8) NOT NULL DEFAULT 'ru'
+    CHECK (language IN ('ru', 'en', 'kk'));
+ALTER TABLE comments ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector(comment_search_config(language), content)) STORED;
+
+DROP INDEX IF EXISTS idx_comments_content_gin;
+CREATE INDEX idx_comments_search_vector ON comments USING gin(search_vector);
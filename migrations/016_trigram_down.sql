@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_comments_author_trgm;
+DROP INDEX IF EXISTS idx_comments_content_trgm;
+DROP EXTENSION IF EXISTS pg_trgm;
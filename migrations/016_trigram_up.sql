@@ -0,0 +1,5 @@
+-- Нечеткий поиск по триграммам: похожие слова в тексте и похожие имена авторов
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_comments_content_trgm ON comments USING gin(content gin_trgm_ops);
+CREATE INDEX idx_comments_author_trgm ON comments USING gin(author gin_trgm_ops);
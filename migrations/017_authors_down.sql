@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS trg_comments_count_authors_update ON comments;
+DROP TRIGGER IF EXISTS trg_comments_count_authors ON comments;
+DROP FUNCTION IF EXISTS count_author_comments();
+DROP INDEX IF EXISTS idx_comments_author_id_created_at;
+ALTER TABLE IF EXISTS comments DROP COLUMN IF EXISTS author_id;
+DROP TABLE IF EXISTS authors;
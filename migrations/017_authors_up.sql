@@ -0,0 +1,49 @@
+-- Профили авторов. comments.author остается именем пользователя для прав и упоминаний,
+-- а отображаемое имя берется из authors при чтении, поэтому его смена не переписывает комментарии
+CREATE TABLE authors (
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    display_name VARCHAR(255) NOT NULL,
+    avatar_url TEXT NOT NULL DEFAULT '',
+    comment_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO authors (username, display_name, created_at)
+SELECT author, author, MIN(created_at) FROM comments GROUP BY author;
+
+ALTER TABLE comments ADD COLUMN author_id BIGINT REFERENCES authors(id);
+UPDATE comments c SET author_id = a.id FROM authors a WHERE a.username = c.author;
+ALTER TABLE comments ALTER COLUMN author_id SET NOT NULL;
+
+CREATE INDEX idx_comments_author_id_created_at ON comments(author_id, created_at DESC, id DESC);
+
+-- comment_count - число видимых (одобренных и не удаленных) комментариев автора
+UPDATE authors a SET comment_count = (
+    SELECT COUNT(*) FROM comments c
+    WHERE c.author_id = a.id AND c.status = 'approved' AND c.deleted_at IS NULL
+);
+
+CREATE FUNCTION count_author_comments() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.status = 'approved' AND OLD.deleted_at IS NULL THEN
+        UPDATE authors SET comment_count = comment_count - 1 WHERE id = OLD.author_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.status = 'approved' AND NEW.deleted_at IS NULL THEN
+        UPDATE authors SET comment_count = comment_count + 1 WHERE id = NEW.author_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_comments_count_authors AFTER INSERT OR DELETE ON comments
+    FOR EACH ROW EXECUTE FUNCTION count_author_comments();
+
+CREATE TRIGGER trg_comments_count_authors_update AFTER UPDATE OF status, deleted_at ON comments
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status OR OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+    EXECUTE FUNCTION count_author_comments();
+
+GRANT ALL PRIVILEGES ON TABLE authors TO comment_tree_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO comment_tree_user;
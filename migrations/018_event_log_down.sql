@@ -0,0 +1,42 @@
+DROP TRIGGER IF EXISTS trg_outbox_notify ON outbox;
+DROP INDEX IF EXISTS idx_outbox_created_at;
+DROP INDEX IF EXISTS idx_outbox_undispatched;
+
+-- Файл может выполняться и до 018_up (initdb выполняет все файлы по порядку имен), когда колонок еще нет
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'outbox' AND column_name = 'dispatched_at'
+    ) THEN
+        DELETE FROM outbox WHERE event_type IS NULL OR dispatched_at IS NOT NULL;
+    END IF;
+END $$;
+ALTER TABLE IF EXISTS outbox DROP COLUMN IF EXISTS dispatched_at;
+ALTER TABLE IF EXISTS outbox DROP COLUMN IF EXISTS path;
+ALTER TABLE IF EXISTS outbox DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE IF EXISTS outbox DROP COLUMN IF EXISTS thread_key;
+ALTER TABLE IF EXISTS outbox DROP COLUMN IF EXISTS live_type;
+ALTER TABLE IF EXISTS outbox ALTER COLUMN payload SET NOT NULL;
+ALTER TABLE IF EXISTS outbox ALTER COLUMN comment_id SET NOT NULL;
+ALTER TABLE IF EXISTS outbox ALTER COLUMN event_type SET NOT NULL;
+
+CREATE TABLE IF NOT EXISTS comment_events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(16) NOT NULL,
+    thread_key VARCHAR(255) NOT NULL,
+    comment_id INTEGER NOT NULL,
+    parent_id INTEGER NULL,
+    path BIGINT[] NOT NULL DEFAULT '{}',
+    payload JSONB NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_events_created_at ON comment_events(created_at);
+
+DROP TRIGGER IF EXISTS trg_comment_events_notify ON comment_events;
+CREATE TRIGGER trg_comment_events_notify AFTER INSERT ON comment_events
+    FOR EACH ROW EXECUTE FUNCTION notify_comment_event();
+
+GRANT ALL PRIVILEGES ON TABLE comment_events TO comment_tree_user;
+GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO comment_tree_user;
@@ -0,0 +1,19 @@
+-- Единая транзакционная лента событий: строка outbox пишется в одной транзакции с изменением
+-- и несет событие для вебхуков (event_type) и/или для живых обновлений SSE и WebSocket (live_type)
+DROP TABLE comment_events;
+
+ALTER TABLE outbox ALTER COLUMN event_type DROP NOT NULL;
+ALTER TABLE outbox ALTER COLUMN comment_id DROP NOT NULL;
+ALTER TABLE outbox ALTER COLUMN payload DROP NOT NULL;
+ALTER TABLE outbox ADD COLUMN live_type VARCHAR(16) NULL;
+ALTER TABLE outbox ADD COLUMN thread_key VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE outbox ADD COLUMN parent_id INTEGER NULL;
+ALTER TABLE outbox ADD COLUMN path BIGINT[] NOT NULL DEFAULT '{}';
+-- Строки не удаляются при разборе в доставки: их дочитывают слушатели живых событий
+ALTER TABLE outbox ADD COLUMN dispatched_at TIMESTAMP NULL;
+
+CREATE INDEX idx_outbox_undispatched ON outbox(id) WHERE event_type IS NOT NULL AND dispatched_at IS NULL;
+CREATE INDEX idx_outbox_created_at ON outbox(created_at);
+
+CREATE TRIGGER trg_outbox_notify AFTER INSERT ON outbox
+    FOR EACH ROW EXECUTE FUNCTION notify_comment_event();
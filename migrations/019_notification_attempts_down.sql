@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_notifications_unsent;
+CREATE INDEX idx_notifications_unsent ON notifications(id) WHERE sent_at IS NULL;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,5 @@
+ALTER TABLE notifications ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE notifications ADD COLUMN next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+DROP INDEX IF EXISTS idx_notifications_unsent;
+CREATE INDEX idx_notifications_unsent ON notifications(next_attempt_at) WHERE sent_at IS NULL;
@@ -0,0 +1,11 @@
+-- Файл может выполняться и до 020_up (initdb выполняет все файлы по порядку имен), когда колонки еще нет
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'outbox' AND column_name = 'author_id'
+    ) THEN
+        DELETE FROM outbox WHERE author_id IS NOT NULL;
+    END IF;
+END $$;
+ALTER TABLE IF EXISTS outbox DROP COLUMN IF EXISTS author_id;
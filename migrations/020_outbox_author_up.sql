@@ -0,0 +1,2 @@
+-- Изменение профиля автора пишется в outbox, чтобы поисковый индекс обновил его комментарии
+ALTER TABLE outbox ADD COLUMN author_id BIGINT NULL;
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS comment_mentions DROP COLUMN IF EXISTS offsets;
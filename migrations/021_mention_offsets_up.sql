@@ -0,0 +1,2 @@
+-- Позиции упоминаний в тексте (в символах) сохраняются вместе с именем, чтобы не разбирать текст при чтении
+ALTER TABLE comment_mentions ADD COLUMN offsets INTEGER[] NOT NULL DEFAULT '{}';
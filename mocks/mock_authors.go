@@ -0,0 +1,204 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// Authors is an autogenerated mock type for the Authors type
+type Authors struct {
+	mock.Mock
+}
+
+type Authors_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Authors) EXPECT() *Authors_Expecter {
+	return &Authors_Expecter{mock: &_m.Mock}
+}
+
+// GetAuthor provides a mock function with given fields: ctx, id
+func (_m *Authors) GetAuthor(ctx context.Context, id int64) (*models.Author, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthor")
+	}
+
+	var r0 *models.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Author, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Author); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authors_GetAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthor'
+type Authors_GetAuthor_Call struct {
+	*mock.Call
+}
+
+// GetAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Authors_Expecter) GetAuthor(ctx interface{}, id interface{}) *Authors_GetAuthor_Call {
+	return &Authors_GetAuthor_Call{Call: _e.mock.On("GetAuthor", ctx, id)}
+}
+
+func (_c *Authors_GetAuthor_Call) Run(run func(ctx context.Context, id int64)) *Authors_GetAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Authors_GetAuthor_Call) Return(_a0 *models.Author, _a1 error) *Authors_GetAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Authors_GetAuthor_Call) RunAndReturn(run func(context.Context, int64) (*models.Author, error)) *Authors_GetAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorComments provides a mock function with given fields: ctx, id, pag
+func (_m *Authors) GetAuthorComments(ctx context.Context, id int64, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, id, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorComments")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, id, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, id, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.PagParam) error); ok {
+		r1 = rf(ctx, id, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authors_GetAuthorComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorComments'
+type Authors_GetAuthorComments_Call struct {
+	*mock.Call
+}
+
+// GetAuthorComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - pag *models.PagParam
+func (_e *Authors_Expecter) GetAuthorComments(ctx interface{}, id interface{}, pag interface{}) *Authors_GetAuthorComments_Call {
+	return &Authors_GetAuthorComments_Call{Call: _e.mock.On("GetAuthorComments", ctx, id, pag)}
+}
+
+func (_c *Authors_GetAuthorComments_Call) Run(run func(ctx context.Context, id int64, pag *models.PagParam)) *Authors_GetAuthorComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Authors_GetAuthorComments_Call) Return(_a0 *models.CommentsRes, _a1 error) *Authors_GetAuthorComments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Authors_GetAuthorComments_Call) RunAndReturn(run func(context.Context, int64, *models.PagParam) (*models.CommentsRes, error)) *Authors_GetAuthorComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAuthor provides a mock function with given fields: ctx, author, actor
+func (_m *Authors) UpdateAuthor(ctx context.Context, author *models.Author, actor *models.Actor) error {
+	ret := _m.Called(ctx, author, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAuthor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Author, *models.Actor) error); ok {
+		r0 = rf(ctx, author, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Authors_UpdateAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAuthor'
+type Authors_UpdateAuthor_Call struct {
+	*mock.Call
+}
+
+// UpdateAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - author *models.Author
+//   - actor *models.Actor
+func (_e *Authors_Expecter) UpdateAuthor(ctx interface{}, author interface{}, actor interface{}) *Authors_UpdateAuthor_Call {
+	return &Authors_UpdateAuthor_Call{Call: _e.mock.On("UpdateAuthor", ctx, author, actor)}
+}
+
+func (_c *Authors_UpdateAuthor_Call) Run(run func(ctx context.Context, author *models.Author, actor *models.Actor)) *Authors_UpdateAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Author), args[2].(*models.Actor))
+	})
+	return _c
+}
+
+func (_c *Authors_UpdateAuthor_Call) Return(_a0 error) *Authors_UpdateAuthor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Authors_UpdateAuthor_Call) RunAndReturn(run func(context.Context, *models.Author, *models.Actor) error) *Authors_UpdateAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthors creates a new instance of Authors. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthors(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Authors {
+	mock := &Authors{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
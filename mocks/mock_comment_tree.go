@@ -0,0 +1,666 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// CommentTree is an autogenerated mock type for the CommentTree type
+type CommentTree struct {
+	mock.Mock
+}
+
+type CommentTree_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CommentTree) EXPECT() *CommentTree_Expecter {
+	return &CommentTree_Expecter{mock: &_m.Mock}
+}
+
+// AcceptAnswer provides a mock function with given fields: ctx, id, actor
+func (_m *CommentTree) AcceptAnswer(ctx context.Context, id int64, actor *models.Actor) (*models.Comment, error) {
+	ret := _m.Called(ctx, id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptAnswer")
+	}
+
+	var r0 *models.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor) (*models.Comment, error)); ok {
+		return rf(ctx, id, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor) *models.Comment); ok {
+		r0 = rf(ctx, id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.Actor) error); ok {
+		r1 = rf(ctx, id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentTree_AcceptAnswer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptAnswer'
+type CommentTree_AcceptAnswer_Call struct {
+	*mock.Call
+}
+
+// AcceptAnswer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - actor *models.Actor
+func (_e *CommentTree_Expecter) AcceptAnswer(ctx interface{}, id interface{}, actor interface{}) *CommentTree_AcceptAnswer_Call {
+	return &CommentTree_AcceptAnswer_Call{Call: _e.mock.On("AcceptAnswer", ctx, id, actor)}
+}
+
+func (_c *CommentTree_AcceptAnswer_Call) Run(run func(ctx context.Context, id int64, actor *models.Actor)) *CommentTree_AcceptAnswer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.Actor))
+	})
+	return _c
+}
+
+func (_c *CommentTree_AcceptAnswer_Call) Return(_a0 *models.Comment, _a1 error) *CommentTree_AcceptAnswer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentTree_AcceptAnswer_Call) RunAndReturn(run func(context.Context, int64, *models.Actor) (*models.Comment, error)) *CommentTree_AcceptAnswer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteComment provides a mock function with given fields: ctx, id, actor, reason
+func (_m *CommentTree) DeleteComment(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	ret := _m.Called(ctx, id, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor, string) error); ok {
+		r0 = rf(ctx, id, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommentTree_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
+type CommentTree_DeleteComment_Call struct {
+	*mock.Call
+}
+
+// DeleteComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - actor *models.Actor
+//   - reason string
+func (_e *CommentTree_Expecter) DeleteComment(ctx interface{}, id interface{}, actor interface{}, reason interface{}) *CommentTree_DeleteComment_Call {
+	return &CommentTree_DeleteComment_Call{Call: _e.mock.On("DeleteComment", ctx, id, actor, reason)}
+}
+
+func (_c *CommentTree_DeleteComment_Call) Run(run func(ctx context.Context, id int64, actor *models.Actor, reason string)) *CommentTree_DeleteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.Actor), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *CommentTree_DeleteComment_Call) Return(_a0 error) *CommentTree_DeleteComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommentTree_DeleteComment_Call) RunAndReturn(run func(context.Context, int64, *models.Actor, string) error) *CommentTree_DeleteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditComment provides a mock function with given fields: ctx, id, content, actor, reason
+func (_m *CommentTree) EditComment(ctx context.Context, id int64, content string, actor *models.Actor, reason string) (*models.Comment, error) {
+	ret := _m.Called(ctx, id, content, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditComment")
+	}
+
+	var r0 *models.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *models.Actor, string) (*models.Comment, error)); ok {
+		return rf(ctx, id, content, actor, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *models.Actor, string) *models.Comment); ok {
+		r0 = rf(ctx, id, content, actor, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, *models.Actor, string) error); ok {
+		r1 = rf(ctx, id, content, actor, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentTree_EditComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditComment'
+type CommentTree_EditComment_Call struct {
+	*mock.Call
+}
+
+// EditComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - content string
+//   - actor *models.Actor
+//   - reason string
+func (_e *CommentTree_Expecter) EditComment(ctx interface{}, id interface{}, content interface{}, actor interface{}, reason interface{}) *CommentTree_EditComment_Call {
+	return &CommentTree_EditComment_Call{Call: _e.mock.On("EditComment", ctx, id, content, actor, reason)}
+}
+
+func (_c *CommentTree_EditComment_Call) Run(run func(ctx context.Context, id int64, content string, actor *models.Actor, reason string)) *CommentTree_EditComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(*models.Actor), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *CommentTree_EditComment_Call) Return(_a0 *models.Comment, _a1 error) *CommentTree_EditComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentTree_EditComment_Call) RunAndReturn(run func(context.Context, int64, string, *models.Actor, string) (*models.Comment, error)) *CommentTree_EditComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetComments provides a mock function with given fields: ctx, parentID, pag
+func (_m *CommentTree) GetComments(ctx context.Context, parentID int64, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, parentID, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComments")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, parentID, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, parentID, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.PagParam) error); ok {
+		r1 = rf(ctx, parentID, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentTree_GetComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetComments'
+type CommentTree_GetComments_Call struct {
+	*mock.Call
+}
+
+// GetComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentID int64
+//   - pag *models.PagParam
+func (_e *CommentTree_Expecter) GetComments(ctx interface{}, parentID interface{}, pag interface{}) *CommentTree_GetComments_Call {
+	return &CommentTree_GetComments_Call{Call: _e.mock.On("GetComments", ctx, parentID, pag)}
+}
+
+func (_c *CommentTree_GetComments_Call) Run(run func(ctx context.Context, parentID int64, pag *models.PagParam)) *CommentTree_GetComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *CommentTree_GetComments_Call) Return(_a0 *models.CommentsRes, _a1 error) *CommentTree_GetComments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentTree_GetComments_Call) RunAndReturn(run func(context.Context, int64, *models.PagParam) (*models.CommentsRes, error)) *CommentTree_GetComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMentions provides a mock function with given fields: ctx, username, pag
+func (_m *CommentTree) GetMentions(ctx context.Context, username string, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, username, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMentions")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, username, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, username, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PagParam) error); ok {
+		r1 = rf(ctx, username, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentTree_GetMentions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMentions'
+type CommentTree_GetMentions_Call struct {
+	*mock.Call
+}
+
+// GetMentions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - pag *models.PagParam
+func (_e *CommentTree_Expecter) GetMentions(ctx interface{}, username interface{}, pag interface{}) *CommentTree_GetMentions_Call {
+	return &CommentTree_GetMentions_Call{Call: _e.mock.On("GetMentions", ctx, username, pag)}
+}
+
+func (_c *CommentTree_GetMentions_Call) Run(run func(ctx context.Context, username string, pag *models.PagParam)) *CommentTree_GetMentions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *CommentTree_GetMentions_Call) Return(_a0 *models.CommentsRes, _a1 error) *CommentTree_GetMentions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentTree_GetMentions_Call) RunAndReturn(run func(context.Context, string, *models.PagParam) (*models.CommentsRes, error)) *CommentTree_GetMentions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRootComments provides a mock function with given fields: ctx, pag
+func (_m *CommentTree) GetRootComments(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRootComments")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PagParam) error); ok {
+		r1 = rf(ctx, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentTree_GetRootComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRootComments'
+type CommentTree_GetRootComments_Call struct {
+	*mock.Call
+}
+
+// GetRootComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pag *models.PagParam
+func (_e *CommentTree_Expecter) GetRootComments(ctx interface{}, pag interface{}) *CommentTree_GetRootComments_Call {
+	return &CommentTree_GetRootComments_Call{Call: _e.mock.On("GetRootComments", ctx, pag)}
+}
+
+func (_c *CommentTree_GetRootComments_Call) Run(run func(ctx context.Context, pag *models.PagParam)) *CommentTree_GetRootComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *CommentTree_GetRootComments_Call) Return(_a0 *models.CommentsRes, _a1 error) *CommentTree_GetRootComments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentTree_GetRootComments_Call) RunAndReturn(run func(context.Context, *models.PagParam) (*models.CommentsRes, error)) *CommentTree_GetRootComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveComment provides a mock function with given fields: ctx, id, parentID, actor, reason
+func (_m *CommentTree) MoveComment(ctx context.Context, id int64, parentID *int64, actor *models.Actor, reason string) (*models.Comment, error) {
+	ret := _m.Called(ctx, id, parentID, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveComment")
+	}
+
+	var r0 *models.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64, *models.Actor, string) (*models.Comment, error)); ok {
+		return rf(ctx, id, parentID, actor, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64, *models.Actor, string) *models.Comment); ok {
+		r0 = rf(ctx, id, parentID, actor, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *int64, *models.Actor, string) error); ok {
+		r1 = rf(ctx, id, parentID, actor, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentTree_MoveComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveComment'
+type CommentTree_MoveComment_Call struct {
+	*mock.Call
+}
+
+// MoveComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - parentID *int64
+//   - actor *models.Actor
+//   - reason string
+func (_e *CommentTree_Expecter) MoveComment(ctx interface{}, id interface{}, parentID interface{}, actor interface{}, reason interface{}) *CommentTree_MoveComment_Call {
+	return &CommentTree_MoveComment_Call{Call: _e.mock.On("MoveComment", ctx, id, parentID, actor, reason)}
+}
+
+func (_c *CommentTree_MoveComment_Call) Run(run func(ctx context.Context, id int64, parentID *int64, actor *models.Actor, reason string)) *CommentTree_MoveComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*int64), args[3].(*models.Actor), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *CommentTree_MoveComment_Call) Return(_a0 *models.Comment, _a1 error) *CommentTree_MoveComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentTree_MoveComment_Call) RunAndReturn(run func(context.Context, int64, *int64, *models.Actor, string) (*models.Comment, error)) *CommentTree_MoveComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreComment provides a mock function with given fields: ctx, id, actor, reason
+func (_m *CommentTree) RestoreComment(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	ret := _m.Called(ctx, id, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor, string) error); ok {
+		r0 = rf(ctx, id, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommentTree_RestoreComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreComment'
+type CommentTree_RestoreComment_Call struct {
+	*mock.Call
+}
+
+// RestoreComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - actor *models.Actor
+//   - reason string
+func (_e *CommentTree_Expecter) RestoreComment(ctx interface{}, id interface{}, actor interface{}, reason interface{}) *CommentTree_RestoreComment_Call {
+	return &CommentTree_RestoreComment_Call{Call: _e.mock.On("RestoreComment", ctx, id, actor, reason)}
+}
+
+func (_c *CommentTree_RestoreComment_Call) Run(run func(ctx context.Context, id int64, actor *models.Actor, reason string)) *CommentTree_RestoreComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.Actor), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *CommentTree_RestoreComment_Call) Return(_a0 error) *CommentTree_RestoreComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommentTree_RestoreComment_Call) RunAndReturn(run func(context.Context, int64, *models.Actor, string) error) *CommentTree_RestoreComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnacceptAnswer provides a mock function with given fields: ctx, id, actor
+func (_m *CommentTree) UnacceptAnswer(ctx context.Context, id int64, actor *models.Actor) (*models.Comment, error) {
+	ret := _m.Called(ctx, id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnacceptAnswer")
+	}
+
+	var r0 *models.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor) (*models.Comment, error)); ok {
+		return rf(ctx, id, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor) *models.Comment); ok {
+		r0 = rf(ctx, id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.Actor) error); ok {
+		r1 = rf(ctx, id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentTree_UnacceptAnswer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnacceptAnswer'
+type CommentTree_UnacceptAnswer_Call struct {
+	*mock.Call
+}
+
+// UnacceptAnswer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - actor *models.Actor
+func (_e *CommentTree_Expecter) UnacceptAnswer(ctx interface{}, id interface{}, actor interface{}) *CommentTree_UnacceptAnswer_Call {
+	return &CommentTree_UnacceptAnswer_Call{Call: _e.mock.On("UnacceptAnswer", ctx, id, actor)}
+}
+
+func (_c *CommentTree_UnacceptAnswer_Call) Run(run func(ctx context.Context, id int64, actor *models.Actor)) *CommentTree_UnacceptAnswer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.Actor))
+	})
+	return _c
+}
+
+func (_c *CommentTree_UnacceptAnswer_Call) Return(_a0 *models.Comment, _a1 error) *CommentTree_UnacceptAnswer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentTree_UnacceptAnswer_Call) RunAndReturn(run func(context.Context, int64, *models.Actor) (*models.Comment, error)) *CommentTree_UnacceptAnswer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Vote provides a mock function with given fields: ctx, id, user, value
+func (_m *CommentTree) Vote(ctx context.Context, id int64, user string, value int) (*models.VoteResult, error) {
+	ret := _m.Called(ctx, id, user, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vote")
+	}
+
+	var r0 *models.VoteResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int) (*models.VoteResult, error)); ok {
+		return rf(ctx, id, user, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int) *models.VoteResult); ok {
+		r0 = rf(ctx, id, user, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VoteResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int) error); ok {
+		r1 = rf(ctx, id, user, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentTree_Vote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Vote'
+type CommentTree_Vote_Call struct {
+	*mock.Call
+}
+
+// Vote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - user string
+//   - value int
+func (_e *CommentTree_Expecter) Vote(ctx interface{}, id interface{}, user interface{}, value interface{}) *CommentTree_Vote_Call {
+	return &CommentTree_Vote_Call{Call: _e.mock.On("Vote", ctx, id, user, value)}
+}
+
+func (_c *CommentTree_Vote_Call) Run(run func(ctx context.Context, id int64, user string, value int)) *CommentTree_Vote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *CommentTree_Vote_Call) Return(_a0 *models.VoteResult, _a1 error) *CommentTree_Vote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentTree_Vote_Call) RunAndReturn(run func(context.Context, int64, string, int) (*models.VoteResult, error)) *CommentTree_Vote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteComment provides a mock function with given fields: ctx, comment
+func (_m *CommentTree) WriteComment(ctx context.Context, comment *models.Comment) error {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommentTree_WriteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteComment'
+type CommentTree_WriteComment_Call struct {
+	*mock.Call
+}
+
+// WriteComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *models.Comment
+func (_e *CommentTree_Expecter) WriteComment(ctx interface{}, comment interface{}) *CommentTree_WriteComment_Call {
+	return &CommentTree_WriteComment_Call{Call: _e.mock.On("WriteComment", ctx, comment)}
+}
+
+func (_c *CommentTree_WriteComment_Call) Run(run func(ctx context.Context, comment *models.Comment)) *CommentTree_WriteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Comment))
+	})
+	return _c
+}
+
+func (_c *CommentTree_WriteComment_Call) Return(_a0 error) *CommentTree_WriteComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommentTree_WriteComment_Call) RunAndReturn(run func(context.Context, *models.Comment) error) *CommentTree_WriteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCommentTree creates a new instance of CommentTree. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommentTree(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommentTree {
+	mock := &CommentTree{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
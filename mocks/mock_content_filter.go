@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// ContentFilter is an autogenerated mock type for the ContentFilter type
+type ContentFilter struct {
+	mock.Mock
+}
+
+type ContentFilter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ContentFilter) EXPECT() *ContentFilter_Expecter {
+	return &ContentFilter_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx, comment
+func (_m *ContentFilter) Check(ctx context.Context, comment *models.Comment) (models.FilterResult, error) {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 models.FilterResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment) (models.FilterResult, error)); ok {
+		return rf(ctx, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment) models.FilterResult); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Get(0).(models.FilterResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Comment) error); ok {
+		r1 = rf(ctx, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentFilter_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type ContentFilter_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *models.Comment
+func (_e *ContentFilter_Expecter) Check(ctx interface{}, comment interface{}) *ContentFilter_Check_Call {
+	return &ContentFilter_Check_Call{Call: _e.mock.On("Check", ctx, comment)}
+}
+
+func (_c *ContentFilter_Check_Call) Run(run func(ctx context.Context, comment *models.Comment)) *ContentFilter_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Comment))
+	})
+	return _c
+}
+
+func (_c *ContentFilter_Check_Call) Return(_a0 models.FilterResult, _a1 error) *ContentFilter_Check_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentFilter_Check_Call) RunAndReturn(run func(context.Context, *models.Comment) (models.FilterResult, error)) *ContentFilter_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewContentFilter creates a new instance of ContentFilter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContentFilter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContentFilter {
+	mock := &ContentFilter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
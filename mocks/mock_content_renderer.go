@@ -0,0 +1,81 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// ContentRenderer is an autogenerated mock type for the ContentRenderer type
+type ContentRenderer struct {
+	mock.Mock
+}
+
+type ContentRenderer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ContentRenderer) EXPECT() *ContentRenderer_Expecter {
+	return &ContentRenderer_Expecter{mock: &_m.Mock}
+}
+
+// Render provides a mock function with given fields: comment
+func (_m *ContentRenderer) Render(comment *models.Comment) string {
+	ret := _m.Called(comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Render")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*models.Comment) string); ok {
+		r0 = rf(comment)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ContentRenderer_Render_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Render'
+type ContentRenderer_Render_Call struct {
+	*mock.Call
+}
+
+// Render is a helper method to define mock.On call
+//   - comment *models.Comment
+func (_e *ContentRenderer_Expecter) Render(comment interface{}) *ContentRenderer_Render_Call {
+	return &ContentRenderer_Render_Call{Call: _e.mock.On("Render", comment)}
+}
+
+func (_c *ContentRenderer_Render_Call) Run(run func(comment *models.Comment)) *ContentRenderer_Render_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Comment))
+	})
+	return _c
+}
+
+func (_c *ContentRenderer_Render_Call) Return(_a0 string) *ContentRenderer_Render_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContentRenderer_Render_Call) RunAndReturn(run func(*models.Comment) string) *ContentRenderer_Render_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewContentRenderer creates a new instance of ContentRenderer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContentRenderer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContentRenderer {
+	mock := &ContentRenderer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,3073 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sunr3d/comment-tree/models"
+
+	time "time"
+)
+
+// Database is an autogenerated mock type for the Database type
+type Database struct {
+	mock.Mock
+}
+
+type Database_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Database) EXPECT() *Database_Expecter {
+	return &Database_Expecter{mock: &_m.Mock}
+}
+
+// AddNotification provides a mock function with given fields: ctx, n
+func (_m *Database) AddNotification(ctx context.Context, n *models.Notification) error {
+	ret := _m.Called(ctx, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Notification) error); ok {
+		r0 = rf(ctx, n)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_AddNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddNotification'
+type Database_AddNotification_Call struct {
+	*mock.Call
+}
+
+// AddNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - n *models.Notification
+func (_e *Database_Expecter) AddNotification(ctx interface{}, n interface{}) *Database_AddNotification_Call {
+	return &Database_AddNotification_Call{Call: _e.mock.On("AddNotification", ctx, n)}
+}
+
+func (_c *Database_AddNotification_Call) Run(run func(ctx context.Context, n *models.Notification)) *Database_AddNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Notification))
+	})
+	return _c
+}
+
+func (_c *Database_AddNotification_Call) Return(_a0 error) *Database_AddNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_AddNotification_Call) RunAndReturn(run func(context.Context, *models.Notification) error) *Database_AddNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddReaction provides a mock function with given fields: ctx, commentID, user, emoji
+func (_m *Database) AddReaction(ctx context.Context, commentID int64, user string, emoji string) error {
+	ret := _m.Called(ctx, commentID, user, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, commentID, user, emoji)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_AddReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReaction'
+type Database_AddReaction_Call struct {
+	*mock.Call
+}
+
+// AddReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commentID int64
+//   - user string
+//   - emoji string
+func (_e *Database_Expecter) AddReaction(ctx interface{}, commentID interface{}, user interface{}, emoji interface{}) *Database_AddReaction_Call {
+	return &Database_AddReaction_Call{Call: _e.mock.On("AddReaction", ctx, commentID, user, emoji)}
+}
+
+func (_c *Database_AddReaction_Call) Run(run func(ctx context.Context, commentID int64, user string, emoji string)) *Database_AddReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Database_AddReaction_Call) Return(_a0 error) *Database_AddReaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_AddReaction_Call) RunAndReturn(run func(context.Context, int64, string, string) error) *Database_AddReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddReport provides a mock function with given fields: ctx, report
+func (_m *Database) AddReport(ctx context.Context, report *models.Report) (int, bool, error) {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReport")
+	}
+
+	var r0 int
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Report) (int, bool, error)); ok {
+		return rf(ctx, report)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Report) int); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Report) bool); ok {
+		r1 = rf(ctx, report)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.Report) error); ok {
+		r2 = rf(ctx, report)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Database_AddReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReport'
+type Database_AddReport_Call struct {
+	*mock.Call
+}
+
+// AddReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - report *models.Report
+func (_e *Database_Expecter) AddReport(ctx interface{}, report interface{}) *Database_AddReport_Call {
+	return &Database_AddReport_Call{Call: _e.mock.On("AddReport", ctx, report)}
+}
+
+func (_c *Database_AddReport_Call) Run(run func(ctx context.Context, report *models.Report)) *Database_AddReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Report))
+	})
+	return _c
+}
+
+func (_c *Database_AddReport_Call) Return(_a0 int, _a1 bool, _a2 error) *Database_AddReport_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Database_AddReport_Call) RunAndReturn(run func(context.Context, *models.Report) (int, bool, error)) *Database_AddReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDeliveries provides a mock function with given fields: ctx, limit, lease
+func (_m *Database) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeliveries")
+	}
+
+	var r0 []models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]models.WebhookDelivery, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ClaimDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDeliveries'
+type Database_ClaimDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - lease time.Duration
+func (_e *Database_Expecter) ClaimDeliveries(ctx interface{}, limit interface{}, lease interface{}) *Database_ClaimDeliveries_Call {
+	return &Database_ClaimDeliveries_Call{Call: _e.mock.On("ClaimDeliveries", ctx, limit, lease)}
+}
+
+func (_c *Database_ClaimDeliveries_Call) Run(run func(ctx context.Context, limit int, lease time.Duration)) *Database_ClaimDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Database_ClaimDeliveries_Call) Return(_a0 []models.WebhookDelivery, _a1 error) *Database_ClaimDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ClaimDeliveries_Call) RunAndReturn(run func(context.Context, int, time.Duration) ([]models.WebhookDelivery, error)) *Database_ClaimDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimNotifications provides a mock function with given fields: ctx, recipients, limit, maxAttempts, lease
+func (_m *Database) ClaimNotifications(ctx context.Context, recipients []string, limit int, maxAttempts int, lease time.Duration) ([]models.Notification, error) {
+	ret := _m.Called(ctx, recipients, limit, maxAttempts, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimNotifications")
+	}
+
+	var r0 []models.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int, int, time.Duration) ([]models.Notification, error)); ok {
+		return rf(ctx, recipients, limit, maxAttempts, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int, int, time.Duration) []models.Notification); ok {
+		r0 = rf(ctx, recipients, limit, maxAttempts, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int, int, time.Duration) error); ok {
+		r1 = rf(ctx, recipients, limit, maxAttempts, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ClaimNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimNotifications'
+type Database_ClaimNotifications_Call struct {
+	*mock.Call
+}
+
+// ClaimNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipients []string
+//   - limit int
+//   - maxAttempts int
+//   - lease time.Duration
+func (_e *Database_Expecter) ClaimNotifications(ctx interface{}, recipients interface{}, limit interface{}, maxAttempts interface{}, lease interface{}) *Database_ClaimNotifications_Call {
+	return &Database_ClaimNotifications_Call{Call: _e.mock.On("ClaimNotifications", ctx, recipients, limit, maxAttempts, lease)}
+}
+
+func (_c *Database_ClaimNotifications_Call) Run(run func(ctx context.Context, recipients []string, limit int, maxAttempts int, lease time.Duration)) *Database_ClaimNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(int), args[3].(int), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Database_ClaimNotifications_Call) Return(_a0 []models.Notification, _a1 error) *Database_ClaimNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ClaimNotifications_Call) RunAndReturn(run func(context.Context, []string, int, int, time.Duration) ([]models.Notification, error)) *Database_ClaimNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, comment
+func (_m *Database) Create(ctx context.Context, comment *models.Comment) error {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type Database_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *models.Comment
+func (_e *Database_Expecter) Create(ctx interface{}, comment interface{}) *Database_Create_Call {
+	return &Database_Create_Call{Call: _e.mock.On("Create", ctx, comment)}
+}
+
+func (_c *Database_Create_Call) Run(run func(ctx context.Context, comment *models.Comment)) *Database_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Comment))
+	})
+	return _c
+}
+
+func (_c *Database_Create_Call) Return(_a0 error) *Database_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_Create_Call) RunAndReturn(run func(context.Context, *models.Comment) error) *Database_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *Database) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type Database_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhook *models.Webhook
+func (_e *Database_Expecter) CreateWebhook(ctx interface{}, webhook interface{}) *Database_CreateWebhook_Call {
+	return &Database_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, webhook)}
+}
+
+func (_c *Database_CreateWebhook_Call) Run(run func(ctx context.Context, webhook *models.Webhook)) *Database_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Webhook))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWebhook_Call) Return(_a0 error) *Database_CreateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateWebhook_Call) RunAndReturn(run func(context.Context, *models.Webhook) error) *Database_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id, entry
+func (_m *Database) Delete(ctx context.Context, id int64, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, id, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, id, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type Database_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) Delete(ctx interface{}, id interface{}, entry interface{}) *Database_Delete_Call {
+	return &Database_Delete_Call{Call: _e.mock.On("Delete", ctx, id, entry)}
+}
+
+func (_c *Database_Delete_Call) Run(run func(ctx context.Context, id int64, entry *models.AuditEntry)) *Database_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_Delete_Call) Return(_a0 error) *Database_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_Delete_Call) RunAndReturn(run func(context.Context, int64, *models.AuditEntry) error) *Database_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *Database) DeleteWebhook(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type Database_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) DeleteWebhook(ctx interface{}, id interface{}) *Database_DeleteWebhook_Call {
+	return &Database_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, id)}
+}
+
+func (_c *Database_DeleteWebhook_Call) Run(run func(ctx context.Context, id int64)) *Database_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWebhook_Call) Return(_a0 error) *Database_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWebhook_Call) RunAndReturn(run func(context.Context, int64) error) *Database_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FanOutOutbox provides a mock function with given fields: ctx, limit
+func (_m *Database) FanOutOutbox(ctx context.Context, limit int) (int, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FanOutOutbox")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_FanOutOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FanOutOutbox'
+type Database_FanOutOutbox_Call struct {
+	*mock.Call
+}
+
+// FanOutOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *Database_Expecter) FanOutOutbox(ctx interface{}, limit interface{}) *Database_FanOutOutbox_Call {
+	return &Database_FanOutOutbox_Call{Call: _e.mock.On("FanOutOutbox", ctx, limit)}
+}
+
+func (_c *Database_FanOutOutbox_Call) Run(run func(ctx context.Context, limit int)) *Database_FanOutOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_FanOutOutbox_Call) Return(_a0 int, _a1 error) *Database_FanOutOutbox_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_FanOutOutbox_Call) RunAndReturn(run func(context.Context, int) (int, error)) *Database_FanOutOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAncestorIDs provides a mock function with given fields: ctx, id
+func (_m *Database) GetAncestorIDs(ctx context.Context, id int64) ([]int64, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAncestorIDs")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAncestorIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAncestorIDs'
+type Database_GetAncestorIDs_Call struct {
+	*mock.Call
+}
+
+// GetAncestorIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) GetAncestorIDs(ctx interface{}, id interface{}) *Database_GetAncestorIDs_Call {
+	return &Database_GetAncestorIDs_Call{Call: _e.mock.On("GetAncestorIDs", ctx, id)}
+}
+
+func (_c *Database_GetAncestorIDs_Call) Run(run func(ctx context.Context, id int64)) *Database_GetAncestorIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_GetAncestorIDs_Call) Return(_a0 []int64, _a1 error) *Database_GetAncestorIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAncestorIDs_Call) RunAndReturn(run func(context.Context, int64) ([]int64, error)) *Database_GetAncestorIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuditLog provides a mock function with given fields: ctx, filter
+func (_m *Database) GetAuditLog(ctx context.Context, filter *models.AuditFilter) (*models.AuditRes, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditLog")
+	}
+
+	var r0 *models.AuditRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditFilter) (*models.AuditRes, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditFilter) *models.AuditRes); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuditRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLog'
+type Database_GetAuditLog_Call struct {
+	*mock.Call
+}
+
+// GetAuditLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.AuditFilter
+func (_e *Database_Expecter) GetAuditLog(ctx interface{}, filter interface{}) *Database_GetAuditLog_Call {
+	return &Database_GetAuditLog_Call{Call: _e.mock.On("GetAuditLog", ctx, filter)}
+}
+
+func (_c *Database_GetAuditLog_Call) Run(run func(ctx context.Context, filter *models.AuditFilter)) *Database_GetAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *Database_GetAuditLog_Call) Return(_a0 *models.AuditRes, _a1 error) *Database_GetAuditLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAuditLog_Call) RunAndReturn(run func(context.Context, *models.AuditFilter) (*models.AuditRes, error)) *Database_GetAuditLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthor provides a mock function with given fields: ctx, id
+func (_m *Database) GetAuthor(ctx context.Context, id int64) (*models.Author, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthor")
+	}
+
+	var r0 *models.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Author, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Author); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthor'
+type Database_GetAuthor_Call struct {
+	*mock.Call
+}
+
+// GetAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) GetAuthor(ctx interface{}, id interface{}) *Database_GetAuthor_Call {
+	return &Database_GetAuthor_Call{Call: _e.mock.On("GetAuthor", ctx, id)}
+}
+
+func (_c *Database_GetAuthor_Call) Run(run func(ctx context.Context, id int64)) *Database_GetAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_GetAuthor_Call) Return(_a0 *models.Author, _a1 error) *Database_GetAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAuthor_Call) RunAndReturn(run func(context.Context, int64) (*models.Author, error)) *Database_GetAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorComments provides a mock function with given fields: ctx, id, pag
+func (_m *Database) GetAuthorComments(ctx context.Context, id int64, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, id, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorComments")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, id, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, id, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.PagParam) error); ok {
+		r1 = rf(ctx, id, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAuthorComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorComments'
+type Database_GetAuthorComments_Call struct {
+	*mock.Call
+}
+
+// GetAuthorComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - pag *models.PagParam
+func (_e *Database_Expecter) GetAuthorComments(ctx interface{}, id interface{}, pag interface{}) *Database_GetAuthorComments_Call {
+	return &Database_GetAuthorComments_Call{Call: _e.mock.On("GetAuthorComments", ctx, id, pag)}
+}
+
+func (_c *Database_GetAuthorComments_Call) Run(run func(ctx context.Context, id int64, pag *models.PagParam)) *Database_GetAuthorComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Database_GetAuthorComments_Call) Return(_a0 *models.CommentsRes, _a1 error) *Database_GetAuthorComments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAuthorComments_Call) RunAndReturn(run func(context.Context, int64, *models.PagParam) (*models.CommentsRes, error)) *Database_GetAuthorComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorSearchDocs provides a mock function with given fields: ctx, authorID, afterID, limit
+func (_m *Database) GetAuthorSearchDocs(ctx context.Context, authorID int64, afterID int64, limit int) ([]models.SearchDoc, error) {
+	ret := _m.Called(ctx, authorID, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorSearchDocs")
+	}
+
+	var r0 []models.SearchDoc
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int) ([]models.SearchDoc, error)); ok {
+		return rf(ctx, authorID, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int) []models.SearchDoc); ok {
+		r0 = rf(ctx, authorID, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SearchDoc)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int) error); ok {
+		r1 = rf(ctx, authorID, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAuthorSearchDocs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorSearchDocs'
+type Database_GetAuthorSearchDocs_Call struct {
+	*mock.Call
+}
+
+// GetAuthorSearchDocs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID int64
+//   - afterID int64
+//   - limit int
+func (_e *Database_Expecter) GetAuthorSearchDocs(ctx interface{}, authorID interface{}, afterID interface{}, limit interface{}) *Database_GetAuthorSearchDocs_Call {
+	return &Database_GetAuthorSearchDocs_Call{Call: _e.mock.On("GetAuthorSearchDocs", ctx, authorID, afterID, limit)}
+}
+
+func (_c *Database_GetAuthorSearchDocs_Call) Run(run func(ctx context.Context, authorID int64, afterID int64, limit int)) *Database_GetAuthorSearchDocs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetAuthorSearchDocs_Call) Return(_a0 []models.SearchDoc, _a1 error) *Database_GetAuthorSearchDocs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAuthorSearchDocs_Call) RunAndReturn(run func(context.Context, int64, int64, int) ([]models.SearchDoc, error)) *Database_GetAuthorSearchDocs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *Database) GetByID(ctx context.Context, id int64) (*models.Comment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Comment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Comment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type Database_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) GetByID(ctx interface{}, id interface{}) *Database_GetByID_Call {
+	return &Database_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *Database_GetByID_Call) Run(run func(ctx context.Context, id int64)) *Database_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_GetByID_Call) Return(_a0 *models.Comment, _a1 error) *Database_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetByID_Call) RunAndReturn(run func(context.Context, int64) (*models.Comment, error)) *Database_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByParentID provides a mock function with given fields: ctx, parentID, pag
+func (_m *Database) GetByParentID(ctx context.Context, parentID int64, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, parentID, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByParentID")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, parentID, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, parentID, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.PagParam) error); ok {
+		r1 = rf(ctx, parentID, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetByParentID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByParentID'
+type Database_GetByParentID_Call struct {
+	*mock.Call
+}
+
+// GetByParentID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentID int64
+//   - pag *models.PagParam
+func (_e *Database_Expecter) GetByParentID(ctx interface{}, parentID interface{}, pag interface{}) *Database_GetByParentID_Call {
+	return &Database_GetByParentID_Call{Call: _e.mock.On("GetByParentID", ctx, parentID, pag)}
+}
+
+func (_c *Database_GetByParentID_Call) Run(run func(ctx context.Context, parentID int64, pag *models.PagParam)) *Database_GetByParentID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Database_GetByParentID_Call) Return(_a0 *models.CommentsRes, _a1 error) *Database_GetByParentID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetByParentID_Call) RunAndReturn(run func(context.Context, int64, *models.PagParam) (*models.CommentsRes, error)) *Database_GetByParentID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, filter
+func (_m *Database) GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) (*models.DeliveriesRes, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 *models.DeliveriesRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeliveryFilter) (*models.DeliveriesRes, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeliveryFilter) *models.DeliveriesRes); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveriesRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DeliveryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveries'
+type Database_GetDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.DeliveryFilter
+func (_e *Database_Expecter) GetDeliveries(ctx interface{}, filter interface{}) *Database_GetDeliveries_Call {
+	return &Database_GetDeliveries_Call{Call: _e.mock.On("GetDeliveries", ctx, filter)}
+}
+
+func (_c *Database_GetDeliveries_Call) Run(run func(ctx context.Context, filter *models.DeliveryFilter)) *Database_GetDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.DeliveryFilter))
+	})
+	return _c
+}
+
+func (_c *Database_GetDeliveries_Call) Return(_a0 *models.DeliveriesRes, _a1 error) *Database_GetDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetDeliveries_Call) RunAndReturn(run func(context.Context, *models.DeliveryFilter) (*models.DeliveriesRes, error)) *Database_GetDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDelivery provides a mock function with given fields: ctx, id
+func (_m *Database) GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelivery")
+	}
+
+	var r0 *models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.WebhookDelivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.WebhookDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelivery'
+type Database_GetDelivery_Call struct {
+	*mock.Call
+}
+
+// GetDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) GetDelivery(ctx interface{}, id interface{}) *Database_GetDelivery_Call {
+	return &Database_GetDelivery_Call{Call: _e.mock.On("GetDelivery", ctx, id)}
+}
+
+func (_c *Database_GetDelivery_Call) Run(run func(ctx context.Context, id int64)) *Database_GetDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_GetDelivery_Call) Return(_a0 *models.WebhookDelivery, _a1 error) *Database_GetDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetDelivery_Call) RunAndReturn(run func(context.Context, int64) (*models.WebhookDelivery, error)) *Database_GetDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMentions provides a mock function with given fields: ctx, username, pag
+func (_m *Database) GetMentions(ctx context.Context, username string, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, username, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMentions")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, username, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, username, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PagParam) error); ok {
+		r1 = rf(ctx, username, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetMentions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMentions'
+type Database_GetMentions_Call struct {
+	*mock.Call
+}
+
+// GetMentions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - pag *models.PagParam
+func (_e *Database_Expecter) GetMentions(ctx interface{}, username interface{}, pag interface{}) *Database_GetMentions_Call {
+	return &Database_GetMentions_Call{Call: _e.mock.On("GetMentions", ctx, username, pag)}
+}
+
+func (_c *Database_GetMentions_Call) Run(run func(ctx context.Context, username string, pag *models.PagParam)) *Database_GetMentions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Database_GetMentions_Call) Return(_a0 *models.CommentsRes, _a1 error) *Database_GetMentions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetMentions_Call) RunAndReturn(run func(context.Context, string, *models.PagParam) (*models.CommentsRes, error)) *Database_GetMentions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPending provides a mock function with given fields: ctx, pag
+func (_m *Database) GetPending(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPending")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PagParam) error); ok {
+		r1 = rf(ctx, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPending'
+type Database_GetPending_Call struct {
+	*mock.Call
+}
+
+// GetPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pag *models.PagParam
+func (_e *Database_Expecter) GetPending(ctx interface{}, pag interface{}) *Database_GetPending_Call {
+	return &Database_GetPending_Call{Call: _e.mock.On("GetPending", ctx, pag)}
+}
+
+func (_c *Database_GetPending_Call) Run(run func(ctx context.Context, pag *models.PagParam)) *Database_GetPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Database_GetPending_Call) Return(_a0 *models.CommentsRes, _a1 error) *Database_GetPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPending_Call) RunAndReturn(run func(context.Context, *models.PagParam) (*models.CommentsRes, error)) *Database_GetPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReactions provides a mock function with given fields: ctx, ids, user
+func (_m *Database) GetReactions(ctx context.Context, ids []int64, user string) (map[int64][]models.Reaction, error) {
+	ret := _m.Called(ctx, ids, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReactions")
+	}
+
+	var r0 map[int64][]models.Reaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, string) (map[int64][]models.Reaction, error)); ok {
+		return rf(ctx, ids, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, string) map[int64][]models.Reaction); ok {
+		r0 = rf(ctx, ids, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]models.Reaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, string) error); ok {
+		r1 = rf(ctx, ids, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetReactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReactions'
+type Database_GetReactions_Call struct {
+	*mock.Call
+}
+
+// GetReactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+//   - user string
+func (_e *Database_Expecter) GetReactions(ctx interface{}, ids interface{}, user interface{}) *Database_GetReactions_Call {
+	return &Database_GetReactions_Call{Call: _e.mock.On("GetReactions", ctx, ids, user)}
+}
+
+func (_c *Database_GetReactions_Call) Run(run func(ctx context.Context, ids []int64, user string)) *Database_GetReactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetReactions_Call) Return(_a0 map[int64][]models.Reaction, _a1 error) *Database_GetReactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetReactions_Call) RunAndReturn(run func(context.Context, []int64, string) (map[int64][]models.Reaction, error)) *Database_GetReactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecentContents provides a mock function with given fields: ctx, author, window
+func (_m *Database) GetRecentContents(ctx context.Context, author string, window time.Duration) ([]string, error) {
+	ret := _m.Called(ctx, author, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentContents")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) ([]string, error)); ok {
+		return rf(ctx, author, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) []string); ok {
+		r0 = rf(ctx, author, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, author, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetRecentContents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentContents'
+type Database_GetRecentContents_Call struct {
+	*mock.Call
+}
+
+// GetRecentContents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - author string
+//   - window time.Duration
+func (_e *Database_Expecter) GetRecentContents(ctx interface{}, author interface{}, window interface{}) *Database_GetRecentContents_Call {
+	return &Database_GetRecentContents_Call{Call: _e.mock.On("GetRecentContents", ctx, author, window)}
+}
+
+func (_c *Database_GetRecentContents_Call) Run(run func(ctx context.Context, author string, window time.Duration)) *Database_GetRecentContents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Database_GetRecentContents_Call) Return(_a0 []string, _a1 error) *Database_GetRecentContents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetRecentContents_Call) RunAndReturn(run func(context.Context, string, time.Duration) ([]string, error)) *Database_GetRecentContents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReported provides a mock function with given fields: ctx, pag
+func (_m *Database) GetReported(ctx context.Context, pag *models.PagParam) (*models.ReportedRes, error) {
+	ret := _m.Called(ctx, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReported")
+	}
+
+	var r0 *models.ReportedRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) (*models.ReportedRes, error)); ok {
+		return rf(ctx, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) *models.ReportedRes); ok {
+		r0 = rf(ctx, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReportedRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PagParam) error); ok {
+		r1 = rf(ctx, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetReported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReported'
+type Database_GetReported_Call struct {
+	*mock.Call
+}
+
+// GetReported is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pag *models.PagParam
+func (_e *Database_Expecter) GetReported(ctx interface{}, pag interface{}) *Database_GetReported_Call {
+	return &Database_GetReported_Call{Call: _e.mock.On("GetReported", ctx, pag)}
+}
+
+func (_c *Database_GetReported_Call) Run(run func(ctx context.Context, pag *models.PagParam)) *Database_GetReported_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Database_GetReported_Call) Return(_a0 *models.ReportedRes, _a1 error) *Database_GetReported_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetReported_Call) RunAndReturn(run func(context.Context, *models.PagParam) (*models.ReportedRes, error)) *Database_GetReported_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRootComments provides a mock function with given fields: ctx, pag
+func (_m *Database) GetRootComments(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRootComments")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PagParam) error); ok {
+		r1 = rf(ctx, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetRootComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRootComments'
+type Database_GetRootComments_Call struct {
+	*mock.Call
+}
+
+// GetRootComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pag *models.PagParam
+func (_e *Database_Expecter) GetRootComments(ctx interface{}, pag interface{}) *Database_GetRootComments_Call {
+	return &Database_GetRootComments_Call{Call: _e.mock.On("GetRootComments", ctx, pag)}
+}
+
+func (_c *Database_GetRootComments_Call) Run(run func(ctx context.Context, pag *models.PagParam)) *Database_GetRootComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Database_GetRootComments_Call) Return(_a0 *models.CommentsRes, _a1 error) *Database_GetRootComments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetRootComments_Call) RunAndReturn(run func(context.Context, *models.PagParam) (*models.CommentsRes, error)) *Database_GetRootComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSearchDocs provides a mock function with given fields: ctx, afterID, limit
+func (_m *Database) GetSearchDocs(ctx context.Context, afterID int64, limit int) ([]models.SearchDoc, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchDocs")
+	}
+
+	var r0 []models.SearchDoc
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]models.SearchDoc, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []models.SearchDoc); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SearchDoc)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetSearchDocs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchDocs'
+type Database_GetSearchDocs_Call struct {
+	*mock.Call
+}
+
+// GetSearchDocs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int64
+//   - limit int
+func (_e *Database_Expecter) GetSearchDocs(ctx interface{}, afterID interface{}, limit interface{}) *Database_GetSearchDocs_Call {
+	return &Database_GetSearchDocs_Call{Call: _e.mock.On("GetSearchDocs", ctx, afterID, limit)}
+}
+
+func (_c *Database_GetSearchDocs_Call) Run(run func(ctx context.Context, afterID int64, limit int)) *Database_GetSearchDocs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetSearchDocs_Call) Return(_a0 []models.SearchDoc, _a1 error) *Database_GetSearchDocs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetSearchDocs_Call) RunAndReturn(run func(context.Context, int64, int) ([]models.SearchDoc, error)) *Database_GetSearchDocs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubtreeHeight provides a mock function with given fields: ctx, id
+func (_m *Database) GetSubtreeHeight(ctx context.Context, id int64) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubtreeHeight")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetSubtreeHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubtreeHeight'
+type Database_GetSubtreeHeight_Call struct {
+	*mock.Call
+}
+
+// GetSubtreeHeight is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) GetSubtreeHeight(ctx interface{}, id interface{}) *Database_GetSubtreeHeight_Call {
+	return &Database_GetSubtreeHeight_Call{Call: _e.mock.On("GetSubtreeHeight", ctx, id)}
+}
+
+func (_c *Database_GetSubtreeHeight_Call) Run(run func(ctx context.Context, id int64)) *Database_GetSubtreeHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_GetSubtreeHeight_Call) Return(_a0 int, _a1 error) *Database_GetSubtreeHeight_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetSubtreeHeight_Call) RunAndReturn(run func(context.Context, int64) (int, error)) *Database_GetSubtreeHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubtreeSearchDocs provides a mock function with given fields: ctx, id
+func (_m *Database) GetSubtreeSearchDocs(ctx context.Context, id int64) ([]models.SearchDoc, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubtreeSearchDocs")
+	}
+
+	var r0 []models.SearchDoc
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.SearchDoc, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.SearchDoc); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SearchDoc)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetSubtreeSearchDocs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubtreeSearchDocs'
+type Database_GetSubtreeSearchDocs_Call struct {
+	*mock.Call
+}
+
+// GetSubtreeSearchDocs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) GetSubtreeSearchDocs(ctx interface{}, id interface{}) *Database_GetSubtreeSearchDocs_Call {
+	return &Database_GetSubtreeSearchDocs_Call{Call: _e.mock.On("GetSubtreeSearchDocs", ctx, id)}
+}
+
+func (_c *Database_GetSubtreeSearchDocs_Call) Run(run func(ctx context.Context, id int64)) *Database_GetSubtreeSearchDocs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_GetSubtreeSearchDocs_Call) Return(_a0 []models.SearchDoc, _a1 error) *Database_GetSubtreeSearchDocs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetSubtreeSearchDocs_Call) RunAndReturn(run func(context.Context, int64) ([]models.SearchDoc, error)) *Database_GetSubtreeSearchDocs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThread provides a mock function with given fields: ctx, key
+func (_m *Database) GetThread(ctx context.Context, key string) (*models.Thread, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThread")
+	}
+
+	var r0 *models.Thread
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Thread, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Thread); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Thread)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThread'
+type Database_GetThread_Call struct {
+	*mock.Call
+}
+
+// GetThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *Database_Expecter) GetThread(ctx interface{}, key interface{}) *Database_GetThread_Call {
+	return &Database_GetThread_Call{Call: _e.mock.On("GetThread", ctx, key)}
+}
+
+func (_c *Database_GetThread_Call) Run(run func(ctx context.Context, key string)) *Database_GetThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetThread_Call) Return(_a0 *models.Thread, _a1 error) *Database_GetThread_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetThread_Call) RunAndReturn(run func(context.Context, string) (*models.Thread, error)) *Database_GetThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThreadRedirects provides a mock function with given fields: ctx, key
+func (_m *Database) GetThreadRedirects(ctx context.Context, key string) ([]models.ThreadRedirect, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThreadRedirects")
+	}
+
+	var r0 []models.ThreadRedirect
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ThreadRedirect, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ThreadRedirect); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ThreadRedirect)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetThreadRedirects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThreadRedirects'
+type Database_GetThreadRedirects_Call struct {
+	*mock.Call
+}
+
+// GetThreadRedirects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *Database_Expecter) GetThreadRedirects(ctx interface{}, key interface{}) *Database_GetThreadRedirects_Call {
+	return &Database_GetThreadRedirects_Call{Call: _e.mock.On("GetThreadRedirects", ctx, key)}
+}
+
+func (_c *Database_GetThreadRedirects_Call) Run(run func(ctx context.Context, key string)) *Database_GetThreadRedirects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetThreadRedirects_Call) Return(_a0 []models.ThreadRedirect, _a1 error) *Database_GetThreadRedirects_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetThreadRedirects_Call) RunAndReturn(run func(context.Context, string) ([]models.ThreadRedirect, error)) *Database_GetThreadRedirects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnreadNotifications provides a mock function with given fields: ctx, recipient, pag
+func (_m *Database) GetUnreadNotifications(ctx context.Context, recipient string, pag *models.PagParam) (*models.NotificationsRes, error) {
+	ret := _m.Called(ctx, recipient, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnreadNotifications")
+	}
+
+	var r0 *models.NotificationsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PagParam) (*models.NotificationsRes, error)); ok {
+		return rf(ctx, recipient, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PagParam) *models.NotificationsRes); ok {
+		r0 = rf(ctx, recipient, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PagParam) error); ok {
+		r1 = rf(ctx, recipient, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetUnreadNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnreadNotifications'
+type Database_GetUnreadNotifications_Call struct {
+	*mock.Call
+}
+
+// GetUnreadNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipient string
+//   - pag *models.PagParam
+func (_e *Database_Expecter) GetUnreadNotifications(ctx interface{}, recipient interface{}, pag interface{}) *Database_GetUnreadNotifications_Call {
+	return &Database_GetUnreadNotifications_Call{Call: _e.mock.On("GetUnreadNotifications", ctx, recipient, pag)}
+}
+
+func (_c *Database_GetUnreadNotifications_Call) Run(run func(ctx context.Context, recipient string, pag *models.PagParam)) *Database_GetUnreadNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Database_GetUnreadNotifications_Call) Return(_a0 *models.NotificationsRes, _a1 error) *Database_GetUnreadNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetUnreadNotifications_Call) RunAndReturn(run func(context.Context, string, *models.PagParam) (*models.NotificationsRes, error)) *Database_GetUnreadNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhook provides a mock function with given fields: ctx, id
+func (_m *Database) GetWebhook(ctx context.Context, id int64) (*models.Webhook, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhook")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Webhook, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Webhook); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type Database_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) GetWebhook(ctx interface{}, id interface{}) *Database_GetWebhook_Call {
+	return &Database_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, id)}
+}
+
+func (_c *Database_GetWebhook_Call) Run(run func(ctx context.Context, id int64)) *Database_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_GetWebhook_Call) Return(_a0 *models.Webhook, _a1 error) *Database_GetWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetWebhook_Call) RunAndReturn(run func(context.Context, int64) (*models.Webhook, error)) *Database_GetWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhooks provides a mock function with given fields: ctx
+func (_m *Database) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooks")
+	}
+
+	var r0 []models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooks'
+type Database_GetWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Database_Expecter) GetWebhooks(ctx interface{}) *Database_GetWebhooks_Call {
+	return &Database_GetWebhooks_Call{Call: _e.mock.On("GetWebhooks", ctx)}
+}
+
+func (_c *Database_GetWebhooks_Call) Run(run func(ctx context.Context)) *Database_GetWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Database_GetWebhooks_Call) Return(_a0 []models.Webhook, _a1 error) *Database_GetWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetWebhooks_Call) RunAndReturn(run func(context.Context) ([]models.Webhook, error)) *Database_GetWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HideReported provides a mock function with given fields: ctx, id, threshold, entry
+func (_m *Database) HideReported(ctx context.Context, id int64, threshold int, entry *models.AuditEntry) (bool, error) {
+	ret := _m.Called(ctx, id, threshold, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HideReported")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, *models.AuditEntry) (bool, error)); ok {
+		return rf(ctx, id, threshold, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, *models.AuditEntry) bool); ok {
+		r0 = rf(ctx, id, threshold, entry)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, *models.AuditEntry) error); ok {
+		r1 = rf(ctx, id, threshold, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_HideReported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HideReported'
+type Database_HideReported_Call struct {
+	*mock.Call
+}
+
+// HideReported is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - threshold int
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) HideReported(ctx interface{}, id interface{}, threshold interface{}, entry interface{}) *Database_HideReported_Call {
+	return &Database_HideReported_Call{Call: _e.mock.On("HideReported", ctx, id, threshold, entry)}
+}
+
+func (_c *Database_HideReported_Call) Run(run func(ctx context.Context, id int64, threshold int, entry *models.AuditEntry)) *Database_HideReported_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_HideReported_Call) Return(_a0 bool, _a1 error) *Database_HideReported_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_HideReported_Call) RunAndReturn(run func(context.Context, int64, int, *models.AuditEntry) (bool, error)) *Database_HideReported_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadSearchHits provides a mock function with given fields: ctx, hits, viewer
+func (_m *Database) LoadSearchHits(ctx context.Context, hits []models.SearchHit, viewer *models.Actor) ([]models.SearchHit, error) {
+	ret := _m.Called(ctx, hits, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadSearchHits")
+	}
+
+	var r0 []models.SearchHit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.SearchHit, *models.Actor) ([]models.SearchHit, error)); ok {
+		return rf(ctx, hits, viewer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.SearchHit, *models.Actor) []models.SearchHit); ok {
+		r0 = rf(ctx, hits, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.SearchHit, *models.Actor) error); ok {
+		r1 = rf(ctx, hits, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_LoadSearchHits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadSearchHits'
+type Database_LoadSearchHits_Call struct {
+	*mock.Call
+}
+
+// LoadSearchHits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hits []models.SearchHit
+//   - viewer *models.Actor
+func (_e *Database_Expecter) LoadSearchHits(ctx interface{}, hits interface{}, viewer interface{}) *Database_LoadSearchHits_Call {
+	return &Database_LoadSearchHits_Call{Call: _e.mock.On("LoadSearchHits", ctx, hits, viewer)}
+}
+
+func (_c *Database_LoadSearchHits_Call) Run(run func(ctx context.Context, hits []models.SearchHit, viewer *models.Actor)) *Database_LoadSearchHits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.SearchHit), args[2].(*models.Actor))
+	})
+	return _c
+}
+
+func (_c *Database_LoadSearchHits_Call) Return(_a0 []models.SearchHit, _a1 error) *Database_LoadSearchHits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_LoadSearchHits_Call) RunAndReturn(run func(context.Context, []models.SearchHit, *models.Actor) ([]models.SearchHit, error)) *Database_LoadSearchHits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAllNotificationsRead provides a mock function with given fields: ctx, recipient
+func (_m *Database) MarkAllNotificationsRead(ctx context.Context, recipient string) (int, error) {
+	ret := _m.Called(ctx, recipient)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAllNotificationsRead")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, recipient)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, recipient)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, recipient)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_MarkAllNotificationsRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAllNotificationsRead'
+type Database_MarkAllNotificationsRead_Call struct {
+	*mock.Call
+}
+
+// MarkAllNotificationsRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipient string
+func (_e *Database_Expecter) MarkAllNotificationsRead(ctx interface{}, recipient interface{}) *Database_MarkAllNotificationsRead_Call {
+	return &Database_MarkAllNotificationsRead_Call{Call: _e.mock.On("MarkAllNotificationsRead", ctx, recipient)}
+}
+
+func (_c *Database_MarkAllNotificationsRead_Call) Run(run func(ctx context.Context, recipient string)) *Database_MarkAllNotificationsRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_MarkAllNotificationsRead_Call) Return(_a0 int, _a1 error) *Database_MarkAllNotificationsRead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_MarkAllNotificationsRead_Call) RunAndReturn(run func(context.Context, string) (int, error)) *Database_MarkAllNotificationsRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkNotificationRead provides a mock function with given fields: ctx, recipient, id
+func (_m *Database) MarkNotificationRead(ctx context.Context, recipient string, id int64) (bool, error) {
+	ret := _m.Called(ctx, recipient, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkNotificationRead")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (bool, error)); ok {
+		return rf(ctx, recipient, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(ctx, recipient, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, recipient, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_MarkNotificationRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkNotificationRead'
+type Database_MarkNotificationRead_Call struct {
+	*mock.Call
+}
+
+// MarkNotificationRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipient string
+//   - id int64
+func (_e *Database_Expecter) MarkNotificationRead(ctx interface{}, recipient interface{}, id interface{}) *Database_MarkNotificationRead_Call {
+	return &Database_MarkNotificationRead_Call{Call: _e.mock.On("MarkNotificationRead", ctx, recipient, id)}
+}
+
+func (_c *Database_MarkNotificationRead_Call) Run(run func(ctx context.Context, recipient string, id int64)) *Database_MarkNotificationRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_MarkNotificationRead_Call) Return(_a0 bool, _a1 error) *Database_MarkNotificationRead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_MarkNotificationRead_Call) RunAndReturn(run func(context.Context, string, int64) (bool, error)) *Database_MarkNotificationRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeThreads provides a mock function with given fields: ctx, from, into, entry
+func (_m *Database) MergeThreads(ctx context.Context, from string, into string, entry *models.AuditEntry) (int64, error) {
+	ret := _m.Called(ctx, from, into, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeThreads")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.AuditEntry) (int64, error)); ok {
+		return rf(ctx, from, into, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.AuditEntry) int64); ok {
+		r0 = rf(ctx, from, into, entry)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.AuditEntry) error); ok {
+		r1 = rf(ctx, from, into, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_MergeThreads_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeThreads'
+type Database_MergeThreads_Call struct {
+	*mock.Call
+}
+
+// MergeThreads is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - into string
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) MergeThreads(ctx interface{}, from interface{}, into interface{}, entry interface{}) *Database_MergeThreads_Call {
+	return &Database_MergeThreads_Call{Call: _e.mock.On("MergeThreads", ctx, from, into, entry)}
+}
+
+func (_c *Database_MergeThreads_Call) Run(run func(ctx context.Context, from string, into string, entry *models.AuditEntry)) *Database_MergeThreads_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_MergeThreads_Call) Return(_a0 int64, _a1 error) *Database_MergeThreads_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_MergeThreads_Call) RunAndReturn(run func(context.Context, string, string, *models.AuditEntry) (int64, error)) *Database_MergeThreads_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveComment provides a mock function with given fields: ctx, id, parentID, thread, rootID, entry
+func (_m *Database) MoveComment(ctx context.Context, id int64, parentID *int64, thread string, rootID int64, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, id, parentID, thread, rootID, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64, string, int64, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, id, parentID, thread, rootID, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_MoveComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveComment'
+type Database_MoveComment_Call struct {
+	*mock.Call
+}
+
+// MoveComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - parentID *int64
+//   - thread string
+//   - rootID int64
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) MoveComment(ctx interface{}, id interface{}, parentID interface{}, thread interface{}, rootID interface{}, entry interface{}) *Database_MoveComment_Call {
+	return &Database_MoveComment_Call{Call: _e.mock.On("MoveComment", ctx, id, parentID, thread, rootID, entry)}
+}
+
+func (_c *Database_MoveComment_Call) Run(run func(ctx context.Context, id int64, parentID *int64, thread string, rootID int64, entry *models.AuditEntry)) *Database_MoveComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*int64), args[3].(string), args[4].(int64), args[5].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_MoveComment_Call) Return(_a0 error) *Database_MoveComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_MoveComment_Call) RunAndReturn(run func(context.Context, int64, *int64, string, int64, *models.AuditEntry) error) *Database_MoveComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PinComment provides a mock function with given fields: ctx, id, thread, maxPinned, entry
+func (_m *Database) PinComment(ctx context.Context, id int64, thread string, maxPinned int, entry *models.AuditEntry) (bool, error) {
+	ret := _m.Called(ctx, id, thread, maxPinned, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinComment")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int, *models.AuditEntry) (bool, error)); ok {
+		return rf(ctx, id, thread, maxPinned, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int, *models.AuditEntry) bool); ok {
+		r0 = rf(ctx, id, thread, maxPinned, entry)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int, *models.AuditEntry) error); ok {
+		r1 = rf(ctx, id, thread, maxPinned, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_PinComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PinComment'
+type Database_PinComment_Call struct {
+	*mock.Call
+}
+
+// PinComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - thread string
+//   - maxPinned int
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) PinComment(ctx interface{}, id interface{}, thread interface{}, maxPinned interface{}, entry interface{}) *Database_PinComment_Call {
+	return &Database_PinComment_Call{Call: _e.mock.On("PinComment", ctx, id, thread, maxPinned, entry)}
+}
+
+func (_c *Database_PinComment_Call) Run(run func(ctx context.Context, id int64, thread string, maxPinned int, entry *models.AuditEntry)) *Database_PinComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(int), args[4].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_PinComment_Call) Return(_a0 bool, _a1 error) *Database_PinComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_PinComment_Call) RunAndReturn(run func(context.Context, int64, string, int, *models.AuditEntry) (bool, error)) *Database_PinComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Redeliver provides a mock function with given fields: ctx, id
+func (_m *Database) Redeliver(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redeliver")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_Redeliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redeliver'
+type Database_Redeliver_Call struct {
+	*mock.Call
+}
+
+// Redeliver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Database_Expecter) Redeliver(ctx interface{}, id interface{}) *Database_Redeliver_Call {
+	return &Database_Redeliver_Call{Call: _e.mock.On("Redeliver", ctx, id)}
+}
+
+func (_c *Database_Redeliver_Call) Run(run func(ctx context.Context, id int64)) *Database_Redeliver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_Redeliver_Call) Return(_a0 error) *Database_Redeliver_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_Redeliver_Call) RunAndReturn(run func(context.Context, int64) error) *Database_Redeliver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveReaction provides a mock function with given fields: ctx, commentID, user, emoji
+func (_m *Database) RemoveReaction(ctx context.Context, commentID int64, user string, emoji string) error {
+	ret := _m.Called(ctx, commentID, user, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, commentID, user, emoji)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_RemoveReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReaction'
+type Database_RemoveReaction_Call struct {
+	*mock.Call
+}
+
+// RemoveReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commentID int64
+//   - user string
+//   - emoji string
+func (_e *Database_Expecter) RemoveReaction(ctx interface{}, commentID interface{}, user interface{}, emoji interface{}) *Database_RemoveReaction_Call {
+	return &Database_RemoveReaction_Call{Call: _e.mock.On("RemoveReaction", ctx, commentID, user, emoji)}
+}
+
+func (_c *Database_RemoveReaction_Call) Run(run func(ctx context.Context, commentID int64, user string, emoji string)) *Database_RemoveReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Database_RemoveReaction_Call) Return(_a0 error) *Database_RemoveReaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_RemoveReaction_Call) RunAndReturn(run func(context.Context, int64, string, string) error) *Database_RemoveReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, id, entry
+func (_m *Database) Restore(ctx context.Context, id int64, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, id, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, id, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type Database_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) Restore(ctx interface{}, id interface{}, entry interface{}) *Database_Restore_Call {
+	return &Database_Restore_Call{Call: _e.mock.On("Restore", ctx, id, entry)}
+}
+
+func (_c *Database_Restore_Call) Run(run func(ctx context.Context, id int64, entry *models.AuditEntry)) *Database_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_Restore_Call) Return(_a0 error) *Database_Restore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_Restore_Call) RunAndReturn(run func(context.Context, int64, *models.AuditEntry) error) *Database_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveDeliveryAttempt provides a mock function with given fields: ctx, delivery, retryIn
+func (_m *Database) SaveDeliveryAttempt(ctx context.Context, delivery *models.WebhookDelivery, retryIn time.Duration) error {
+	ret := _m.Called(ctx, delivery, retryIn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDeliveryAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery, time.Duration) error); ok {
+		r0 = rf(ctx, delivery, retryIn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SaveDeliveryAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDeliveryAttempt'
+type Database_SaveDeliveryAttempt_Call struct {
+	*mock.Call
+}
+
+// SaveDeliveryAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery *models.WebhookDelivery
+//   - retryIn time.Duration
+func (_e *Database_Expecter) SaveDeliveryAttempt(ctx interface{}, delivery interface{}, retryIn interface{}) *Database_SaveDeliveryAttempt_Call {
+	return &Database_SaveDeliveryAttempt_Call{Call: _e.mock.On("SaveDeliveryAttempt", ctx, delivery, retryIn)}
+}
+
+func (_c *Database_SaveDeliveryAttempt_Call) Run(run func(ctx context.Context, delivery *models.WebhookDelivery, retryIn time.Duration)) *Database_SaveDeliveryAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WebhookDelivery), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Database_SaveDeliveryAttempt_Call) Return(_a0 error) *Database_SaveDeliveryAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SaveDeliveryAttempt_Call) RunAndReturn(run func(context.Context, *models.WebhookDelivery, time.Duration) error) *Database_SaveDeliveryAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveNotificationAttempt provides a mock function with given fields: ctx, id, sent, retryIn
+func (_m *Database) SaveNotificationAttempt(ctx context.Context, id int64, sent bool, retryIn time.Duration) error {
+	ret := _m.Called(ctx, id, sent, retryIn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveNotificationAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool, time.Duration) error); ok {
+		r0 = rf(ctx, id, sent, retryIn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SaveNotificationAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveNotificationAttempt'
+type Database_SaveNotificationAttempt_Call struct {
+	*mock.Call
+}
+
+// SaveNotificationAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - sent bool
+//   - retryIn time.Duration
+func (_e *Database_Expecter) SaveNotificationAttempt(ctx interface{}, id interface{}, sent interface{}, retryIn interface{}) *Database_SaveNotificationAttempt_Call {
+	return &Database_SaveNotificationAttempt_Call{Call: _e.mock.On("SaveNotificationAttempt", ctx, id, sent, retryIn)}
+}
+
+func (_c *Database_SaveNotificationAttempt_Call) Run(run func(ctx context.Context, id int64, sent bool, retryIn time.Duration)) *Database_SaveNotificationAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Database_SaveNotificationAttempt_Call) Return(_a0 error) *Database_SaveNotificationAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SaveNotificationAttempt_Call) RunAndReturn(run func(context.Context, int64, bool, time.Duration) error) *Database_SaveNotificationAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveThread provides a mock function with given fields: ctx, thread, entry
+func (_m *Database) SaveThread(ctx context.Context, thread *models.Thread, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, thread, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveThread")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Thread, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, thread, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SaveThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveThread'
+type Database_SaveThread_Call struct {
+	*mock.Call
+}
+
+// SaveThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - thread *models.Thread
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) SaveThread(ctx interface{}, thread interface{}, entry interface{}) *Database_SaveThread_Call {
+	return &Database_SaveThread_Call{Call: _e.mock.On("SaveThread", ctx, thread, entry)}
+}
+
+func (_c *Database_SaveThread_Call) Run(run func(ctx context.Context, thread *models.Thread, entry *models.AuditEntry)) *Database_SaveThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Thread), args[2].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_SaveThread_Call) Return(_a0 error) *Database_SaveThread_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SaveThread_Call) RunAndReturn(run func(context.Context, *models.Thread, *models.AuditEntry) error) *Database_SaveThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAcceptedAnswer provides a mock function with given fields: ctx, rootID, answerID, entry
+func (_m *Database) SetAcceptedAnswer(ctx context.Context, rootID int64, answerID *int64, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, rootID, answerID, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAcceptedAnswer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, rootID, answerID, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetAcceptedAnswer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAcceptedAnswer'
+type Database_SetAcceptedAnswer_Call struct {
+	*mock.Call
+}
+
+// SetAcceptedAnswer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rootID int64
+//   - answerID *int64
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) SetAcceptedAnswer(ctx interface{}, rootID interface{}, answerID interface{}, entry interface{}) *Database_SetAcceptedAnswer_Call {
+	return &Database_SetAcceptedAnswer_Call{Call: _e.mock.On("SetAcceptedAnswer", ctx, rootID, answerID, entry)}
+}
+
+func (_c *Database_SetAcceptedAnswer_Call) Run(run func(ctx context.Context, rootID int64, answerID *int64, entry *models.AuditEntry)) *Database_SetAcceptedAnswer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*int64), args[3].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_SetAcceptedAnswer_Call) Return(_a0 error) *Database_SetAcceptedAnswer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetAcceptedAnswer_Call) RunAndReturn(run func(context.Context, int64, *int64, *models.AuditEntry) error) *Database_SetAcceptedAnswer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStatus provides a mock function with given fields: ctx, id, status, entry
+func (_m *Database) SetStatus(ctx context.Context, id int64, status models.CommentStatus, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, id, status, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.CommentStatus, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, id, status, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStatus'
+type Database_SetStatus_Call struct {
+	*mock.Call
+}
+
+// SetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - status models.CommentStatus
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) SetStatus(ctx interface{}, id interface{}, status interface{}, entry interface{}) *Database_SetStatus_Call {
+	return &Database_SetStatus_Call{Call: _e.mock.On("SetStatus", ctx, id, status, entry)}
+}
+
+func (_c *Database_SetStatus_Call) Run(run func(ctx context.Context, id int64, status models.CommentStatus, entry *models.AuditEntry)) *Database_SetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.CommentStatus), args[3].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_SetStatus_Call) Return(_a0 error) *Database_SetStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetStatus_Call) RunAndReturn(run func(context.Context, int64, models.CommentStatus, *models.AuditEntry) error) *Database_SetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetThreadLock provides a mock function with given fields: ctx, key, locked, entry
+func (_m *Database) SetThreadLock(ctx context.Context, key string, locked bool, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, key, locked, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetThreadLock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, key, locked, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetThreadLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetThreadLock'
+type Database_SetThreadLock_Call struct {
+	*mock.Call
+}
+
+// SetThreadLock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - locked bool
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) SetThreadLock(ctx interface{}, key interface{}, locked interface{}, entry interface{}) *Database_SetThreadLock_Call {
+	return &Database_SetThreadLock_Call{Call: _e.mock.On("SetThreadLock", ctx, key, locked, entry)}
+}
+
+func (_c *Database_SetThreadLock_Call) Run(run func(ctx context.Context, key string, locked bool, entry *models.AuditEntry)) *Database_SetThreadLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_SetThreadLock_Call) Return(_a0 error) *Database_SetThreadLock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetThreadLock_Call) RunAndReturn(run func(context.Context, string, bool, *models.AuditEntry) error) *Database_SetThreadLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SplitThread provides a mock function with given fields: ctx, id, from, to, entry
+func (_m *Database) SplitThread(ctx context.Context, id int64, from string, to string, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, id, from, to, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitThread")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, id, from, to, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SplitThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitThread'
+type Database_SplitThread_Call struct {
+	*mock.Call
+}
+
+// SplitThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - from string
+//   - to string
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) SplitThread(ctx interface{}, id interface{}, from interface{}, to interface{}, entry interface{}) *Database_SplitThread_Call {
+	return &Database_SplitThread_Call{Call: _e.mock.On("SplitThread", ctx, id, from, to, entry)}
+}
+
+func (_c *Database_SplitThread_Call) Run(run func(ctx context.Context, id int64, from string, to string, entry *models.AuditEntry)) *Database_SplitThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string), args[4].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_SplitThread_Call) Return(_a0 error) *Database_SplitThread_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SplitThread_Call) RunAndReturn(run func(context.Context, int64, string, string, *models.AuditEntry) error) *Database_SplitThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnpinComment provides a mock function with given fields: ctx, id, entry
+func (_m *Database) UnpinComment(ctx context.Context, id int64, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, id, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpinComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, id, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_UnpinComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnpinComment'
+type Database_UnpinComment_Call struct {
+	*mock.Call
+}
+
+// UnpinComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) UnpinComment(ctx interface{}, id interface{}, entry interface{}) *Database_UnpinComment_Call {
+	return &Database_UnpinComment_Call{Call: _e.mock.On("UnpinComment", ctx, id, entry)}
+}
+
+func (_c *Database_UnpinComment_Call) Run(run func(ctx context.Context, id int64, entry *models.AuditEntry)) *Database_UnpinComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_UnpinComment_Call) Return(_a0 error) *Database_UnpinComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UnpinComment_Call) RunAndReturn(run func(context.Context, int64, *models.AuditEntry) error) *Database_UnpinComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAuthor provides a mock function with given fields: ctx, author, entry
+func (_m *Database) UpdateAuthor(ctx context.Context, author *models.Author, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, author, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAuthor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Author, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, author, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_UpdateAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAuthor'
+type Database_UpdateAuthor_Call struct {
+	*mock.Call
+}
+
+// UpdateAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - author *models.Author
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) UpdateAuthor(ctx interface{}, author interface{}, entry interface{}) *Database_UpdateAuthor_Call {
+	return &Database_UpdateAuthor_Call{Call: _e.mock.On("UpdateAuthor", ctx, author, entry)}
+}
+
+func (_c *Database_UpdateAuthor_Call) Run(run func(ctx context.Context, author *models.Author, entry *models.AuditEntry)) *Database_UpdateAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Author), args[2].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateAuthor_Call) Return(_a0 error) *Database_UpdateAuthor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UpdateAuthor_Call) RunAndReturn(run func(context.Context, *models.Author, *models.AuditEntry) error) *Database_UpdateAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateContent provides a mock function with given fields: ctx, comment, entry
+func (_m *Database) UpdateContent(ctx context.Context, comment *models.Comment, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, comment, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateContent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, comment, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_UpdateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateContent'
+type Database_UpdateContent_Call struct {
+	*mock.Call
+}
+
+// UpdateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *models.Comment
+//   - entry *models.AuditEntry
+func (_e *Database_Expecter) UpdateContent(ctx interface{}, comment interface{}, entry interface{}) *Database_UpdateContent_Call {
+	return &Database_UpdateContent_Call{Call: _e.mock.On("UpdateContent", ctx, comment, entry)}
+}
+
+func (_c *Database_UpdateContent_Call) Run(run func(ctx context.Context, comment *models.Comment, entry *models.AuditEntry)) *Database_UpdateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Comment), args[2].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateContent_Call) Return(_a0 error) *Database_UpdateContent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UpdateContent_Call) RunAndReturn(run func(context.Context, *models.Comment, *models.AuditEntry) error) *Database_UpdateContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *Database) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_UpdateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhook'
+type Database_UpdateWebhook_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhook *models.Webhook
+func (_e *Database_Expecter) UpdateWebhook(ctx interface{}, webhook interface{}) *Database_UpdateWebhook_Call {
+	return &Database_UpdateWebhook_Call{Call: _e.mock.On("UpdateWebhook", ctx, webhook)}
+}
+
+func (_c *Database_UpdateWebhook_Call) Run(run func(ctx context.Context, webhook *models.Webhook)) *Database_UpdateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Webhook))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateWebhook_Call) Return(_a0 error) *Database_UpdateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UpdateWebhook_Call) RunAndReturn(run func(context.Context, *models.Webhook) error) *Database_UpdateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Vote provides a mock function with given fields: ctx, commentID, user, value
+func (_m *Database) Vote(ctx context.Context, commentID int64, user string, value int) (*models.VoteResult, error) {
+	ret := _m.Called(ctx, commentID, user, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vote")
+	}
+
+	var r0 *models.VoteResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int) (*models.VoteResult, error)); ok {
+		return rf(ctx, commentID, user, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int) *models.VoteResult); ok {
+		r0 = rf(ctx, commentID, user, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VoteResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int) error); ok {
+		r1 = rf(ctx, commentID, user, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_Vote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Vote'
+type Database_Vote_Call struct {
+	*mock.Call
+}
+
+// Vote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commentID int64
+//   - user string
+//   - value int
+func (_e *Database_Expecter) Vote(ctx interface{}, commentID interface{}, user interface{}, value interface{}) *Database_Vote_Call {
+	return &Database_Vote_Call{Call: _e.mock.On("Vote", ctx, commentID, user, value)}
+}
+
+func (_c *Database_Vote_Call) Run(run func(ctx context.Context, commentID int64, user string, value int)) *Database_Vote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Database_Vote_Call) Return(_a0 *models.VoteResult, _a1 error) *Database_Vote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_Vote_Call) RunAndReturn(run func(context.Context, int64, string, int) (*models.VoteResult, error)) *Database_Vote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDatabase creates a new instance of Database. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDatabase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Database {
+	mock := &Database{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
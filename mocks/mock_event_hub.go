@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	infra "github.com/sunr3d/comment-tree/internal/interfaces/infra"
+
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// EventHub is an autogenerated mock type for the EventHub type
+type EventHub struct {
+	mock.Mock
+}
+
+type EventHub_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EventHub) EXPECT() *EventHub_Expecter {
+	return &EventHub_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function with given fields: ctx, event
+func (_m *EventHub) Publish(ctx context.Context, event *models.CommentEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CommentEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EventHub_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type EventHub_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *models.CommentEvent
+func (_e *EventHub_Expecter) Publish(ctx interface{}, event interface{}) *EventHub_Publish_Call {
+	return &EventHub_Publish_Call{Call: _e.mock.On("Publish", ctx, event)}
+}
+
+func (_c *EventHub_Publish_Call) Run(run func(ctx context.Context, event *models.CommentEvent)) *EventHub_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CommentEvent))
+	})
+	return _c
+}
+
+func (_c *EventHub_Publish_Call) Return(_a0 error) *EventHub_Publish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventHub_Publish_Call) RunAndReturn(run func(context.Context, *models.CommentEvent) error) *EventHub_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: filter, lastEventID
+func (_m *EventHub) Subscribe(filter models.EventFilter, lastEventID int64) (infra.Subscription, error) {
+	ret := _m.Called(filter, lastEventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 infra.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.EventFilter, int64) (infra.Subscription, error)); ok {
+		return rf(filter, lastEventID)
+	}
+	if rf, ok := ret.Get(0).(func(models.EventFilter, int64) infra.Subscription); ok {
+		r0 = rf(filter, lastEventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(infra.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.EventFilter, int64) error); ok {
+		r1 = rf(filter, lastEventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EventHub_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type EventHub_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - filter models.EventFilter
+//   - lastEventID int64
+func (_e *EventHub_Expecter) Subscribe(filter interface{}, lastEventID interface{}) *EventHub_Subscribe_Call {
+	return &EventHub_Subscribe_Call{Call: _e.mock.On("Subscribe", filter, lastEventID)}
+}
+
+func (_c *EventHub_Subscribe_Call) Run(run func(filter models.EventFilter, lastEventID int64)) *EventHub_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.EventFilter), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *EventHub_Subscribe_Call) Return(_a0 infra.Subscription, _a1 error) *EventHub_Subscribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EventHub_Subscribe_Call) RunAndReturn(run func(models.EventFilter, int64) (infra.Subscription, error)) *EventHub_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEventHub creates a new instance of EventHub. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventHub(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventHub {
+	mock := &EventHub{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,795 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// Moderation is an autogenerated mock type for the Moderation type
+type Moderation struct {
+	mock.Mock
+}
+
+type Moderation_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Moderation) EXPECT() *Moderation_Expecter {
+	return &Moderation_Expecter{mock: &_m.Mock}
+}
+
+// Approve provides a mock function with given fields: ctx, id, actor, reason
+func (_m *Moderation) Approve(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	ret := _m.Called(ctx, id, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Approve")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor, string) error); ok {
+		r0 = rf(ctx, id, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Moderation_Approve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Approve'
+type Moderation_Approve_Call struct {
+	*mock.Call
+}
+
+// Approve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - actor *models.Actor
+//   - reason string
+func (_e *Moderation_Expecter) Approve(ctx interface{}, id interface{}, actor interface{}, reason interface{}) *Moderation_Approve_Call {
+	return &Moderation_Approve_Call{Call: _e.mock.On("Approve", ctx, id, actor, reason)}
+}
+
+func (_c *Moderation_Approve_Call) Run(run func(ctx context.Context, id int64, actor *models.Actor, reason string)) *Moderation_Approve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.Actor), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_Approve_Call) Return(_a0 error) *Moderation_Approve_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Moderation_Approve_Call) RunAndReturn(run func(context.Context, int64, *models.Actor, string) error) *Moderation_Approve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuditLog provides a mock function with given fields: ctx, filter
+func (_m *Moderation) GetAuditLog(ctx context.Context, filter *models.AuditFilter) (*models.AuditRes, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditLog")
+	}
+
+	var r0 *models.AuditRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditFilter) (*models.AuditRes, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditFilter) *models.AuditRes); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuditRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderation_GetAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLog'
+type Moderation_GetAuditLog_Call struct {
+	*mock.Call
+}
+
+// GetAuditLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.AuditFilter
+func (_e *Moderation_Expecter) GetAuditLog(ctx interface{}, filter interface{}) *Moderation_GetAuditLog_Call {
+	return &Moderation_GetAuditLog_Call{Call: _e.mock.On("GetAuditLog", ctx, filter)}
+}
+
+func (_c *Moderation_GetAuditLog_Call) Run(run func(ctx context.Context, filter *models.AuditFilter)) *Moderation_GetAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *Moderation_GetAuditLog_Call) Return(_a0 *models.AuditRes, _a1 error) *Moderation_GetAuditLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Moderation_GetAuditLog_Call) RunAndReturn(run func(context.Context, *models.AuditFilter) (*models.AuditRes, error)) *Moderation_GetAuditLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQueue provides a mock function with given fields: ctx, pag
+func (_m *Moderation) GetQueue(ctx context.Context, pag *models.PagParam) (*models.CommentsRes, error) {
+	ret := _m.Called(ctx, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueue")
+	}
+
+	var r0 *models.CommentsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) (*models.CommentsRes, error)); ok {
+		return rf(ctx, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) *models.CommentsRes); ok {
+		r0 = rf(ctx, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommentsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PagParam) error); ok {
+		r1 = rf(ctx, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderation_GetQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQueue'
+type Moderation_GetQueue_Call struct {
+	*mock.Call
+}
+
+// GetQueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pag *models.PagParam
+func (_e *Moderation_Expecter) GetQueue(ctx interface{}, pag interface{}) *Moderation_GetQueue_Call {
+	return &Moderation_GetQueue_Call{Call: _e.mock.On("GetQueue", ctx, pag)}
+}
+
+func (_c *Moderation_GetQueue_Call) Run(run func(ctx context.Context, pag *models.PagParam)) *Moderation_GetQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Moderation_GetQueue_Call) Return(_a0 *models.CommentsRes, _a1 error) *Moderation_GetQueue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Moderation_GetQueue_Call) RunAndReturn(run func(context.Context, *models.PagParam) (*models.CommentsRes, error)) *Moderation_GetQueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReported provides a mock function with given fields: ctx, pag
+func (_m *Moderation) GetReported(ctx context.Context, pag *models.PagParam) (*models.ReportedRes, error) {
+	ret := _m.Called(ctx, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReported")
+	}
+
+	var r0 *models.ReportedRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) (*models.ReportedRes, error)); ok {
+		return rf(ctx, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PagParam) *models.ReportedRes); ok {
+		r0 = rf(ctx, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReportedRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PagParam) error); ok {
+		r1 = rf(ctx, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderation_GetReported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReported'
+type Moderation_GetReported_Call struct {
+	*mock.Call
+}
+
+// GetReported is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pag *models.PagParam
+func (_e *Moderation_Expecter) GetReported(ctx interface{}, pag interface{}) *Moderation_GetReported_Call {
+	return &Moderation_GetReported_Call{Call: _e.mock.On("GetReported", ctx, pag)}
+}
+
+func (_c *Moderation_GetReported_Call) Run(run func(ctx context.Context, pag *models.PagParam)) *Moderation_GetReported_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Moderation_GetReported_Call) Return(_a0 *models.ReportedRes, _a1 error) *Moderation_GetReported_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Moderation_GetReported_Call) RunAndReturn(run func(context.Context, *models.PagParam) (*models.ReportedRes, error)) *Moderation_GetReported_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThread provides a mock function with given fields: ctx, key
+func (_m *Moderation) GetThread(ctx context.Context, key string) (*models.Thread, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThread")
+	}
+
+	var r0 *models.Thread
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Thread, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Thread); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Thread)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderation_GetThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThread'
+type Moderation_GetThread_Call struct {
+	*mock.Call
+}
+
+// GetThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *Moderation_Expecter) GetThread(ctx interface{}, key interface{}) *Moderation_GetThread_Call {
+	return &Moderation_GetThread_Call{Call: _e.mock.On("GetThread", ctx, key)}
+}
+
+func (_c *Moderation_GetThread_Call) Run(run func(ctx context.Context, key string)) *Moderation_GetThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_GetThread_Call) Return(_a0 *models.Thread, _a1 error) *Moderation_GetThread_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Moderation_GetThread_Call) RunAndReturn(run func(context.Context, string) (*models.Thread, error)) *Moderation_GetThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThreadRedirects provides a mock function with given fields: ctx, key
+func (_m *Moderation) GetThreadRedirects(ctx context.Context, key string) ([]models.ThreadRedirect, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThreadRedirects")
+	}
+
+	var r0 []models.ThreadRedirect
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ThreadRedirect, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ThreadRedirect); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ThreadRedirect)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderation_GetThreadRedirects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThreadRedirects'
+type Moderation_GetThreadRedirects_Call struct {
+	*mock.Call
+}
+
+// GetThreadRedirects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *Moderation_Expecter) GetThreadRedirects(ctx interface{}, key interface{}) *Moderation_GetThreadRedirects_Call {
+	return &Moderation_GetThreadRedirects_Call{Call: _e.mock.On("GetThreadRedirects", ctx, key)}
+}
+
+func (_c *Moderation_GetThreadRedirects_Call) Run(run func(ctx context.Context, key string)) *Moderation_GetThreadRedirects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_GetThreadRedirects_Call) Return(_a0 []models.ThreadRedirect, _a1 error) *Moderation_GetThreadRedirects_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Moderation_GetThreadRedirects_Call) RunAndReturn(run func(context.Context, string) ([]models.ThreadRedirect, error)) *Moderation_GetThreadRedirects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockThread provides a mock function with given fields: ctx, key, locked, actor, reason
+func (_m *Moderation) LockThread(ctx context.Context, key string, locked bool, actor *models.Actor, reason string) error {
+	ret := _m.Called(ctx, key, locked, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockThread")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, *models.Actor, string) error); ok {
+		r0 = rf(ctx, key, locked, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Moderation_LockThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockThread'
+type Moderation_LockThread_Call struct {
+	*mock.Call
+}
+
+// LockThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - locked bool
+//   - actor *models.Actor
+//   - reason string
+func (_e *Moderation_Expecter) LockThread(ctx interface{}, key interface{}, locked interface{}, actor interface{}, reason interface{}) *Moderation_LockThread_Call {
+	return &Moderation_LockThread_Call{Call: _e.mock.On("LockThread", ctx, key, locked, actor, reason)}
+}
+
+func (_c *Moderation_LockThread_Call) Run(run func(ctx context.Context, key string, locked bool, actor *models.Actor, reason string)) *Moderation_LockThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(*models.Actor), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_LockThread_Call) Return(_a0 error) *Moderation_LockThread_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Moderation_LockThread_Call) RunAndReturn(run func(context.Context, string, bool, *models.Actor, string) error) *Moderation_LockThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeThreads provides a mock function with given fields: ctx, from, into, actor, reason
+func (_m *Moderation) MergeThreads(ctx context.Context, from string, into string, actor *models.Actor, reason string) (int64, error) {
+	ret := _m.Called(ctx, from, into, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeThreads")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.Actor, string) (int64, error)); ok {
+		return rf(ctx, from, into, actor, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.Actor, string) int64); ok {
+		r0 = rf(ctx, from, into, actor, reason)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.Actor, string) error); ok {
+		r1 = rf(ctx, from, into, actor, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderation_MergeThreads_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeThreads'
+type Moderation_MergeThreads_Call struct {
+	*mock.Call
+}
+
+// MergeThreads is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - into string
+//   - actor *models.Actor
+//   - reason string
+func (_e *Moderation_Expecter) MergeThreads(ctx interface{}, from interface{}, into interface{}, actor interface{}, reason interface{}) *Moderation_MergeThreads_Call {
+	return &Moderation_MergeThreads_Call{Call: _e.mock.On("MergeThreads", ctx, from, into, actor, reason)}
+}
+
+func (_c *Moderation_MergeThreads_Call) Run(run func(ctx context.Context, from string, into string, actor *models.Actor, reason string)) *Moderation_MergeThreads_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.Actor), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_MergeThreads_Call) Return(_a0 int64, _a1 error) *Moderation_MergeThreads_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Moderation_MergeThreads_Call) RunAndReturn(run func(context.Context, string, string, *models.Actor, string) (int64, error)) *Moderation_MergeThreads_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pin provides a mock function with given fields: ctx, id, actor, reason
+func (_m *Moderation) Pin(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	ret := _m.Called(ctx, id, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor, string) error); ok {
+		r0 = rf(ctx, id, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Moderation_Pin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pin'
+type Moderation_Pin_Call struct {
+	*mock.Call
+}
+
+// Pin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - actor *models.Actor
+//   - reason string
+func (_e *Moderation_Expecter) Pin(ctx interface{}, id interface{}, actor interface{}, reason interface{}) *Moderation_Pin_Call {
+	return &Moderation_Pin_Call{Call: _e.mock.On("Pin", ctx, id, actor, reason)}
+}
+
+func (_c *Moderation_Pin_Call) Run(run func(ctx context.Context, id int64, actor *models.Actor, reason string)) *Moderation_Pin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.Actor), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_Pin_Call) Return(_a0 error) *Moderation_Pin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Moderation_Pin_Call) RunAndReturn(run func(context.Context, int64, *models.Actor, string) error) *Moderation_Pin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reject provides a mock function with given fields: ctx, id, actor, reason
+func (_m *Moderation) Reject(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	ret := _m.Called(ctx, id, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor, string) error); ok {
+		r0 = rf(ctx, id, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Moderation_Reject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reject'
+type Moderation_Reject_Call struct {
+	*mock.Call
+}
+
+// Reject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - actor *models.Actor
+//   - reason string
+func (_e *Moderation_Expecter) Reject(ctx interface{}, id interface{}, actor interface{}, reason interface{}) *Moderation_Reject_Call {
+	return &Moderation_Reject_Call{Call: _e.mock.On("Reject", ctx, id, actor, reason)}
+}
+
+func (_c *Moderation_Reject_Call) Run(run func(ctx context.Context, id int64, actor *models.Actor, reason string)) *Moderation_Reject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.Actor), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_Reject_Call) Return(_a0 error) *Moderation_Reject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Moderation_Reject_Call) RunAndReturn(run func(context.Context, int64, *models.Actor, string) error) *Moderation_Reject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Report provides a mock function with given fields: ctx, report
+func (_m *Moderation) Report(ctx context.Context, report *models.Report) error {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Report) error); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Moderation_Report_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Report'
+type Moderation_Report_Call struct {
+	*mock.Call
+}
+
+// Report is a helper method to define mock.On call
+//   - ctx context.Context
+//   - report *models.Report
+func (_e *Moderation_Expecter) Report(ctx interface{}, report interface{}) *Moderation_Report_Call {
+	return &Moderation_Report_Call{Call: _e.mock.On("Report", ctx, report)}
+}
+
+func (_c *Moderation_Report_Call) Run(run func(ctx context.Context, report *models.Report)) *Moderation_Report_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Report))
+	})
+	return _c
+}
+
+func (_c *Moderation_Report_Call) Return(_a0 error) *Moderation_Report_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Moderation_Report_Call) RunAndReturn(run func(context.Context, *models.Report) error) *Moderation_Report_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SplitThread provides a mock function with given fields: ctx, id, key, actor, reason
+func (_m *Moderation) SplitThread(ctx context.Context, id int64, key string, actor *models.Actor, reason string) (*models.Comment, error) {
+	ret := _m.Called(ctx, id, key, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitThread")
+	}
+
+	var r0 *models.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *models.Actor, string) (*models.Comment, error)); ok {
+		return rf(ctx, id, key, actor, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *models.Actor, string) *models.Comment); ok {
+		r0 = rf(ctx, id, key, actor, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, *models.Actor, string) error); ok {
+		r1 = rf(ctx, id, key, actor, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderation_SplitThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitThread'
+type Moderation_SplitThread_Call struct {
+	*mock.Call
+}
+
+// SplitThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - key string
+//   - actor *models.Actor
+//   - reason string
+func (_e *Moderation_Expecter) SplitThread(ctx interface{}, id interface{}, key interface{}, actor interface{}, reason interface{}) *Moderation_SplitThread_Call {
+	return &Moderation_SplitThread_Call{Call: _e.mock.On("SplitThread", ctx, id, key, actor, reason)}
+}
+
+func (_c *Moderation_SplitThread_Call) Run(run func(ctx context.Context, id int64, key string, actor *models.Actor, reason string)) *Moderation_SplitThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(*models.Actor), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_SplitThread_Call) Return(_a0 *models.Comment, _a1 error) *Moderation_SplitThread_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Moderation_SplitThread_Call) RunAndReturn(run func(context.Context, int64, string, *models.Actor, string) (*models.Comment, error)) *Moderation_SplitThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unpin provides a mock function with given fields: ctx, id, actor, reason
+func (_m *Moderation) Unpin(ctx context.Context, id int64, actor *models.Actor, reason string) error {
+	ret := _m.Called(ctx, id, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unpin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.Actor, string) error); ok {
+		r0 = rf(ctx, id, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Moderation_Unpin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unpin'
+type Moderation_Unpin_Call struct {
+	*mock.Call
+}
+
+// Unpin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - actor *models.Actor
+//   - reason string
+func (_e *Moderation_Expecter) Unpin(ctx interface{}, id interface{}, actor interface{}, reason interface{}) *Moderation_Unpin_Call {
+	return &Moderation_Unpin_Call{Call: _e.mock.On("Unpin", ctx, id, actor, reason)}
+}
+
+func (_c *Moderation_Unpin_Call) Run(run func(ctx context.Context, id int64, actor *models.Actor, reason string)) *Moderation_Unpin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*models.Actor), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Moderation_Unpin_Call) Return(_a0 error) *Moderation_Unpin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Moderation_Unpin_Call) RunAndReturn(run func(context.Context, int64, *models.Actor, string) error) *Moderation_Unpin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateThread provides a mock function with given fields: ctx, thread, actor
+func (_m *Moderation) UpdateThread(ctx context.Context, thread *models.Thread, actor *models.Actor) error {
+	ret := _m.Called(ctx, thread, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateThread")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Thread, *models.Actor) error); ok {
+		r0 = rf(ctx, thread, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Moderation_UpdateThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateThread'
+type Moderation_UpdateThread_Call struct {
+	*mock.Call
+}
+
+// UpdateThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - thread *models.Thread
+//   - actor *models.Actor
+func (_e *Moderation_Expecter) UpdateThread(ctx interface{}, thread interface{}, actor interface{}) *Moderation_UpdateThread_Call {
+	return &Moderation_UpdateThread_Call{Call: _e.mock.On("UpdateThread", ctx, thread, actor)}
+}
+
+func (_c *Moderation_UpdateThread_Call) Run(run func(ctx context.Context, thread *models.Thread, actor *models.Actor)) *Moderation_UpdateThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Thread), args[2].(*models.Actor))
+	})
+	return _c
+}
+
+func (_c *Moderation_UpdateThread_Call) Return(_a0 error) *Moderation_UpdateThread_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Moderation_UpdateThread_Call) RunAndReturn(run func(context.Context, *models.Thread, *models.Actor) error) *Moderation_UpdateThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewModeration creates a new instance of Moderation. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewModeration(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Moderation {
+	mock := &Moderation{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
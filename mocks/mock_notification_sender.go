@@ -0,0 +1,86 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// NotificationSender is an autogenerated mock type for the NotificationSender type
+type NotificationSender struct {
+	mock.Mock
+}
+
+type NotificationSender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotificationSender) EXPECT() *NotificationSender_Expecter {
+	return &NotificationSender_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: ctx, to, n
+func (_m *NotificationSender) Send(ctx context.Context, to *models.Recipient, n *models.Notification) error {
+	ret := _m.Called(ctx, to, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Recipient, *models.Notification) error); ok {
+		r0 = rf(ctx, to, n)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationSender_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type NotificationSender_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - to *models.Recipient
+//   - n *models.Notification
+func (_e *NotificationSender_Expecter) Send(ctx interface{}, to interface{}, n interface{}) *NotificationSender_Send_Call {
+	return &NotificationSender_Send_Call{Call: _e.mock.On("Send", ctx, to, n)}
+}
+
+func (_c *NotificationSender_Send_Call) Run(run func(ctx context.Context, to *models.Recipient, n *models.Notification)) *NotificationSender_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Recipient), args[2].(*models.Notification))
+	})
+	return _c
+}
+
+func (_c *NotificationSender_Send_Call) Return(_a0 error) *NotificationSender_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationSender_Send_Call) RunAndReturn(run func(context.Context, *models.Recipient, *models.Notification) error) *NotificationSender_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotificationSender creates a new instance of NotificationSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationSender {
+	mock := &NotificationSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,330 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// Notifications is an autogenerated mock type for the Notifications type
+type Notifications struct {
+	mock.Mock
+}
+
+type Notifications_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Notifications) EXPECT() *Notifications_Expecter {
+	return &Notifications_Expecter{mock: &_m.Mock}
+}
+
+// GetUnread provides a mock function with given fields: ctx, user, pag
+func (_m *Notifications) GetUnread(ctx context.Context, user string, pag *models.PagParam) (*models.NotificationsRes, error) {
+	ret := _m.Called(ctx, user, pag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnread")
+	}
+
+	var r0 *models.NotificationsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PagParam) (*models.NotificationsRes, error)); ok {
+		return rf(ctx, user, pag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PagParam) *models.NotificationsRes); ok {
+		r0 = rf(ctx, user, pag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PagParam) error); ok {
+		r1 = rf(ctx, user, pag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Notifications_GetUnread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnread'
+type Notifications_GetUnread_Call struct {
+	*mock.Call
+}
+
+// GetUnread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - pag *models.PagParam
+func (_e *Notifications_Expecter) GetUnread(ctx interface{}, user interface{}, pag interface{}) *Notifications_GetUnread_Call {
+	return &Notifications_GetUnread_Call{Call: _e.mock.On("GetUnread", ctx, user, pag)}
+}
+
+func (_c *Notifications_GetUnread_Call) Run(run func(ctx context.Context, user string, pag *models.PagParam)) *Notifications_GetUnread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PagParam))
+	})
+	return _c
+}
+
+func (_c *Notifications_GetUnread_Call) Return(_a0 *models.NotificationsRes, _a1 error) *Notifications_GetUnread_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Notifications_GetUnread_Call) RunAndReturn(run func(context.Context, string, *models.PagParam) (*models.NotificationsRes, error)) *Notifications_GetUnread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAllRead provides a mock function with given fields: ctx, user
+func (_m *Notifications) MarkAllRead(ctx context.Context, user string) (int, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAllRead")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Notifications_MarkAllRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAllRead'
+type Notifications_MarkAllRead_Call struct {
+	*mock.Call
+}
+
+// MarkAllRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *Notifications_Expecter) MarkAllRead(ctx interface{}, user interface{}) *Notifications_MarkAllRead_Call {
+	return &Notifications_MarkAllRead_Call{Call: _e.mock.On("MarkAllRead", ctx, user)}
+}
+
+func (_c *Notifications_MarkAllRead_Call) Run(run func(ctx context.Context, user string)) *Notifications_MarkAllRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Notifications_MarkAllRead_Call) Return(_a0 int, _a1 error) *Notifications_MarkAllRead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Notifications_MarkAllRead_Call) RunAndReturn(run func(context.Context, string) (int, error)) *Notifications_MarkAllRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRead provides a mock function with given fields: ctx, user, id
+func (_m *Notifications) MarkRead(ctx context.Context, user string, id int64) error {
+	ret := _m.Called(ctx, user, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, user, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Notifications_MarkRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRead'
+type Notifications_MarkRead_Call struct {
+	*mock.Call
+}
+
+// MarkRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - id int64
+func (_e *Notifications_Expecter) MarkRead(ctx interface{}, user interface{}, id interface{}) *Notifications_MarkRead_Call {
+	return &Notifications_MarkRead_Call{Call: _e.mock.On("MarkRead", ctx, user, id)}
+}
+
+func (_c *Notifications_MarkRead_Call) Run(run func(ctx context.Context, user string, id int64)) *Notifications_MarkRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Notifications_MarkRead_Call) Return(_a0 error) *Notifications_MarkRead_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Notifications_MarkRead_Call) RunAndReturn(run func(context.Context, string, int64) error) *Notifications_MarkRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotifyMentions provides a mock function with given fields: ctx, comment
+func (_m *Notifications) NotifyMentions(ctx context.Context, comment *models.Comment) error {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyMentions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Notifications_NotifyMentions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyMentions'
+type Notifications_NotifyMentions_Call struct {
+	*mock.Call
+}
+
+// NotifyMentions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *models.Comment
+func (_e *Notifications_Expecter) NotifyMentions(ctx interface{}, comment interface{}) *Notifications_NotifyMentions_Call {
+	return &Notifications_NotifyMentions_Call{Call: _e.mock.On("NotifyMentions", ctx, comment)}
+}
+
+func (_c *Notifications_NotifyMentions_Call) Run(run func(ctx context.Context, comment *models.Comment)) *Notifications_NotifyMentions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Comment))
+	})
+	return _c
+}
+
+func (_c *Notifications_NotifyMentions_Call) Return(_a0 error) *Notifications_NotifyMentions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Notifications_NotifyMentions_Call) RunAndReturn(run func(context.Context, *models.Comment) error) *Notifications_NotifyMentions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotifyReply provides a mock function with given fields: ctx, reply, parent
+func (_m *Notifications) NotifyReply(ctx context.Context, reply *models.Comment, parent *models.Comment) error {
+	ret := _m.Called(ctx, reply, parent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyReply")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment, *models.Comment) error); ok {
+		r0 = rf(ctx, reply, parent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Notifications_NotifyReply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyReply'
+type Notifications_NotifyReply_Call struct {
+	*mock.Call
+}
+
+// NotifyReply is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reply *models.Comment
+//   - parent *models.Comment
+func (_e *Notifications_Expecter) NotifyReply(ctx interface{}, reply interface{}, parent interface{}) *Notifications_NotifyReply_Call {
+	return &Notifications_NotifyReply_Call{Call: _e.mock.On("NotifyReply", ctx, reply, parent)}
+}
+
+func (_c *Notifications_NotifyReply_Call) Run(run func(ctx context.Context, reply *models.Comment, parent *models.Comment)) *Notifications_NotifyReply_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Comment), args[2].(*models.Comment))
+	})
+	return _c
+}
+
+func (_c *Notifications_NotifyReply_Call) Return(_a0 error) *Notifications_NotifyReply_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Notifications_NotifyReply_Call) RunAndReturn(run func(context.Context, *models.Comment, *models.Comment) error) *Notifications_NotifyReply_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *Notifications) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Notifications_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type Notifications_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Notifications_Expecter) Run(ctx interface{}) *Notifications_Run_Call {
+	return &Notifications_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *Notifications_Run_Call) Run(run func(ctx context.Context)) *Notifications_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Notifications_Run_Call) Return() *Notifications_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Notifications_Run_Call) RunAndReturn(run func(context.Context)) *Notifications_Run_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewNotifications creates a new instance of Notifications. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifications(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Notifications {
+	mock := &Notifications{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,105 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sunr3d/comment-tree/models"
+
+	time "time"
+)
+
+// RateLimiter is an autogenerated mock type for the RateLimiter type
+type RateLimiter struct {
+	mock.Mock
+}
+
+type RateLimiter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RateLimiter) EXPECT() *RateLimiter_Expecter {
+	return &RateLimiter_Expecter{mock: &_m.Mock}
+}
+
+// Allow provides a mock function with given fields: ctx, key, limit
+func (_m *RateLimiter) Allow(ctx context.Context, key string, limit models.RateLimit) (bool, time.Duration, error) {
+	ret := _m.Called(ctx, key, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Allow")
+	}
+
+	var r0 bool
+	var r1 time.Duration
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.RateLimit) (bool, time.Duration, error)); ok {
+		return rf(ctx, key, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.RateLimit) bool); ok {
+		r0 = rf(ctx, key, limit)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.RateLimit) time.Duration); ok {
+		r1 = rf(ctx, key, limit)
+	} else {
+		r1 = ret.Get(1).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.RateLimit) error); ok {
+		r2 = rf(ctx, key, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RateLimiter_Allow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Allow'
+type RateLimiter_Allow_Call struct {
+	*mock.Call
+}
+
+// Allow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - limit models.RateLimit
+func (_e *RateLimiter_Expecter) Allow(ctx interface{}, key interface{}, limit interface{}) *RateLimiter_Allow_Call {
+	return &RateLimiter_Allow_Call{Call: _e.mock.On("Allow", ctx, key, limit)}
+}
+
+func (_c *RateLimiter_Allow_Call) Run(run func(ctx context.Context, key string, limit models.RateLimit)) *RateLimiter_Allow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.RateLimit))
+	})
+	return _c
+}
+
+func (_c *RateLimiter_Allow_Call) Return(_a0 bool, _a1 time.Duration, _a2 error) *RateLimiter_Allow_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *RateLimiter_Allow_Call) RunAndReturn(run func(context.Context, string, models.RateLimit) (bool, time.Duration, error)) *RateLimiter_Allow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRateLimiter creates a new instance of RateLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimiter {
+	mock := &RateLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,159 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// Reactions is an autogenerated mock type for the Reactions type
+type Reactions struct {
+	mock.Mock
+}
+
+type Reactions_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Reactions) EXPECT() *Reactions_Expecter {
+	return &Reactions_Expecter{mock: &_m.Mock}
+}
+
+// React provides a mock function with given fields: ctx, commentID, user, emoji
+func (_m *Reactions) React(ctx context.Context, commentID int64, user string, emoji string) ([]models.Reaction, error) {
+	ret := _m.Called(ctx, commentID, user, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for React")
+	}
+
+	var r0 []models.Reaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) ([]models.Reaction, error)); ok {
+		return rf(ctx, commentID, user, emoji)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) []models.Reaction); ok {
+		r0 = rf(ctx, commentID, user, emoji)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string) error); ok {
+		r1 = rf(ctx, commentID, user, emoji)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reactions_React_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'React'
+type Reactions_React_Call struct {
+	*mock.Call
+}
+
+// React is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commentID int64
+//   - user string
+//   - emoji string
+func (_e *Reactions_Expecter) React(ctx interface{}, commentID interface{}, user interface{}, emoji interface{}) *Reactions_React_Call {
+	return &Reactions_React_Call{Call: _e.mock.On("React", ctx, commentID, user, emoji)}
+}
+
+func (_c *Reactions_React_Call) Run(run func(ctx context.Context, commentID int64, user string, emoji string)) *Reactions_React_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Reactions_React_Call) Return(_a0 []models.Reaction, _a1 error) *Reactions_React_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Reactions_React_Call) RunAndReturn(run func(context.Context, int64, string, string) ([]models.Reaction, error)) *Reactions_React_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unreact provides a mock function with given fields: ctx, commentID, user, emoji
+func (_m *Reactions) Unreact(ctx context.Context, commentID int64, user string, emoji string) ([]models.Reaction, error) {
+	ret := _m.Called(ctx, commentID, user, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unreact")
+	}
+
+	var r0 []models.Reaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) ([]models.Reaction, error)); ok {
+		return rf(ctx, commentID, user, emoji)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) []models.Reaction); ok {
+		r0 = rf(ctx, commentID, user, emoji)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string) error); ok {
+		r1 = rf(ctx, commentID, user, emoji)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reactions_Unreact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unreact'
+type Reactions_Unreact_Call struct {
+	*mock.Call
+}
+
+// Unreact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commentID int64
+//   - user string
+//   - emoji string
+func (_e *Reactions_Expecter) Unreact(ctx interface{}, commentID interface{}, user interface{}, emoji interface{}) *Reactions_Unreact_Call {
+	return &Reactions_Unreact_Call{Call: _e.mock.On("Unreact", ctx, commentID, user, emoji)}
+}
+
+func (_c *Reactions_Unreact_Call) Run(run func(ctx context.Context, commentID int64, user string, emoji string)) *Reactions_Unreact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Reactions_Unreact_Call) Return(_a0 []models.Reaction, _a1 error) *Reactions_Unreact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Reactions_Unreact_Call) RunAndReturn(run func(context.Context, int64, string, string) ([]models.Reaction, error)) *Reactions_Unreact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReactions creates a new instance of Reactions. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReactions(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Reactions {
+	mock := &Reactions{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
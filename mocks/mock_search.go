@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// Search is an autogenerated mock type for the Search type
+type Search struct {
+	mock.Mock
+}
+
+type Search_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Search) EXPECT() *Search_Expecter {
+	return &Search_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function with given fields: ctx, q
+func (_m *Search) Search(ctx context.Context, q *models.SearchQuery) (*models.SearchRes, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *models.SearchRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SearchQuery) (*models.SearchRes, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SearchQuery) *models.SearchRes); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SearchRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SearchQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type Search_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q *models.SearchQuery
+func (_e *Search_Expecter) Search(ctx interface{}, q interface{}) *Search_Search_Call {
+	return &Search_Search_Call{Call: _e.mock.On("Search", ctx, q)}
+}
+
+func (_c *Search_Search_Call) Run(run func(ctx context.Context, q *models.SearchQuery)) *Search_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SearchQuery))
+	})
+	return _c
+}
+
+func (_c *Search_Search_Call) Return(_a0 *models.SearchRes, _a1 error) *Search_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Search_Search_Call) RunAndReturn(run func(context.Context, *models.SearchQuery) (*models.SearchRes, error)) *Search_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSearch creates a new instance of Search. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearch(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Search {
+	mock := &Search{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
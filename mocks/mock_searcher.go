@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// Searcher is an autogenerated mock type for the Searcher type
+type Searcher struct {
+	mock.Mock
+}
+
+type Searcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Searcher) EXPECT() *Searcher_Expecter {
+	return &Searcher_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function with given fields: ctx, q
+func (_m *Searcher) Search(ctx context.Context, q *models.SearchQuery) (*models.SearchRes, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *models.SearchRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SearchQuery) (*models.SearchRes, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SearchQuery) *models.SearchRes); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SearchRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SearchQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Searcher_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type Searcher_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q *models.SearchQuery
+func (_e *Searcher_Expecter) Search(ctx interface{}, q interface{}) *Searcher_Search_Call {
+	return &Searcher_Search_Call{Call: _e.mock.On("Search", ctx, q)}
+}
+
+func (_c *Searcher_Search_Call) Run(run func(ctx context.Context, q *models.SearchQuery)) *Searcher_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SearchQuery))
+	})
+	return _c
+}
+
+func (_c *Searcher_Search_Call) Return(_a0 *models.SearchRes, _a1 error) *Searcher_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Searcher_Search_Call) RunAndReturn(run func(context.Context, *models.SearchQuery) (*models.SearchRes, error)) *Searcher_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSearcher creates a new instance of Searcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Searcher {
+	mock := &Searcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
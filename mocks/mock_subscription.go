@@ -0,0 +1,114 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// Subscription is an autogenerated mock type for the Subscription type
+type Subscription struct {
+	mock.Mock
+}
+
+type Subscription_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Subscription) EXPECT() *Subscription_Expecter {
+	return &Subscription_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with no fields
+func (_m *Subscription) Close() {
+	_m.Called()
+}
+
+// Subscription_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type Subscription_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *Subscription_Expecter) Close() *Subscription_Close_Call {
+	return &Subscription_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *Subscription_Close_Call) Run(run func()) *Subscription_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Subscription_Close_Call) Return() *Subscription_Close_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Subscription_Close_Call) RunAndReturn(run func()) *Subscription_Close_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Events provides a mock function with no fields
+func (_m *Subscription) Events() <-chan models.CommentEvent {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Events")
+	}
+
+	var r0 <-chan models.CommentEvent
+	if rf, ok := ret.Get(0).(func() <-chan models.CommentEvent); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan models.CommentEvent)
+		}
+	}
+
+	return r0
+}
+
+// Subscription_Events_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Events'
+type Subscription_Events_Call struct {
+	*mock.Call
+}
+
+// Events is a helper method to define mock.On call
+func (_e *Subscription_Expecter) Events() *Subscription_Events_Call {
+	return &Subscription_Events_Call{Call: _e.mock.On("Events")}
+}
+
+func (_c *Subscription_Events_Call) Run(run func()) *Subscription_Events_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Subscription_Events_Call) Return(_a0 <-chan models.CommentEvent) *Subscription_Events_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Subscription_Events_Call) RunAndReturn(run func() <-chan models.CommentEvent) *Subscription_Events_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSubscription creates a new instance of Subscription. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSubscription(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Subscription {
+	mock := &Subscription{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,376 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/comment-tree/models"
+)
+
+// Webhooks is an autogenerated mock type for the Webhooks type
+type Webhooks struct {
+	mock.Mock
+}
+
+type Webhooks_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Webhooks) EXPECT() *Webhooks_Expecter {
+	return &Webhooks_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *Webhooks) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Webhooks_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type Webhooks_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhook *models.Webhook
+func (_e *Webhooks_Expecter) CreateWebhook(ctx interface{}, webhook interface{}) *Webhooks_CreateWebhook_Call {
+	return &Webhooks_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, webhook)}
+}
+
+func (_c *Webhooks_CreateWebhook_Call) Run(run func(ctx context.Context, webhook *models.Webhook)) *Webhooks_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Webhook))
+	})
+	return _c
+}
+
+func (_c *Webhooks_CreateWebhook_Call) Return(_a0 error) *Webhooks_CreateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Webhooks_CreateWebhook_Call) RunAndReturn(run func(context.Context, *models.Webhook) error) *Webhooks_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *Webhooks) DeleteWebhook(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Webhooks_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type Webhooks_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *Webhooks_Expecter) DeleteWebhook(ctx interface{}, id interface{}) *Webhooks_DeleteWebhook_Call {
+	return &Webhooks_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, id)}
+}
+
+func (_c *Webhooks_DeleteWebhook_Call) Run(run func(ctx context.Context, id int64)) *Webhooks_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Webhooks_DeleteWebhook_Call) Return(_a0 error) *Webhooks_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Webhooks_DeleteWebhook_Call) RunAndReturn(run func(context.Context, int64) error) *Webhooks_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, filter
+func (_m *Webhooks) GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) (*models.DeliveriesRes, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 *models.DeliveriesRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeliveryFilter) (*models.DeliveriesRes, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeliveryFilter) *models.DeliveriesRes); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveriesRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DeliveryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Webhooks_GetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveries'
+type Webhooks_GetDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.DeliveryFilter
+func (_e *Webhooks_Expecter) GetDeliveries(ctx interface{}, filter interface{}) *Webhooks_GetDeliveries_Call {
+	return &Webhooks_GetDeliveries_Call{Call: _e.mock.On("GetDeliveries", ctx, filter)}
+}
+
+func (_c *Webhooks_GetDeliveries_Call) Run(run func(ctx context.Context, filter *models.DeliveryFilter)) *Webhooks_GetDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.DeliveryFilter))
+	})
+	return _c
+}
+
+func (_c *Webhooks_GetDeliveries_Call) Return(_a0 *models.DeliveriesRes, _a1 error) *Webhooks_GetDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Webhooks_GetDeliveries_Call) RunAndReturn(run func(context.Context, *models.DeliveryFilter) (*models.DeliveriesRes, error)) *Webhooks_GetDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhooks provides a mock function with given fields: ctx
+func (_m *Webhooks) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooks")
+	}
+
+	var r0 []models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Webhooks_GetWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooks'
+type Webhooks_GetWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Webhooks_Expecter) GetWebhooks(ctx interface{}) *Webhooks_GetWebhooks_Call {
+	return &Webhooks_GetWebhooks_Call{Call: _e.mock.On("GetWebhooks", ctx)}
+}
+
+func (_c *Webhooks_GetWebhooks_Call) Run(run func(ctx context.Context)) *Webhooks_GetWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Webhooks_GetWebhooks_Call) Return(_a0 []models.Webhook, _a1 error) *Webhooks_GetWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Webhooks_GetWebhooks_Call) RunAndReturn(run func(context.Context) ([]models.Webhook, error)) *Webhooks_GetWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Redeliver provides a mock function with given fields: ctx, webhookID, deliveryID
+func (_m *Webhooks) Redeliver(ctx context.Context, webhookID int64, deliveryID int64) error {
+	ret := _m.Called(ctx, webhookID, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redeliver")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, webhookID, deliveryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Webhooks_Redeliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redeliver'
+type Webhooks_Redeliver_Call struct {
+	*mock.Call
+}
+
+// Redeliver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID int64
+//   - deliveryID int64
+func (_e *Webhooks_Expecter) Redeliver(ctx interface{}, webhookID interface{}, deliveryID interface{}) *Webhooks_Redeliver_Call {
+	return &Webhooks_Redeliver_Call{Call: _e.mock.On("Redeliver", ctx, webhookID, deliveryID)}
+}
+
+func (_c *Webhooks_Redeliver_Call) Run(run func(ctx context.Context, webhookID int64, deliveryID int64)) *Webhooks_Redeliver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Webhooks_Redeliver_Call) Return(_a0 error) *Webhooks_Redeliver_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Webhooks_Redeliver_Call) RunAndReturn(run func(context.Context, int64, int64) error) *Webhooks_Redeliver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *Webhooks) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Webhooks_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type Webhooks_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Webhooks_Expecter) Run(ctx interface{}) *Webhooks_Run_Call {
+	return &Webhooks_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *Webhooks_Run_Call) Run(run func(ctx context.Context)) *Webhooks_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Webhooks_Run_Call) Return() *Webhooks_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Webhooks_Run_Call) RunAndReturn(run func(context.Context)) *Webhooks_Run_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *Webhooks) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Webhooks_UpdateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhook'
+type Webhooks_UpdateWebhook_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhook *models.Webhook
+func (_e *Webhooks_Expecter) UpdateWebhook(ctx interface{}, webhook interface{}) *Webhooks_UpdateWebhook_Call {
+	return &Webhooks_UpdateWebhook_Call{Call: _e.mock.On("UpdateWebhook", ctx, webhook)}
+}
+
+func (_c *Webhooks_UpdateWebhook_Call) Run(run func(ctx context.Context, webhook *models.Webhook)) *Webhooks_UpdateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Webhook))
+	})
+	return _c
+}
+
+func (_c *Webhooks_UpdateWebhook_Call) Return(_a0 error) *Webhooks_UpdateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Webhooks_UpdateWebhook_Call) RunAndReturn(run func(context.Context, *models.Webhook) error) *Webhooks_UpdateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhooks creates a new instance of Webhooks. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhooks(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Webhooks {
+	mock := &Webhooks{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
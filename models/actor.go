@@ -0,0 +1,48 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Actor - клиент, выполняющий запрос. Анонимный клиент определяется только по IP.
+type Actor struct {
+	User   string
+	Role   string
+	APIKey string
+	IP     string
+}
+
+// IsModerator - модератор или администратор: администратору доступно все, что и модератору.
+func (a *Actor) IsModerator() bool {
+	return a != nil && (a.Role == RoleModerator || a.Role == RoleAdmin)
+}
+
+func (a *Actor) IsAdmin() bool {
+	return a != nil && a.Role == RoleAdmin
+}
+
+func (a *Actor) IsAuthenticated() bool {
+	return a != nil && a.APIKey != ""
+}
+
+// Key - идентификатор клиента для лимитов, жалоб и журнала аудита.
+// Сам API-ключ не сохраняется, вместо него используется отпечаток.
+func (a *Actor) Key() string {
+	switch {
+	case a == nil:
+		return "anonymous"
+	case a.User != "":
+		return "user:" + a.User
+	case a.APIKey != "":
+		sum := sha256.Sum256([]byte(a.APIKey))
+		return "key:" + hex.EncodeToString(sum[:4])
+	default:
+		return "ip:" + a.IP
+	}
+}
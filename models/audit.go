@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+const (
+	AuditTargetComment = "comment"
+	AuditTargetThread  = "thread"
+	AuditTargetAuthor  = "author"
+
+	AuditActorSystem = "system"
+)
+
+const (
+	ActionDelete         = "delete"
+	ActionRestore        = "restore"
+	ActionEdit           = "edit"
+	ActionApprove        = "approve"
+	ActionReject         = "reject"
+	ActionAutoHide       = "auto_hide"
+	ActionPin            = "pin"
+	ActionUnpin          = "unpin"
+	ActionAcceptAnswer   = "accept_answer"
+	ActionUnacceptAnswer = "unaccept_answer"
+	ActionMove           = "move"
+	ActionSplit          = "split"
+	ActionMerge          = "merge"
+	ActionLock           = "lock"
+	ActionUnlock         = "unlock"
+	ActionThreadSettings = "thread_settings"
+	ActionAuthorProfile  = "author_profile"
+)
+
+// AuditEntry - запись журнала. Before/After (снимки объекта) заполняет репозиторий в той же транзакции.
+type AuditEntry struct {
+	ID         int64
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Before     json.RawMessage
+	After      json.RawMessage
+	CreatedAt  time.Time
+}
+
+// NewCommentAudit - запись аудита действия action над комментарием id; actor - Actor.Key() или AuditActorSystem.
+func NewCommentAudit(actor, action string, id int64, reason string) *AuditEntry {
+	return &AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		TargetType: AuditTargetComment,
+		TargetID:   strconv.FormatInt(id, 10),
+		Reason:     reason,
+	}
+}
+
+// NewThreadAudit - запись аудита действия action над тредом key.
+func NewThreadAudit(actor, action, key, reason string) *AuditEntry {
+	return &AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		TargetType: AuditTargetThread,
+		TargetID:   key,
+		Reason:     reason,
+	}
+}
+
+// NewAuthorAudit - запись аудита действия action над профилем автора id.
+func NewAuthorAudit(actor, action string, id int64, reason string) *AuditEntry {
+	return &AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		TargetType: AuditTargetAuthor,
+		TargetID:   strconv.FormatInt(id, 10),
+		Reason:     reason,
+	}
+}
+
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       time.Time
+	To         time.Time
+	Page       int
+	Limit      int
+}
+
+type AuditRes struct {
+	Entries []AuditEntry
+	Total   int
+	Page    int
+	Limit   int
+	Pages   int
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Ограничения профиля автора.
+const (
+	MaxDisplayNameLen = 100
+	MaxAvatarURLLen   = 2048
+)
+
+// Author - профиль автора комментариев. Username совпадает с Comment.Author и не меняется,
+// DisplayName показывается вместо него и по умолчанию равен Username.
+// CommentCount - число одобренных и не удаленных комментариев, его ведет база.
+type Author struct {
+	ID           int64
+	Username     string
+	DisplayName  string
+	AvatarURL    string
+	CommentCount int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -2,15 +2,73 @@ package models
 
 import "time"
 
+const DefaultThread = "default"
+
+type CommentStatus string
+
+const (
+	StatusPending  CommentStatus = "pending"
+	StatusApproved CommentStatus = "approved"
+	StatusRejected CommentStatus = "rejected"
+)
+
 type Comment struct {
 	ID        int64
 	ParentID  *int64
+	ThreadKey string
 	Content   string
 	Author    string
+	Status    CommentStatus
+	Language  string
+	Revision  int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
+	PinnedAt  *time.Time
 	Level     int
+
+	// AuthorID и AuthorName - профиль автора; имя не хранится в комментарии и читается при выборке
+	AuthorID   int64
+	AuthorName string
+
+	// AcceptedCommentID - принятый ответ в ветке корневого комментария
+	AcceptedCommentID *int64
+	// Accepted отмечает принятый ответ при выборке ветки; в таблице не хранится
+	Accepted bool
+
+	// Счетчики голосов; Score = Upvotes - Downvotes хранится в таблице для сортировки
+	Upvotes   int
+	Downvotes int
+	Score     int
+
+	// Mentions заполняется при создании и правке и сохраняется вместе с комментарием,
+	// при чтении загружается из comment_mentions
+	Mentions []Mention
+
+	// Quotes - цитируемые комментарии в порядке ссылок. При создании достаточно CommentID,
+	// остальные поля заполняются при проверке и чтении.
+	Quotes []Quote
+
+	// Reactions заполняется при чтении страницы комментариев с учетом PagParam.Viewer
+	Reactions []Reaction
+}
+
+// Режимы сортировки списков комментариев.
+const (
+	SortCreatedAsc    = "created_at_asc"
+	SortCreatedDesc   = "created_at_desc"
+	SortTop           = "top"
+	SortControversial = "controversial"
+	SortHot           = "hot"
+)
+
+// ValidSort сообщает, поддерживается ли режим сортировки.
+func ValidSort(sort string) bool {
+	switch sort {
+	case SortCreatedAsc, SortCreatedDesc, SortTop, SortControversial, SortHot:
+		return true
+	}
+	return false
 }
 
 type PagParam struct {
@@ -18,6 +76,11 @@ type PagParam struct {
 	Limit  int
 	Sort   string
 	Search string
+	Thread string
+	Viewer *Actor
+
+	// Unresolved оставляет только корневые комментарии без принятого ответа
+	Unresolved bool
 }
 
 type CommentsRes struct {
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidSort(t *testing.T) {
+	for _, sort := range []string{SortCreatedAsc, SortCreatedDesc, SortTop, SortControversial, SortHot} {
+		assert.True(t, ValidSort(sort), sort)
+	}
+	for _, sort := range []string{"", "created_at", "TOP", "score"} {
+		assert.False(t, ValidSort(sort), sort)
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventCreated  EventType = "created"
+	EventEdited   EventType = "edited"
+	EventDeleted  EventType = "deleted"
+	EventRestored EventType = "restored"
+	// EventReset - часть истории потеряна, клиенту нужно перезагрузить ветку целиком.
+	EventReset EventType = "reset"
+	// EventChanged - изменение, невидимое читателям (неодобренный комментарий, закрепление,
+	// профиль автора). Отдается только подпискам с EventFilter.Changes, например поисковому индексу.
+	EventChanged EventType = "changed"
+)
+
+type CommentEvent struct {
+	ID        int64
+	Type      EventType
+	Thread    string
+	CommentID int64
+	AuthorID  int64 // для EventChanged об изменении профиля автора
+	ParentID  *int64
+	Path      []int64 // предки комментария от корня до родителя
+	Comment   *Comment
+	CreatedAt time.Time
+}
+
+// EventFilter - подписка на тред и/или поддерево; пустые поля не ограничивают выборку.
+// Changes включает события EventChanged.
+type EventFilter struct {
+	Thread  string
+	Parent  int64
+	Changes bool
+}
+
+func (f EventFilter) Match(e *CommentEvent) bool {
+	if e.Type == EventReset {
+		return true
+	}
+	if e.Type == EventChanged && !f.Changes {
+		return false
+	}
+	if f.Thread != "" && e.Thread != f.Thread {
+		return false
+	}
+	if f.Parent != 0 && e.CommentID != f.Parent && !slices.Contains(e.Path, f.Parent) {
+		return false
+	}
+
+	return true
+}
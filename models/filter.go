@@ -0,0 +1,15 @@
+package models
+
+type FilterVerdict string
+
+const (
+	VerdictAccept FilterVerdict = "accept"
+	VerdictReject FilterVerdict = "reject"
+	VerdictHold   FilterVerdict = "hold"
+)
+
+type FilterResult struct {
+	Verdict FilterVerdict
+	Filter  string
+	Reason  string
+}
@@ -0,0 +1,56 @@
+package models
+
+import "unicode"
+
+// Языки комментариев. Конфигурация полнотекстового поиска для каждого языка задается
+// функцией comment_search_config в миграциях; у казахского в PostgreSQL нет стеммера,
+// поэтому он индексируется конфигурацией simple.
+const (
+	LanguageRussian = "ru"
+	LanguageEnglish = "en"
+	LanguageKazakh  = "kk"
+)
+
+// ValidLanguage сообщает, поддерживается ли язык комментария.
+func ValidLanguage(lang string) bool {
+	switch lang {
+	case LanguageRussian, LanguageEnglish, LanguageKazakh:
+		return true
+	}
+	return false
+}
+
+// kazakhLetters - буквы казахской кириллицы, которых нет в русском алфавите.
+var kazakhLetters = map[rune]bool{
+	'ә': true, 'ғ': true, 'қ': true, 'ң': true, 'ө': true, 'ұ': true, 'ү': true, 'һ': true, 'і': true,
+}
+
+// DetectLanguage определяет язык текста по алфавиту: кириллица с казахскими буквами - казахский,
+// остальная кириллица - русский, латиница - английский. Если букв нет или алфавиты встречаются
+// поровну, возвращается fallback.
+func DetectLanguage(text, fallback string) string {
+	var cyrillic, latin int
+	kazakh := false
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if kazakhLetters[unicode.ToLower(r)] {
+				kazakh = true
+			}
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	switch {
+	case cyrillic > latin && kazakh:
+		return LanguageKazakh
+	case cyrillic > latin:
+		return LanguageRussian
+	case latin > cyrillic:
+		return LanguageEnglish
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		fallback string
+		want     string
+	}{
+		{name: "русский", text: "Как построить дерево комментариев?", fallback: LanguageEnglish, want: LanguageRussian},
+		{name: "английский", text: "How to build a comment tree?", fallback: LanguageRussian, want: LanguageEnglish},
+		{name: "казахский", text: "Пікір ағашын қалай құруға болады?", fallback: LanguageRussian, want: LanguageKazakh},
+		{name: "казахские заглавные", text: "ӘЛЕМ", fallback: LanguageRussian, want: LanguageKazakh},
+		{name: "преобладает кириллица", text: "Используем PostgreSQL и рекурсивные запросы", fallback: LanguageEnglish, want: LanguageRussian},
+		{name: "без букв", text: "👍 123 !!!", fallback: LanguageKazakh, want: LanguageKazakh},
+		{name: "поровну", text: "да no", fallback: LanguageEnglish, want: LanguageEnglish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectLanguage(tt.text, tt.fallback))
+		})
+	}
+}
+
+func TestValidLanguage(t *testing.T) {
+	assert.True(t, ValidLanguage(LanguageRussian))
+	assert.True(t, ValidLanguage(LanguageKazakh))
+	assert.False(t, ValidLanguage(""))
+	assert.False(t, ValidLanguage("de"))
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+const maxMentionLen = 64
+
+// Mention - упоминание @username в тексте комментария. Offset и Length - в символах (рунах).
+type Mention struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+// ParseMentions находит упоминания в тексте. Блоки кода (```/~~~ и с отступом в 4 пробела)
+// и встроенный код в обратных кавычках пропускаются, как и адреса вида user@example.com.
+func ParseMentions(content string) []Mention {
+	var (
+		out      []Mention
+		offset   int
+		fence    string
+		prevText bool
+		indented bool
+	)
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		runes := []rune(line)
+		text := strings.TrimRight(line, "\r\n")
+		blank := strings.TrimSpace(text) == ""
+
+		switch {
+		case fence != "":
+			if closesFence(text, fence) {
+				fence = ""
+			}
+		case openingFence(text) != "":
+			fence = openingFence(text)
+		case blank:
+		case isIndentedCode(text) && (!prevText || indented):
+			indented = true
+		default:
+			indented = false
+			out = append(out, scanMentions([]rune(text), offset)...)
+		}
+
+		prevText = !blank && fence == "" && !indented
+		offset += len(runes)
+	}
+
+	return out
+}
+
+func scanMentions(line []rune, base int) []Mention {
+	var out []Mention
+
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '`':
+			if end := inlineCodeEnd(line, i); end > 0 {
+				i = end - 1
+			}
+		case '@':
+			if i > 0 && (isMentionRune(line[i-1]) || line[i-1] == '@') {
+				continue
+			}
+
+			end := i + 1
+			for end < len(line) && isMentionRune(line[end]) {
+				end++
+			}
+			// Точка или дефис в конце - знак препинания, а не часть имени
+			for end > i+1 && (line[end-1] == '.' || line[end-1] == '-') {
+				end--
+			}
+
+			name := line[i+1 : end]
+			if len(name) == 0 || len(name) > maxMentionLen || name[0] == '.' || name[0] == '-' {
+				i = end - 1
+				continue
+			}
+			if end < len(line) && line[end] == '@' {
+				i = end - 1
+				continue
+			}
+
+			out = append(out, Mention{Username: string(name), Offset: base + i, Length: end - i})
+			i = end - 1
+		}
+	}
+
+	return out
+}
+
+// inlineCodeEnd возвращает позицию после закрывающей последовательности обратных кавычек той же
+// длины или 0, если код не закрыт в этой строке.
+func inlineCodeEnd(line []rune, start int) int {
+	n := 0
+	for start+n < len(line) && line[start+n] == '`' {
+		n++
+	}
+
+	for i := start + n; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		run := 0
+		for i+run < len(line) && line[i+run] == '`' {
+			run++
+		}
+		if run == n {
+			return i + run
+		}
+		i += run
+	}
+
+	return 0
+}
+
+func openingFence(line string) string {
+	s := strings.TrimLeft(line, " ")
+	if len(line)-len(s) > 3 || len(s) < 3 || (s[0] != '`' && s[0] != '~') {
+		return ""
+	}
+
+	n := 0
+	for n < len(s) && s[n] == s[0] {
+		n++
+	}
+	if n < 3 || (s[0] == '`' && strings.ContainsRune(s[n:], '`')) {
+		return ""
+	}
+
+	return s[:n]
+}
+
+func closesFence(line, fence string) bool {
+	s := strings.TrimLeft(line, " ")
+	if len(line)-len(s) > 3 {
+		return false
+	}
+
+	n := 0
+	for n < len(s) && s[n] == fence[0] {
+		n++
+	}
+
+	return n >= len(fence) && strings.TrimSpace(s[n:]) == ""
+}
+
+func isIndentedCode(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+func isMentionRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
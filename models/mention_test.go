@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Mention
+	}{
+		{
+			name:    "простое упоминание",
+			content: "Привет, @alice!",
+			want:    []Mention{{Username: "alice", Offset: 8, Length: 6}},
+		},
+		{
+			name:    "несколько и кириллица",
+			content: "@боб и @carol.smith.",
+			want: []Mention{
+				{Username: "боб", Offset: 0, Length: 4},
+				{Username: "carol.smith", Offset: 7, Length: 12},
+			},
+		},
+		{
+			name:    "email не упоминание",
+			content: "пишите на support@example.com или @@bob",
+			want:    nil,
+		},
+		{
+			name:    "встроенный код",
+			content: "`@alice` и ``код с ` и @bob`` но @carol",
+			want:    []Mention{{Username: "carol", Offset: 33, Length: 6}},
+		},
+		{
+			name:    "незакрытая кавычка",
+			content: "`@alice",
+			want:    []Mention{{Username: "alice", Offset: 1, Length: 6}},
+		},
+		{
+			name:    "блок кода",
+			content: "до @alice\n```go\n// @bob\n```\nпосле @carol",
+			want: []Mention{
+				{Username: "alice", Offset: 3, Length: 6},
+				{Username: "carol", Offset: 34, Length: 6},
+			},
+		},
+		{
+			name:    "блок кода с тильдами не закрывается кавычками",
+			content: "~~~\n@alice\n```\n@bob\n~~~\n@carol",
+			want:    []Mention{{Username: "carol", Offset: 24, Length: 6}},
+		},
+		{
+			name:    "код с отступом",
+			content: "пример:\n\n    @alice\n\n@bob",
+			want:    []Mention{{Username: "bob", Offset: 21, Length: 4}},
+		},
+		{
+			name:    "отступ внутри абзаца - не код",
+			content: "текст\n    @alice",
+			want:    []Mention{{Username: "alice", Offset: 10, Length: 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseMentions(tt.content))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	NotificationReply   = "reply"
+	NotificationMention = "mention"
+)
+
+// Notification - уведомление об ответе или упоминании. Recipient и Actor - имена авторов
+// комментариев, получатель сравнивается без учета регистра.
+type Notification struct {
+	ID        int64
+	Recipient string
+	Type      string
+	CommentID int64
+	ParentID  *int64
+	ThreadKey string
+	Actor     string
+	Excerpt   string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+	// Attempts - число попыток отправки, включая текущую; заполняется только при выборке на отправку
+	Attempts int
+}
+
+type NotificationsRes struct {
+	Notifications []Notification
+	Total         int
+	Page          int
+	Limit         int
+	Pages         int
+}
+
+// Recipient - адресат доставки уведомления.
+type Recipient struct {
+	User  string
+	Email string
+}
@@ -0,0 +1,31 @@
+package models
+
+// QuoteExcerptLen - сколько символов цитируемого комментария отдается вместе с цитатой.
+const QuoteExcerptLen = 200
+
+// MaxQuotes - сколько комментариев можно процитировать в одном.
+const MaxQuotes = 10
+
+// Quote - ссылка на цитируемый комментарий того же треда. Если цитируемый комментарий удален
+// или скрыт модерацией, цитата становится «надгробием»: заполнены только CommentID и Deleted.
+type Quote struct {
+	CommentID  int64
+	Author     string
+	AuthorName string
+	Excerpt    string
+	Deleted    bool
+}
+
+// NewQuote собирает цитату из комментария с учетом удаления и модерации.
+func NewQuote(c *Comment) Quote {
+	if c.DeletedAt != nil || c.Status != StatusApproved {
+		return Quote{CommentID: c.ID, Deleted: true}
+	}
+
+	excerpt := []rune(c.Content)
+	if len(excerpt) > QuoteExcerptLen {
+		excerpt = excerpt[:QuoteExcerptLen]
+	}
+
+	return Quote{CommentID: c.ID, Author: c.Author, AuthorName: c.AuthorName, Excerpt: string(excerpt)}
+}
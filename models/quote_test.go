@@ -0,0 +1,47 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQuote(t *testing.T) {
+	deletedAt := time.Now()
+	long := strings.Repeat("я", QuoteExcerptLen+10)
+
+	tests := []struct {
+		name    string
+		comment *Comment
+		want    Quote
+	}{
+		{
+			name:    "видимый",
+			comment: &Comment{ID: 1, Author: "alice", AuthorName: "Алиса", Content: "текст", Status: StatusApproved},
+			want:    Quote{CommentID: 1, Author: "alice", AuthorName: "Алиса", Excerpt: "текст"},
+		},
+		{
+			name:    "длинный текст обрезается по символам",
+			comment: &Comment{ID: 2, Author: "bob", Content: long, Status: StatusApproved},
+			want:    Quote{CommentID: 2, Author: "bob", Excerpt: strings.Repeat("я", QuoteExcerptLen)},
+		},
+		{
+			name:    "удаленный - надгробие",
+			comment: &Comment{ID: 3, Author: "carol", AuthorName: "Кэрол", Content: "текст", Status: StatusApproved, DeletedAt: &deletedAt},
+			want:    Quote{CommentID: 3, Deleted: true},
+		},
+		{
+			name:    "отклоненный - надгробие",
+			comment: &Comment{ID: 4, Author: "dave", Content: "текст", Status: StatusRejected},
+			want:    Quote{CommentID: 4, Deleted: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewQuote(tt.comment))
+		})
+	}
+}
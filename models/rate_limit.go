@@ -0,0 +1,6 @@
+package models
+
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
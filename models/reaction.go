@@ -0,0 +1,9 @@
+package models
+
+// Reaction - сводка по одной реакции на комментарий: сколько пользователей ее поставили
+// и есть ли среди них текущий.
+type Reaction struct {
+	Emoji   string
+	Count   int
+	Reacted bool
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type Report struct {
+	ID        int64
+	CommentID int64
+	Reporter  string
+	Reason    string
+	CreatedAt time.Time
+}
+
+type ReportedComment struct {
+	Comment
+	Reports        int
+	LastReportedAt time.Time
+	Reasons        []string
+}
+
+type ReportedRes struct {
+	Comments []ReportedComment
+	Total    int
+	Page     int
+	Limit    int
+	Pages    int
+}
@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+// Режимы поиска: полнотекстовый (FTS) и нечеткий по триграммам, устойчивый к опечаткам.
+const (
+	SearchModeFTS   = "fts"
+	SearchModeFuzzy = "fuzzy"
+)
+
+// ValidSearchMode сообщает, поддерживается ли режим поиска; пустой режим - FTS с переходом
+// на нечеткий поиск, если ничего не найдено.
+func ValidSearchMode(mode string) bool {
+	switch mode {
+	case "", SearchModeFTS, SearchModeFuzzy:
+		return true
+	}
+	return false
+}
+
+// SearchQuery - запрос к полнотекстовому поиску; пустые фильтры не ограничивают выборку.
+type SearchQuery struct {
+	Query          string // синтаксис websearch_to_tsquery: "фраза", OR, -исключение
+	Author         string
+	From           time.Time
+	To             time.Time
+	Thread         string
+	Subtree        int64 // комментарий и все ответы под ним
+	IncludeDeleted bool
+	Viewer         *Actor
+	Page           int
+	Limit          int
+
+	Mode      string
+	Threshold float64 // порог сходства для нечеткого поиска, от 0 до 1
+}
+
+// Границы совпадения в SearchHit.Headline - символы из области частного использования Unicode,
+// чтобы их нельзя было спутать с текстом комментария при экранировании.
+const (
+	HighlightStart = "\uE000"
+	HighlightStop  = "\uE001"
+)
+
+// PathExcerptLen - сколько символов предка показывается в пути к найденному комментарию.
+const PathExcerptLen = 80
+
+// SearchHit - найденный комментарий. Headline - фрагменты текста с совпадениями между
+// HighlightStart и HighlightStop, Path - предки от корня до родителя.
+type SearchHit struct {
+	Comment  Comment
+	Rank     float64
+	Headline string
+	Path     []PathItem
+}
+
+// PathItem - предок найденного комментария. Удаленные и скрытые модерацией предки отдаются без текста.
+type PathItem struct {
+	ID         int64
+	Author     string
+	AuthorName string
+	Excerpt    string
+	Deleted    bool
+}
+
+func NewPathItem(c *Comment) PathItem {
+	if c.DeletedAt != nil || c.Status != StatusApproved {
+		return PathItem{ID: c.ID, Deleted: true}
+	}
+
+	excerpt := []rune(c.Content)
+	if len(excerpt) > PathExcerptLen {
+		excerpt = excerpt[:PathExcerptLen]
+	}
+
+	return PathItem{ID: c.ID, Author: c.Author, AuthorName: c.AuthorName, Excerpt: string(excerpt)}
+}
+
+// SearchDoc - комментарий для внешнего поискового индекса; Path - предки от корня до родителя.
+type SearchDoc struct {
+	Comment Comment
+	Path    []int64
+}
+
+// SearchRes - страница результатов; Mode - режим, которым они найдены.
+type SearchRes struct {
+	Hits  []SearchHit
+	Total int
+	Page  int
+	Limit int
+	Pages int
+	Mode  string
+}
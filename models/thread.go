@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type Thread struct {
+	Key           string
+	Premoderation bool
+	LockedAt      *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ThreadRedirect - запись о переезде комментариев треда FromKey в тред ToKey: при объединении
+// тредов CommentID = nil, при выделении ветки в новый тред - корень выделенной ветки.
+type ThreadRedirect struct {
+	FromKey   string
+	ToKey     string
+	CommentID *int64
+	CreatedAt time.Time
+}
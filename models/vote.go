@@ -0,0 +1,17 @@
+package models
+
+// Значения голоса: «за», «против» и отзыв голоса.
+const (
+	VoteUp   = 1
+	VoteDown = -1
+	VoteNone = 0
+)
+
+// VoteResult - счетчики комментария после голосования и текущий голос пользователя.
+type VoteResult struct {
+	CommentID int64
+	Upvotes   int
+	Downvotes int
+	Score     int
+	Vote      int
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+)
+
+// Типы событий outbox, на которые подписываются вебхуки.
+const (
+	OutboxCommentCreated  = "comment.created"
+	OutboxCommentEdited   = "comment.edited"
+	OutboxCommentDeleted  = "comment.deleted"
+	OutboxCommentRestored = "comment.restored"
+	OutboxCommentApproved = "comment.approved"
+	OutboxCommentRejected = "comment.rejected"
+	OutboxCommentHidden   = "comment.hidden"
+	OutboxCommentPinned   = "comment.pinned"
+	OutboxCommentUnpinned = "comment.unpinned"
+	OutboxCommentMoved    = "comment.moved"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// Webhook - подписчик. Пустой Events означает подписку на все события.
+type Webhook struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	EventID        int64
+	EventType      string
+	Payload        json.RawMessage
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeliveredAt    *time.Time
+
+	// Заполняются при выборке доставок к отправке
+	URL    string
+	Secret string
+}
+
+// WebhookRequest - подписанный HTTP-запрос к подписчику.
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID int64
+	EventType  string
+	Body       []byte
+}
+
+type DeliveryFilter struct {
+	WebhookID int64
+	Status    DeliveryStatus
+	Page      int
+	Limit     int
+}
+
+type DeliveriesRes struct {
+	Deliveries []WebhookDelivery
+	Total      int
+	Page       int
+	Limit      int
+	Pages      int
+}
+
+// PublicIP сообщает, можно ли отправлять вебхуки на адрес ip. Loopback, частные, link-local
+// и служебные адреса закрыты, чтобы через вебхук нельзя было обращаться к внутренней сети сервиса.
+func PublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
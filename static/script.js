@@ -1,5 +1,7 @@
 let currentParentId = null; // Загружаем все корневые комментарии
 let searchQuery = '';
+const pageParams = new URLSearchParams(window.location.search);
+let currentThread = pageParams.get('thread') || ''; // Тред из ссылки на результат поиска
 
 // Загрузка корневых комментариев
 async function loadComments() {
@@ -13,6 +15,9 @@ async function loadComments() {
         if (searchQuery) {
             params.append('search', searchQuery);
         }
+        if (currentThread) {
+            params.append('thread', currentThread);
+        }
 
         console.log('Отправляем запрос:', `/comments?${params}`);
         
@@ -47,10 +52,8 @@ function displayRootComments(comments) {
         return;
     }
     
-    // Сортируем по времени создания
-    const sortedComments = comments.sort((a, b) => {
-        return new Date(a.created_at) - new Date(b.created_at);
-    });
+    // Закрепленные первыми, остальные по времени создания
+    const sortedComments = comments.sort(compareComments);
     
     container.innerHTML = sortedComments.map((comment, index) => {
         console.log('Создаем комментарий с ID:', comment.id);
@@ -58,10 +61,12 @@ function displayRootComments(comments) {
         return `
             <div class="root-comment" data-comment-id="${comment.id}">
                 <div class="comment-header">
-                    <span class="comment-author">${escapeHtml(comment.author)}</span>
+                    <span class="comment-author">${escapeHtml(comment.author_name || comment.author)}</span>
                     <span class="comment-date">${formatDate(comment.created_at)}</span>
+                    ${comment.pinned_at ? '<span class="comment-pinned">📌 Закреплено</span>' : ''}
                 </div>
-                <div class="comment-content">${escapeHtml(comment.content)}</div>
+                <div class="comment-content">${renderContent(comment)}</div>
+                ${renderReactions(comment)}
                 <div class="comment-actions">
                     <button class="show-replies-btn" onclick="loadReplies(${comment.id}, ${index})">
                         Показать ответы
@@ -118,18 +123,19 @@ function displayReplies(parentId, replies) {
         return;
     }
     
-    // Сортируем по времени создания
-    const sortedReplies = replies.sort((a, b) => {
-        return new Date(a.created_at) - new Date(b.created_at);
-    });
+    // Закрепленные первыми, остальные по времени создания
+    const sortedReplies = replies.sort(compareComments);
     
     container.innerHTML = sortedReplies.map((reply, index) => `
-        <div class="reply level-${reply.level || 0}" data-reply-id="${reply.id}">
+        <div class="reply level-${reply.level || 0}${reply.accepted ? ' reply-accepted' : ''}" data-reply-id="${reply.id}">
             <div class="comment-header">
-                <span class="comment-author">${escapeHtml(reply.author)}</span>
+                <span class="comment-author">${escapeHtml(reply.author_name || reply.author)}</span>
                 <span class="comment-date">${formatDate(reply.created_at)}</span>
+                ${reply.pinned_at ? '<span class="comment-pinned">📌 Закреплено</span>' : ''}
+                ${reply.accepted ? '<span class="comment-accepted">✔ Принятый ответ</span>' : ''}
             </div>
-            <div class="comment-content">${escapeHtml(reply.content)}</div>
+            <div class="comment-content">${renderContent(reply)}</div>
+            ${renderReactions(reply)}
             <div class="comment-actions">
                 <button class="reply-btn" onclick="replyToComment(${reply.id})">Ответить</button>
             </div>
@@ -349,40 +355,21 @@ async function loadCommentsWithSearch() {
     try {
         console.log('Загружаем комментарии с поиском:', searchQuery);
         
-        // Сначала ищем по корневым комментариям
-        const rootParams = new URLSearchParams({
-            parent: 0,
-            search: searchQuery
-        });
-
-        const rootResponse = await fetch(`/comments?${rootParams}`);
-        const rootData = await rootResponse.json();
-        
-        console.log('Результаты поиска по корневым:', rootData);
-        
-        // Затем ищем по всем комментариям
-        const allParams = new URLSearchParams({
-            parent: 1,
-            search: searchQuery
+        const params = new URLSearchParams({
+            q: searchQuery,
+            limit: 50
         });
 
-        const allResponse = await fetch(`/comments?${allParams}`);
-        const allData = await allResponse.json();
-        
-        console.log('Результаты поиска по всем:', allData);
-        
-        // Объединяем результаты
-        const allResults = [...(rootData.comments || []), ...(allData.comments || [])];
-        
-        // Убираем дубликаты по ID
-        const uniqueResults = allResults.filter((comment, index, self) => 
-            index === self.findIndex(c => c.id === comment.id)
-        );
+        const response = await fetch(`/search?${params}`);
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || 'Ошибка поиска');
+        }
         
-        console.log('Объединенные результаты:', uniqueResults);
+        console.log('Результаты поиска:', data);
         
         // Показываем результаты поиска
-        displaySearchResults(uniqueResults);
+        displaySearchResults(data.results);
         
     } catch (error) {
         console.error('Ошибка поиска:', error);
@@ -400,19 +387,18 @@ function displaySearchResults(comments) {
         return;
     }
     
-    // Сортируем по времени создания
-    const sortedComments = comments.sort((a, b) => {
-        return new Date(a.created_at) - new Date(b.created_at);
-    });
-    
-    container.innerHTML = sortedComments.map((comment, index) => `
-        <div class="search-result level-${comment.level || 0}" data-comment-id="${comment.id}">
+    // Сервер уже отсортировал результаты по релевантности
+    container.innerHTML = comments.map(comment => `
+        <div class="search-result" data-comment-id="${comment.id}">
             <div class="comment-header">
-                <span class="comment-author">${escapeHtml(comment.author)}</span>
+                <span class="comment-author">${escapeHtml(comment.author_name || comment.author)}</span>
                 <span class="comment-date">${formatDate(comment.created_at)}</span>
-                <span class="search-level">Уровень: ${comment.level || 0}</span>
+                <a class="search-level" href="${searchResultLink(comment)}">Тред: ${escapeHtml(comment.thread)}</a>
             </div>
-            <div class="comment-content">${escapeHtml(comment.content)}</div>
+            ${renderSearchPath(comment.path)}
+            <!-- headline уже экранирован сервером, совпадения размечены <mark> -->
+            <div class="comment-content search-headline">${comment.headline || renderContent(comment)}</div>
+            ${renderReactions(comment)}
             <div class="comment-actions">
                 <button class="reply-btn" onclick="replyToComment(${comment.id})">Ответить</button>
             </div>
@@ -420,6 +406,46 @@ function displaySearchResults(comments) {
     `).join('');
 }
 
+// Ссылка на найденный комментарий: тред, корень его ветки и якорь
+function searchResultLink(comment) {
+    const rootId = comment.path && comment.path.length > 0 ? comment.path[0].id : comment.id;
+    return `/?thread=${encodeURIComponent(comment.thread)}&root=${rootId}#comment-${comment.id}`;
+}
+
+// Цепочка предков от корня до родителя
+function renderSearchPath(path) {
+    if (!path || path.length === 0) {
+        return '';
+    }
+
+    const items = path.map(item => item.deleted
+        ? '<span class="search-path-item search-path-deleted">[удалено]</span>'
+        : `<span class="search-path-item"><b>${escapeHtml(item.author_name || item.author)}</b>: ${escapeHtml(item.excerpt)}</span>`
+    );
+
+    return `<div class="search-path">${items.join(' › ')}</div>`;
+}
+
+// Раскрытие ветки и прокрутка к комментарию из ссылки на результат поиска
+async function focusLinkedComment() {
+    const match = window.location.hash.match(/^#comment-(\d+)$/);
+    if (!match) {
+        return;
+    }
+
+    const id = match[1];
+    const root = pageParams.get('root');
+    if (root && root !== id) {
+        await loadReplies(Number(root));
+    }
+
+    const element = document.querySelector(`[data-comment-id="${id}"], [data-reply-id="${id}"]`);
+    if (element) {
+        element.classList.add('comment-focused');
+        element.scrollIntoView({ behavior: 'smooth', block: 'center' });
+    }
+}
+
 // Очистка поиска
 function clearSearch() {
     document.getElementById('searchInput').value = '';
@@ -429,6 +455,54 @@ function clearSearch() {
 }
 
 // Вспомогательные функции
+
+function compareComments(a, b) {
+    if (Boolean(a.pinned_at) !== Boolean(b.pinned_at)) {
+        return a.pinned_at ? -1 : 1;
+    }
+    if (a.pinned_at && b.pinned_at) {
+        return new Date(a.pinned_at) - new Date(b.pinned_at);
+    }
+    return new Date(a.created_at) - new Date(b.created_at);
+}
+
+// content_html уже очищен сервером; для старых ответов без него показываем текст как есть
+function renderContent(comment) {
+    return renderQuotes(comment.quotes) + (comment.content_html || escapeHtml(comment.content));
+}
+
+// Цитаты показываются над текстом; у удаленных комментариев остается только отметка
+function renderQuotes(quotes) {
+    if (!quotes || quotes.length === 0) {
+        return '';
+    }
+
+    return quotes.map(quote => {
+        if (quote.deleted) {
+            return `<div class="comment-quote deleted">Цитируемый комментарий #${quote.id} удален</div>`;
+        }
+        return `
+            <div class="comment-quote">
+                <span class="comment-author">${escapeHtml(quote.author_name || quote.author)}</span>
+                <div>${escapeHtml(quote.excerpt)}</div>
+            </div>
+        `;
+    }).join('');
+}
+
+// Реакции только показываются: ставить их могут клиенты с API-ключом пользователя
+function renderReactions(comment) {
+    if (!comment.reactions || comment.reactions.length === 0) {
+        return '';
+    }
+
+    const items = comment.reactions.map(reaction =>
+        `<span class="reaction${reaction.reacted ? ' reacted' : ''}">${escapeHtml(reaction.emoji)} ${reaction.count}</span>`
+    ).join('');
+
+    return `<div class="comment-reactions">${items}</div>`;
+}
+
 function escapeHtml(text) {
     const div = document.createElement('div');
     div.textContent = text;
@@ -452,5 +526,30 @@ function showMessage(message, type) {
     }, 3000);
 }
 
+// Живые обновления: при любом изменении перезагружаем дерево (кроме режима поиска)
+let reloadTimer = null;
+
+function subscribeToUpdates() {
+    if (!window.EventSource) {
+        return;
+    }
+
+    const source = new EventSource('/comments/stream');
+    const scheduleReload = () => {
+        if (searchQuery) {
+            return;
+        }
+        clearTimeout(reloadTimer);
+        reloadTimer = setTimeout(loadComments, 300);
+    };
+
+    ['created', 'edited', 'deleted', 'restored', 'reset'].forEach(type => {
+        source.addEventListener(type, scheduleReload);
+    });
+}
+
 // Загрузка при старте
-document.addEventListener('DOMContentLoaded', loadComments);
\ No newline at end of file
+document.addEventListener('DOMContentLoaded', () => {
+    loadComments().then(focusLinkedComment);
+    subscribeToUpdates();
+});
\ No newline at end of file